@@ -49,7 +49,10 @@ func GenerateAgents(topic string) (string, error) {
 	Follow these examples to create 10 agents for the %s field.
 	Format the response as valid JSON only, no additional text.`, topic, string(physicsData), string(biologyData), topic)
 
-	response := GenerateLLMResponse(prompt)
+	response, err := GenerateLLMResponseE(prompt)
+	if err != nil {
+		return "", err
+	}
 
 	log.Println("Generated agents: ", response)
 
@@ -65,8 +68,20 @@ func GenerateAgents(topic string) (string, error) {
 		return "", fmt.Errorf("failed to create examples directory: %v", err)
 	}
 
-	// Replace spaces with underscores in the topic name
-	sanitizedTopic := strings.ReplaceAll(topic, " ", "_")
+	// Keep only filename-safe characters so the topic can't escape the examples directory
+	sanitizedTopic := strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '_'
+		case r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return -1
+		}
+	}, topic)
+	if sanitizedTopic == "" {
+		sanitizedTopic = "agents"
+	}
 	filename := fmt.Sprintf("examples/%s.json", sanitizedTopic)
 	if err := os.WriteFile(filename, []byte(response), 0644); err != nil {
 		return "", fmt.Errorf("failed to write agents file: %v", err)
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -17,15 +18,16 @@ import (
 	openai "github.com/sashabaranov/go-openai"
 )
 
-var client *openai.Client
-
 func init() {
+	// OPENAI_API_BASE points the default provider at an OpenAI-compatible gateway,
+	// which may not need a key
 	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
+	baseURL := os.Getenv("OPENAI_API_BASE")
+	if apiKey == "" && baseURL == "" {
 		log.Println("Warning: OPENAI_API_KEY not set, using mock responses")
 		return
 	}
-	client = openai.NewClient(apiKey)
+	SetProvider(NewOpenAIProvider(apiKey, baseURL))
 
 	if os.Getenv("SERP_API_KEY") == "" {
 		log.Println("Warning: SERP_API_KEY not set, web search will be disabled")
@@ -57,10 +59,18 @@ type ResearchDecision struct {
 
 // LLMConfig holds configuration for LLM interactions
 type LLMConfig struct {
-	Model       string
-	MaxTokens   int
-	Temperature float32
-	StopTokens  []string
+	Model        string
+	MaxTokens    int
+	Temperature  float32
+	StopTokens   []string
+	SystemPrompt string // Optional instructions sent ahead of the prompt
+
+	// Budget, if set, is consulted before every request to the provider, including retries
+	// and research decisions. An error stops the request and is returned to the caller.
+	Budget func() error
+
+	// Trace tags the exchanges this config's requests leave in the trace (see GetTrace)
+	Trace TraceTag
 }
 
 // SearchConfig holds configuration for web search
@@ -145,26 +155,12 @@ func (p *Personality) GenerateBlockAnnouncement(block core.Block) string {
 	return response
 }
 
-// queryLLM sends a request to OpenAI's API
+// queryLLM sends a free-form producer prompt to the LLM provider
 func queryLLM(prompt string) (string, error) {
-	if client == nil {
-		return "", fmt.Errorf("OpenAI client not initialized")
-	}
-
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model: openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{Role: openai.ChatMessageRoleSystem, Content: "You are a chaotic blockchain producer."},
-				{Role: openai.ChatMessageRoleUser, Content: prompt},
-			},
-		},
-	)
-	if err != nil {
-		return "", err
-	}
-	return resp.Choices[0].Message.Content, nil
+	return complete(context.Background(), prompt, LLMConfig{
+		Model:        openai.GPT3Dot5Turbo,
+		SystemPrompt: "You are a chaotic blockchain producer.",
+	})
 }
 
 // formatTransactions formats transactions for AI prompt
@@ -199,70 +195,100 @@ func randomSelection(txs []core.Transaction) []core.Transaction {
 	return txs[:rand.Intn(len(txs))]
 }
 
-// GenerateLLMResponse generates a response using OpenAI's GPT model
+// Errors surfaced by GenerateLLMResponseE and the structured response functions.
+// Check them with errors.Is; the wrapped error carries the details.
+var (
+	ErrLLMRequestFailed   = errors.New("LLM request failed")               // The provider couldn't be reached or refused the request
+	ErrInvalidLLMResponse = errors.New("LLM returned an invalid response") // The reply wasn't the JSON that was asked for
+)
+
+// GenerateLLMResponse generates a response using OpenAI's GPT model.
+// It returns an empty string on any failure; use GenerateLLMResponseE to find out why.
 func GenerateLLMResponse(prompt string) string {
-	return generateLLMResponseWithOptions(prompt, false, "", []string{}, DefaultLLMConfig())
+	response, _ := GenerateLLMResponseE(prompt)
+	return response
+}
+
+// GenerateLLMResponseE works like GenerateLLMResponse but reports failures, wrapping
+// ErrLLMRequestFailed for transport errors and ErrInvalidLLMResponse for malformed replies
+func GenerateLLMResponseE(prompt string) (string, error) {
+	response, _, err := generateLLMResponseWithOptions(prompt, false, "", []string{}, DefaultLLMConfig())
+	return response, err
 }
 
 // GenerateLLMResponseWithResearch generates a response using OpenAI's GPT model with web research capability
 func GenerateLLMResponseWithResearch(prompt string, topic string, traits []string) string {
-	return generateLLMResponseWithOptions(prompt, true, topic, traits, DefaultLLMConfig())
+	response, _, _ := generateLLMResponseWithOptions(prompt, true, topic, traits, DefaultLLMConfig())
+	return response
 }
 
-// generateLLMResponseWithOptions is the internal implementation that handles both research and non-research cases
-func generateLLMResponseWithOptions(prompt string, allowResearch bool, topic string, traits []string, config LLMConfig) string {
-	client := openai.NewClient(os.Getenv("OPENAI_API_KEY"))
+// GenerateLLMResponseWithConfig generates a response using the given LLM configuration
+func GenerateLLMResponseWithConfig(prompt string, config LLMConfig) string {
+	response, _, _ := generateLLMResponseWithOptions(prompt, false, "", []string{}, config)
+	return response
+}
 
-	// Only perform research if allowed and needed
-	if allowResearch && strings.Contains(prompt, "Block details:") {
-		decision, err := decideResearch(topic, traits)
-		if err == nil && decision.NeedsResearch {
-			var researchContext strings.Builder
-			researchContext.WriteString("\nRelevant research findings:\n")
-
-			for _, query := range decision.SearchQueries {
-				results, err := performWebSearch(query, DefaultSearchConfig())
-				if err == nil {
-					for _, result := range results {
-						researchContext.WriteString(fmt.Sprintf("- %s\n  %s\n", result.Title, result.Snippet))
-					}
-				}
-			}
+// GenerateLLMResponseWithResearchInfo works like GenerateLLMResponseWithResearch but takes the
+// LLM configuration and also reports whether web research findings were added to the prompt
+func GenerateLLMResponseWithResearchInfo(prompt string, topic string, traits []string, config LLMConfig) (string, bool) {
+	response, researched, _ := generateLLMResponseWithOptions(prompt, true, topic, traits, config)
+	return response, researched
+}
 
-			// Add research findings to the prompt
-			prompt = strings.Replace(prompt, "Block details:",
-				researchContext.String()+"\nBlock details:", 1)
-		}
-	}
+// generateLLMResponseWithOptions is the internal implementation that handles both research and non-research cases.
+// The returned flag is true when research findings informed the response.
+func generateLLMResponseWithOptions(prompt string, allowResearch bool, topic string, traits []string, config LLMConfig) (string, bool, error) {
+	researched := false
 
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model: config.Model,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
-			MaxTokens:   config.MaxTokens,
-			Temperature: config.Temperature,
-			Stop:        config.StopTokens,
-		},
-	)
+	// Only perform research if allowed and needed
+	if allowResearch {
+		prompt, researched = addResearch(prompt, topic, traits, config)
+	}
 
+	response, err := complete(context.Background(), prompt, config)
 	if err != nil {
-		return ""
+		return "", false, fmt.Errorf("%w: %w", ErrLLMRequestFailed, err)
 	}
-
-	response := resp.Choices[0].Message.Content
+	response = sanitizeJSON(response)
 
 	var jsonTest interface{}
 	if err := json.Unmarshal([]byte(response), &jsonTest); err != nil {
-		return ""
+		return "", false, fmt.Errorf("%w: %v", ErrInvalidLLMResponse, err)
 	}
 
-	return response
+	return response, researched, nil
+}
+
+// addResearch lets the agent decide whether the topic needs web research and, if so, inserts
+// the findings ahead of the block details. The flag is true when findings were added. The
+// research decision is charged to config's budget and traced under its tag.
+func addResearch(prompt string, topic string, traits []string, config LLMConfig) (string, bool) {
+	if !strings.Contains(prompt, "Block details:") {
+		return prompt, false
+	}
+	decision, err := decideResearch(topic, traits, config.Budget, config.Trace)
+	if err != nil || !decision.NeedsResearch {
+		return prompt, false
+	}
+
+	researched := false
+	var researchContext strings.Builder
+	researchContext.WriteString("\nRelevant research findings:\n")
+
+	for _, query := range decision.SearchQueries {
+		results, err := performWebSearch(query, DefaultSearchConfig())
+		if err == nil {
+			for _, result := range results {
+				researchContext.WriteString(fmt.Sprintf("- %s\n  %s\n", result.Title, result.Snippet))
+				researched = true
+			}
+		}
+	}
+
+	// Add research findings to the prompt
+	prompt = strings.Replace(prompt, "Block details:",
+		researchContext.String()+"\nBlock details:", 1)
+	return prompt, researched
 }
 
 // SignBlock generates a cryptographic hash signature for a block
@@ -308,7 +334,7 @@ func performWebSearch(query string, config SearchConfig) ([]SearchResult, error)
 	return searchResults, nil
 }
 
-func decideResearch(topic string, traits []string) (*ResearchDecision, error) {
+func decideResearch(topic string, traits []string, budget func() error, tag TraceTag) (*ResearchDecision, error) {
 	prompt := fmt.Sprintf(`You are an AI agent with these traits: %v
 	
 	You need to analyze this topic: "%s"
@@ -326,7 +352,14 @@ func decideResearch(topic string, traits []string) (*ResearchDecision, error) {
 		"reasoning": "Explain why you do or don't need research"
 	}`, traits, topic)
 
-	response := GenerateLLMResponse(prompt)
+	config := DefaultLLMConfig()
+	config.Budget = budget
+	config.Trace = tag
+	config.Trace.CallType = LLMCallResearch
+	response, _, err := generateLLMResponseWithOptions(prompt, false, "", nil, config)
+	if err != nil {
+		return nil, err
+	}
 
 	var decision ResearchDecision
 	if err := json.Unmarshal([]byte(response), &decision); err != nil {
@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failingProvider fails every request as if the provider were unreachable
+type failingProvider struct{}
+
+func (failingProvider) Complete(ctx context.Context, prompt string, config LLMConfig) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+func TestGenerateLLMResponseEDistinguishesFailures(t *testing.T) {
+	withProvider(t, failingProvider{})
+	if _, err := GenerateLLMResponseE("Vote."); !errors.Is(err, ErrLLMRequestFailed) {
+		t.Errorf("transport failure reported as %v, want ErrLLMRequestFailed", err)
+	}
+
+	withProvider(t, &scriptedProvider{responses: []string{"no JSON here"}})
+	if _, err := GenerateLLMResponseE("Vote."); !errors.Is(err, ErrInvalidLLMResponse) {
+		t.Errorf("malformed reply reported as %v, want ErrInvalidLLMResponse", err)
+	}
+
+	withProvider(t, &scriptedProvider{responses: []string{`{"stance": "SUPPORT"}`}})
+	response, err := GenerateLLMResponseE("Vote.")
+	if err != nil || response != `{"stance": "SUPPORT"}` {
+		t.Errorf("got %q, %v", response, err)
+	}
+	if GenerateLLMResponse("Vote.") != response {
+		t.Error("GenerateLLMResponse should return the same response")
+	}
+}
+
+// blockingProvider counts concurrent requests and holds each until released
+type blockingProvider struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	release  chan struct{}
+}
+
+func (p *blockingProvider) Complete(ctx context.Context, prompt string, config LLMConfig) (string, error) {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.peak {
+		p.peak = p.inFlight
+	}
+	p.mu.Unlock()
+
+	<-p.release
+
+	p.mu.Lock()
+	p.inFlight--
+	p.mu.Unlock()
+	return `{}`, nil
+}
+
+func TestLLMRequestsAreBoundedByMaxConcurrent(t *testing.T) {
+	defer SetMaxConcurrentLLM(MaxConcurrentLLM())
+	SetMaxConcurrentLLM(2)
+
+	p := &blockingProvider{release: make(chan struct{})}
+	withProvider(t, p)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GenerateLLMResponse("Vote.")
+		}()
+	}
+	// Wait until the limit is reached, then let the requests through one at a time
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		p.mu.Lock()
+		inFlight := p.inFlight
+		p.mu.Unlock()
+		if inFlight == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d requests in flight", inFlight)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 6; i++ {
+		p.release <- struct{}{}
+	}
+	wg.Wait()
+
+	if p.peak != 2 {
+		t.Fatalf("peak concurrency %d, want 2", p.peak)
+	}
+}
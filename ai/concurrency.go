@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// DefaultMaxConcurrentLLM bounds in-flight LLM requests so many validators discussing at
+// once don't run into provider rate limits. Requests past the limit wait for a free slot.
+const DefaultMaxConcurrentLLM = 5
+
+var (
+	llmSlots   = make(chan struct{}, maxConcurrentLLMFromEnv())
+	llmSlotsMu sync.RWMutex
+)
+
+// maxConcurrentLLMFromEnv reads LLM_MAX_CONCURRENT, falling back to the default
+func maxConcurrentLLMFromEnv() int {
+	value := os.Getenv("LLM_MAX_CONCURRENT")
+	if value == "" {
+		return DefaultMaxConcurrentLLM
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		log.Printf("Invalid LLM_MAX_CONCURRENT %q, using default of %d", value, DefaultMaxConcurrentLLM)
+		return DefaultMaxConcurrentLLM
+	}
+	return max
+}
+
+// SetMaxConcurrentLLM changes how many LLM requests may be in flight at once. Requests
+// already running finish under the old limit. Non-positive values restore the default.
+func SetMaxConcurrentLLM(max int) {
+	if max <= 0 {
+		max = DefaultMaxConcurrentLLM
+	}
+	llmSlotsMu.Lock()
+	defer llmSlotsMu.Unlock()
+	llmSlots = make(chan struct{}, max)
+}
+
+// MaxConcurrentLLM returns the current limit on in-flight LLM requests
+func MaxConcurrentLLM() int {
+	llmSlotsMu.RLock()
+	defer llmSlotsMu.RUnlock()
+	return cap(llmSlots)
+}
+
+// acquireLLMSlot waits for a free request slot and returns the function that frees it
+func acquireLLMSlot(ctx context.Context) (func(), error) {
+	llmSlotsMu.RLock()
+	slots := llmSlots
+	llmSlotsMu.RUnlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// LLMProvider answers prompts for agents and validators. Register one with SetProvider to
+// use a backend other than OpenAI, such as Ollama or Anthropic.
+type LLMProvider interface {
+	Complete(ctx context.Context, prompt string, config LLMConfig) (string, error)
+}
+
+// namedProvider is implemented by providers that report a name for decision provenance
+type namedProvider interface {
+	Name() string
+}
+
+var (
+	provider   LLMProvider
+	providerMu sync.RWMutex
+)
+
+// SetProvider replaces the provider used for every LLM call. Passing nil disables LLM
+// calls, leaving callers on their mock or fallback responses.
+func SetProvider(p LLMProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	provider = p
+}
+
+func currentProvider() LLMProvider {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	return provider
+}
+
+// complete sends a prompt to the registered provider
+func complete(ctx context.Context, prompt string, config LLMConfig) (string, error) {
+	p := currentProvider()
+	if p == nil {
+		return "", fmt.Errorf("no LLM provider configured")
+	}
+	if config.Budget != nil {
+		if err := config.Budget(); err != nil {
+			return "", err
+		}
+	}
+
+	release, err := acquireLLMSlot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	start := time.Now()
+	response, err := p.Complete(ctx, prompt, config)
+	notifyCallListeners(LLMCall{CallType: config.Trace.CallType, Duration: time.Since(start), Err: err})
+	recordTrace(config.Trace, prompt, response, err)
+	return response, err
+}
+
+// LLMCall describes a finished request to the provider
+type LLMCall struct {
+	CallType string // From the request's TraceTag, empty if untagged
+	Duration time.Duration
+	Err      error
+}
+
+var (
+	callListeners   []func(LLMCall)
+	callListenersMu sync.RWMutex
+)
+
+// OnLLMCall registers a listener called after every request to the provider
+func OnLLMCall(listener func(LLMCall)) {
+	callListenersMu.Lock()
+	defer callListenersMu.Unlock()
+	callListeners = append(callListeners, listener)
+}
+
+func notifyCallListeners(call LLMCall) {
+	callListenersMu.RLock()
+	listeners := make([]func(LLMCall), len(callListeners))
+	copy(listeners, callListeners)
+	callListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(call)
+	}
+}
+
+// ProviderName returns the name of the LLM provider answering requests
+func ProviderName() string {
+	switch p := currentProvider().(type) {
+	case nil:
+		return "none"
+	case namedProvider:
+		return p.Name()
+	default:
+		return fmt.Sprintf("%T", p)
+	}
+}
+
+// OpenAIProvider sends prompts to the OpenAI chat completions API, or to any
+// OpenAI-compatible gateway when created with a base URL
+type OpenAIProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAIProvider creates a provider for the given API key. An empty baseURL uses OpenAI itself.
+func NewOpenAIProvider(apiKey string, baseURL string) *OpenAIProvider {
+	config := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	return &OpenAIProvider{client: openai.NewClientWithConfig(config)}
+}
+
+// Name identifies the provider in decision provenance
+func (p *OpenAIProvider) Name() string {
+	return "openai"
+}
+
+// Complete sends the prompt as a single user message, preceded by the config's system prompt if set
+func (p *OpenAIProvider) Complete(ctx context.Context, prompt string, config LLMConfig) (string, error) {
+	var messages []openai.ChatCompletionMessage
+	if config.SystemPrompt != "" {
+		messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleSystem, Content: config.SystemPrompt})
+	}
+	messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: prompt})
+
+	resp, err := p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       config.Model,
+		Messages:    messages,
+		MaxTokens:   config.MaxTokens,
+		Temperature: config.Temperature,
+		Stop:        config.StopTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in completion response")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,34 @@
+package ai
+
+import "strings"
+
+// sanitizeJSON extracts the JSON payload from an LLM reply, dropping markdown code fences
+// and any prose before the first '{' or '[' or after the last '}' or ']'. Replies without
+// a JSON payload are returned trimmed but otherwise unchanged.
+func sanitizeJSON(s string) string {
+	s = strings.TrimSpace(s)
+
+	// ```json ... ``` or ``` ... ```
+	if strings.HasPrefix(s, "```") {
+		if newline := strings.IndexByte(s, '\n'); newline >= 0 {
+			s = s[newline+1:]
+		} else {
+			s = strings.TrimPrefix(s, "```")
+		}
+		s = strings.TrimSuffix(strings.TrimSpace(s), "```")
+	}
+
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return strings.TrimSpace(s)
+	}
+	closer := "}"
+	if s[start] == '[' {
+		closer = "]"
+	}
+	end := strings.LastIndex(s, closer)
+	if end < start {
+		return strings.TrimSpace(s[start:])
+	}
+	return s[start : end+1]
+}
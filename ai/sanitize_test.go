@@ -0,0 +1,23 @@
+package ai
+
+import "testing"
+
+func TestSanitizeJSON(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"clean object", `{"stance": "SUPPORT"}`, `{"stance": "SUPPORT"}`},
+		{"clean array", `[{"name": "Ada"}]`, `[{"name": "Ada"}]`},
+		{"json fence", "```json\n{\"stance\": \"OPPOSE\"}\n```", `{"stance": "OPPOSE"}`},
+		{"bare fence", "```\n[1, 2]\n```", `[1, 2]`},
+		{"prose prefix", `Sure! Here is my vote: {"stance": "SUPPORT"}`, `{"stance": "SUPPORT"}`},
+		{"prose around fence", "Here you go:\n```json\n{\"a\": {\"b\": 1}}\n```\nHope that helps!", `{"a": {"b": 1}}`},
+		{"no json", "I cannot answer that.", "I cannot answer that."},
+		{"empty", "  ", ""},
+	}
+	for _, tt := range tests {
+		if got := sanitizeJSON(tt.in); got != tt.want {
+			t.Errorf("%s: sanitizeJSON(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
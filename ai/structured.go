@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// StructuredAttempts is how many times a structured response is requested before giving up
+const StructuredAttempts = 3
+
+// Appended to the prompt when retrying after a reply that didn't parse
+const invalidJSONReprompt = `
+
+Your previous reply was not valid JSON. Reply with only the JSON object requested above, with no other text.`
+
+// GenerateStructuredResponse asks the LLM for a JSON reply and decodes it into out, retrying
+// when the reply doesn't parse. It returns an error only once every attempt has failed.
+func GenerateStructuredResponse(prompt string, out interface{}) error {
+	return GenerateStructuredResponseWithConfig(prompt, DefaultLLMConfig(), out)
+}
+
+// GenerateStructuredResponseWithConfig works like GenerateStructuredResponse with the given LLM configuration
+func GenerateStructuredResponseWithConfig(prompt string, config LLMConfig, out interface{}) error {
+	return generateStructured(prompt, config, out)
+}
+
+// GenerateStructuredResponseWithResearch works like GenerateStructuredResponseWithConfig but first
+// lets the agent research the topic, reporting whether findings were added to the prompt.
+// Research runs once; retries reuse the researched prompt.
+func GenerateStructuredResponseWithResearch(prompt string, topic string, traits []string, config LLMConfig, out interface{}) (bool, error) {
+	prompt, researched := addResearch(prompt, topic, traits, config)
+	return researched, generateStructured(prompt, config, out)
+}
+
+func generateStructured(prompt string, config LLMConfig, out interface{}) error {
+	var lastErr error
+	for attempt := 1; attempt <= StructuredAttempts; attempt++ {
+		attemptPrompt := prompt
+		if attempt > 1 {
+			attemptPrompt += invalidJSONReprompt
+		}
+
+		response, err := complete(context.Background(), attemptPrompt, config)
+		if err != nil {
+			// Provider failures aren't fixed by re-prompting
+			return fmt.Errorf("%w: %w", ErrLLMRequestFailed, err)
+		}
+		response = sanitizeJSON(response)
+		if response == "" {
+			lastErr = fmt.Errorf("empty response")
+		} else if lastErr = json.Unmarshal([]byte(response), out); lastErr == nil {
+			return nil
+		}
+		log.Printf("LLM reply attempt %d/%d did not parse: %v", attempt, StructuredAttempts, lastErr)
+	}
+	return fmt.Errorf("%w: no valid JSON after %d attempts: %v", ErrInvalidLLMResponse, StructuredAttempts, lastErr)
+}
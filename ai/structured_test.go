@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// scriptedProvider replies with each response in turn and records the prompts it was sent
+type scriptedProvider struct {
+	responses []string
+	prompts   []string
+}
+
+func (p *scriptedProvider) Complete(ctx context.Context, prompt string, config LLMConfig) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	response := p.responses[0]
+	if len(p.responses) > 1 {
+		p.responses = p.responses[1:]
+	}
+	return response, nil
+}
+
+func withProvider(t *testing.T, p LLMProvider) {
+	previous := currentProvider()
+	SetProvider(p)
+	t.Cleanup(func() { SetProvider(previous) })
+}
+
+func TestGenerateStructuredResponseRetriesInvalidJSON(t *testing.T) {
+	p := &scriptedProvider{responses: []string{"Sure! Here is my answer:", `{"stance": "SUPPORT", "reason": "solid"}`}}
+	withProvider(t, p)
+
+	var out struct {
+		Stance string `json:"stance"`
+	}
+	if err := GenerateStructuredResponse("Vote.", &out); err != nil {
+		t.Fatalf("expected the retry to succeed: %v", err)
+	}
+	if out.Stance != "SUPPORT" {
+		t.Errorf("stance = %q, want SUPPORT", out.Stance)
+	}
+	if len(p.prompts) != 2 || !strings.Contains(p.prompts[1], "not valid JSON") {
+		t.Errorf("expected one re-prompt mentioning invalid JSON, got %q", p.prompts)
+	}
+}
+
+func TestGenerateStructuredResponseGivesUp(t *testing.T) {
+	p := &scriptedProvider{responses: []string{"not json"}}
+	withProvider(t, p)
+
+	var out map[string]interface{}
+	if err := GenerateStructuredResponse("Vote.", &out); err == nil {
+		t.Fatal("expected an error when no attempt returns JSON")
+	}
+	if len(p.prompts) != StructuredAttempts {
+		t.Errorf("made %d attempts, want %d", len(p.prompts), StructuredAttempts)
+	}
+}
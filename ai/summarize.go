@@ -0,0 +1,51 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const summarizePromptTemplate = `Summarize the following submission in at most %d characters.
+Keep every concrete claim, request and number a reviewer would need to judge it, and drop the rest.
+
+Submission:
+%s
+
+Respond in JSON format:
+{
+    "summary": "the summary"
+}`
+
+// SummarizeContent condenses content to at most maxLength characters using the LLM
+func SummarizeContent(content string, maxLength int) (string, error) {
+	// Ask for some headroom, models routinely overshoot character budgets
+	target := maxLength * 4 / 5
+	if target < 1 {
+		target = maxLength
+	}
+
+	config := DefaultLLMConfig()
+	config.StopTokens = nil
+	response, _, err := generateLLMResponseWithOptions(fmt.Sprintf(summarizePromptTemplate, target, content), false, "", nil, config)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return "", fmt.Errorf("failed to parse summary: %v", err)
+	}
+	summary := strings.TrimSpace(result.Summary)
+	if summary == "" {
+		return "", fmt.Errorf("LLM returned an empty summary")
+	}
+
+	if utf8.RuneCountInString(summary) > maxLength {
+		summary = string([]rune(summary)[:maxLength])
+	}
+	return summary, nil
+}
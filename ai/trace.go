@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultTraceSize is how many LLM exchanges the trace keeps when LLM_TRACE_SIZE is unset
+const DefaultTraceSize = 500
+
+// LLMCallResearch tags the call in which an agent decides whether to research a topic
+const LLMCallResearch = "research"
+
+// TraceTag identifies who made an LLM call and why, so traced exchanges can be looked up per
+// agent and block. Calls made without a tag are traced untagged.
+type TraceTag struct {
+	ChainID     string `json:"chainId,omitempty"`
+	AgentID     string `json:"agentId,omitempty"`
+	BlockHeight int    `json:"blockHeight"`
+	Round       int    `json:"round,omitempty"`
+	CallType    string `json:"callType,omitempty"` // core.LLMCallDiscussion, core.LLMCallVote, LLMCallResearch, ...
+}
+
+// TraceEntry is one prompt sent to the provider and what came back
+type TraceEntry struct {
+	TraceTag
+	Timestamp time.Time `json:"timestamp"`
+	Prompt    string    `json:"prompt"`
+	Response  string    `json:"response,omitempty"` // As returned by the provider, before sanitizing
+	Error     string    `json:"error,omitempty"`
+}
+
+// The trace is a ring buffer of the most recent exchanges; next is where the next one goes
+var (
+	trace     = make([]TraceEntry, 0, traceSizeFromEnv())
+	traceNext int
+	traceMu   sync.Mutex
+)
+
+// traceSizeFromEnv reads LLM_TRACE_SIZE, falling back to the default. 0 disables the trace.
+func traceSizeFromEnv() int {
+	value := os.Getenv("LLM_TRACE_SIZE")
+	if value == "" {
+		return DefaultTraceSize
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 0 {
+		log.Printf("Invalid LLM_TRACE_SIZE %q, using default of %d", value, DefaultTraceSize)
+		return DefaultTraceSize
+	}
+	return size
+}
+
+// recordTrace adds an exchange to the trace, overwriting the oldest once it is full
+func recordTrace(tag TraceTag, prompt, response string, err error) {
+	entry := TraceEntry{TraceTag: tag, Timestamp: time.Now(), Prompt: prompt, Response: response}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	switch {
+	case cap(trace) == 0:
+	case len(trace) < cap(trace):
+		trace = append(trace, entry)
+	default:
+		trace[traceNext] = entry
+		traceNext = (traceNext + 1) % len(trace)
+	}
+}
+
+// snapshotTrace returns the traced exchanges, oldest first. Callers hold traceMu.
+func snapshotTrace() []TraceEntry {
+	entries := make([]TraceEntry, 0, len(trace))
+	entries = append(entries, trace[traceNext:]...)
+	return append(entries, trace[:traceNext]...)
+}
+
+// GetTrace returns up to limit of the most recent LLM exchanges, oldest first. A limit of 0
+// or less returns everything still in the trace.
+func GetTrace(limit int) []TraceEntry {
+	traceMu.Lock()
+	entries := snapshotTrace()
+	traceMu.Unlock()
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries
+}
+
+// GetAgentTrace returns the traced exchanges of one agent on a chain, oldest first, limited
+// to a single block unless blockHeight is negative
+func GetAgentTrace(chainID, agentID string, blockHeight int) []TraceEntry {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	entries := []TraceEntry{}
+	for _, entry := range snapshotTrace() {
+		if entry.ChainID != chainID || entry.AgentID != agentID {
+			continue
+		}
+		if blockHeight >= 0 && entry.BlockHeight != blockHeight {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// SetTraceSize resizes the trace, dropping what it held. 0 disables it.
+func SetTraceSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	trace = make([]TraceEntry, 0, size)
+	traceNext = 0
+}
@@ -0,0 +1,38 @@
+package ai
+
+import "testing"
+
+func TestTraceKeepsMostRecentExchanges(t *testing.T) {
+	SetTraceSize(3)
+	t.Cleanup(func() { SetTraceSize(DefaultTraceSize) })
+	withProvider(t, &scriptedProvider{responses: []string{`{"stance": "SUPPORT"}`}})
+
+	for round := 1; round <= 4; round++ {
+		config := DefaultLLMConfig()
+		config.Trace = TraceTag{ChainID: "trace-chain", AgentID: "v1", BlockHeight: 2, Round: round, CallType: "discussion"}
+		var out struct{ Stance string }
+		if err := GenerateStructuredResponseWithConfig("Discuss.", config, &out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	GenerateLLMResponse("Untagged.")
+
+	all := GetTrace(0)
+	if len(all) != 3 {
+		t.Fatalf("trace holds %d exchanges, want 3", len(all))
+	}
+	if all[0].Round != 3 || all[1].Round != 4 || all[2].Prompt != "Untagged." {
+		t.Fatalf("trace out of order: %+v", all)
+	}
+	if latest := GetTrace(1); len(latest) != 1 || latest[0].Prompt != "Untagged." {
+		t.Fatalf("GetTrace(1) = %+v", latest)
+	}
+
+	agent := GetAgentTrace("trace-chain", "v1", 2)
+	if len(agent) != 2 || agent[0].Response != `{"stance": "SUPPORT"}` {
+		t.Fatalf("agent trace = %+v", agent)
+	}
+	if other := GetAgentTrace("trace-chain", "v1", 3); len(other) != 0 {
+		t.Fatalf("expected nothing traced for another block, got %+v", other)
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig controls which cross-origin requests the API accepts
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins such as "http://localhost:3000".
+	// "*" allows any origin and must be listed explicitly.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int // Seconds browsers may cache preflight results, 0 to omit
+}
+
+// DefaultCORSConfig only allows the frontend origin. The origins can be overridden with a
+// comma-separated CORS_ALLOWED_ORIGINS and credentials enabled with CORS_ALLOW_CREDENTIALS=true.
+func DefaultCORSConfig() CORSConfig {
+	config := CORSConfig{
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "X-Chain-Id"},
+		MaxAge:         600,
+	}
+
+	if origins := os.Getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		for _, origin := range strings.Split(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				config.AllowedOrigins = append(config.AllowedOrigins, origin)
+			}
+		}
+	} else if port := os.Getenv("PORT"); port != "" {
+		config.AllowedOrigins = []string{"http://localhost:" + port}
+	} else {
+		config.AllowedOrigins = []string{"http://localhost:3000"}
+	}
+
+	config.AllowCredentials, _ = strconv.ParseBool(os.Getenv("CORS_ALLOW_CREDENTIALS"))
+	return config
+}
+
+// WithCORS replaces the default CORS policy
+func WithCORS(config CORSConfig) RouteOption {
+	return func(o *routeOptions) {
+		o.cors = &config
+	}
+}
+
+// corsMiddleware answers preflight requests and sets CORS headers for allowed origins
+func corsMiddleware(config CORSConfig) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(config.AllowedOrigins))
+	wildcard := false
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			wildcard = true
+			continue
+		}
+		allowed[strings.TrimRight(origin, "/")] = true
+	}
+	if wildcard && config.AllowCredentials {
+		// Browsers reject credentialed responses to "*", so only listed origins get credentials
+		log.Println("Warning: CORS wildcard origin is not allowed credentials; only listed origins will receive them")
+	}
+
+	methods := strings.Join(config.AllowedMethods, ", ")
+	headers := strings.Join(config.AllowedHeaders, ", ")
+	maxAge := ""
+	if config.MaxAge > 0 {
+		maxAge = strconv.Itoa(config.MaxAge)
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			// Not a cross-origin request
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		listed := allowed[strings.TrimRight(origin, "/")]
+		if !listed && !wildcard {
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if listed {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			if config.AllowCredentials {
+				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+		} else {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+		c.Writer.Header().Set("Access-Control-Allow-Methods", methods)
+		c.Writer.Header().Set("Access-Control-Allow-Headers", headers)
+
+		if c.Request.Method == http.MethodOptions {
+			if maxAge != "" {
+				c.Writer.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,14 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -28,9 +36,8 @@ import (
 )
 
 var (
-	lastUsedPort         = 8080
-	portMutex            sync.Mutex
-	agentIdentitiesMutex sync.RWMutex
+	lastUsedPort = 8080
+	portMutex    sync.Mutex
 )
 
 func findAvailablePort() int {
@@ -69,28 +76,20 @@ func RegisterAgent(c *gin.Context) {
 		return
 	}
 
+	if agent.VotingPower < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "voting_power must not be negative"})
+		return
+	}
+
 	// Assign a unique ID
 	agent.ID = uuid.New().String()
 
-	// Get bootstrap node's P2P instance
-	var bootstrapNode *p2p.Node
-	chain.NodesMu.RLock()
-	for _, node := range chain.Nodes {
-		bootstrapNode = node
-		break // Get first node
-	}
-	chain.NodesMu.RUnlock()
-
-	if bootstrapNode == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "No bootstrap node found for chain"})
+	bootstrapNode, err := chain.GetHealthyBootstrapNode()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No bootstrap node is accepting connections for this chain"})
 		return
 	}
-
 	bootstrapPort := bootstrapNode.GetPort()
-	if bootstrapPort == 0 {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Bootstrap node not ready"})
-		return
-	}
 
 	log.Printf("Found bootstrap node at port: %d", bootstrapPort)
 
@@ -144,6 +143,10 @@ func RegisterAgent(c *gin.Context) {
 			agent.Influences,
 			agentNode.GetP2PNode(),
 		)
+		validatorInstance.Observer = agent.Observer
+		if agent.VotingPower > 0 {
+			validatorInstance.VotingPower = agent.VotingPower
+		}
 
 		// Register on the agent's node
 		registry.RegisterValidator(chainID, agent.ID, validatorInstance)
@@ -152,7 +155,12 @@ func RegisterAgent(c *gin.Context) {
 		return
 	}
 
-	communication.BroadcastEvent(communication.EventAgentRegistered, agent)
+	communication.BroadcastChainEvent(chainID, communication.EventAgentRegistered, communication.AgentRegisteredEvent{
+		Agent:     agent,
+		ChainID:   chainID,
+		NodePort:  newPort,
+		Timestamp: time.Now(),
+	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Agent registered successfully",
@@ -177,15 +185,54 @@ func GetBlock(c *gin.Context) {
 		return
 	}
 
-	if height < 0 || height >= len(chain.Blocks) {
+	block, ok := chain.BlockAt(height)
+	if !ok {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Block not found"})
 		return
 	}
-	block := chain.Blocks[height]
 
 	c.JSON(http.StatusOK, gin.H{"block": block})
 }
 
+// GetBlockHeader returns a block's header signed by the chain's bootstrap node, ready to
+// anchor on L1. The route's :blockHash segment holds the block height.
+func GetBlockHeader(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	height, err := strconv.Atoi(c.Param("blockHash"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block height"})
+		return
+	}
+
+	chain := core.GetChain(chainID)
+	if chain == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+	block, ok := chain.BlockAt(height)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Block not found"})
+		return
+	}
+	node := chain.BootstrapNode()
+	if node == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Chain has no bootstrap node"})
+		return
+	}
+
+	signed, err := block.Header().Sign(node)
+	if errors.Is(err, p2p.ErrNoSigningKey) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Chain's node has no signing key"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to sign header of block %d on chain %s: %v", height, chainID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign block header"})
+		return
+	}
+	c.JSON(http.StatusOK, signed)
+}
+
 // GetNetworkStatus - Returns the current status of ChaosChain
 func GetNetworkStatus(c *gin.Context) {
 	chainID := c.GetString("chainID")
@@ -200,16 +247,68 @@ func GetNetworkStatus(c *gin.Context) {
 	nodeCount := len(bc.Nodes)
 	bc.NodesMu.RUnlock()
 
+	// Blocks only join the chain once finalized, so the tip is the finalized height
+	latest, _ := bc.LatestBlock()
 	status := map[string]interface{}{
-		"height":     len(bc.Blocks) - 1,
-		"latestHash": bc.Blocks[len(bc.Blocks)-1].Hash(),
-		"totalTxs":   len(bc.Blocks[len(bc.Blocks)-1].Txs),
+		"height":     latest.Height,
+		"latestHash": bc.LatestHash(),
+		"totalTxs":   len(latest.Txs),
 		"nodeCount":  nodeCount,
 	}
+	if mp := mempool.GetMempool(chainID); mp != nil {
+		status["mempool"] = mp.Stats()
+	}
 
 	c.JSON(http.StatusOK, gin.H{"status": status})
 }
 
+// Health reports that the process is up and serving requests
+func Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready reports whether the node's dependencies are up: the NATS connection, the EigenDA
+// service and a bootstrap P2P node with at least one peer. Any that is down makes it 503.
+func Ready(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	checks := make(map[string]gin.H)
+	ready := true
+	check := func(name string, ok bool, detail gin.H) {
+		if detail == nil {
+			detail = gin.H{}
+		}
+		detail["ok"] = ok
+		checks[name] = detail
+		ready = ready && ok
+	}
+
+	health := core.GetNATSHealth()
+	check("nats", health.Connected, gin.H{
+		"status":     health.Status,
+		"since":      health.Since,
+		"reconnects": health.Reconnects,
+		"buffered":   health.Buffered,
+		"dropped":    health.Dropped,
+	})
+
+	check("eigenda", da.GlobalDAService != nil, nil)
+
+	if bc := core.GetChain(chainID); bc == nil {
+		check("p2p", false, gin.H{"chainId": chainID, "error": "chain not found"})
+	} else if bootstrap := bc.BootstrapNode(); bootstrap == nil {
+		check("p2p", false, gin.H{"chainId": chainID, "error": "no bootstrap node"})
+	} else {
+		peers := bootstrap.GetPeerCount()
+		check("p2p", peers > 0, gin.H{"chainId": chainID, "peers": peers})
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not ready", http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{"status": status, "checks": checks})
+}
+
 // SubmitTransaction - Allows an agent to submit a transaction
 func SubmitTransaction(c *gin.Context) {
 	chainID := c.GetString("chainID")
@@ -223,41 +322,274 @@ func SubmitTransaction(c *gin.Context) {
 	// Set the chainID on the transaction
 	tx.ChainID = chainID
 
-	// In production, you would get the private key from secure storage
-	privateKey, err := core.GenerateKeyPair()
+	bc := core.GetChain(chainID)
+	if bc == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	// Get the chain's mempool
+	mp := mempool.GetMempool(chainID)
+	if mp == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Mempool not found for chain"})
+		return
+	}
+
+	tx, summarized, status, err := prepareTransaction(bc, mp, tx, func(from string) uint64 {
+		return bc.AccountNonce(from) + 1
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate key"})
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := bc.ProcessTransaction(tx, mp); err != nil {
+		c.JSON(processStatus(err), gin.H{"error": "Transaction failed: " + err.Error()})
+		return
+	}
+
+	communication.BroadcastChainEvent(chainID, communication.EventNewTransaction, tx)
+
+	if summarized {
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Transaction submitted with summarized content",
+			"summarized": true,
+			"content":    tx.Content,
+		})
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": "Transaction submitted successfully"})
+}
+
+// MaxTransactionBatch bounds how many transactions one batch submission may carry
+const MaxTransactionBatch = 1000
+
+// BatchTransactionResult reports what happened to one transaction of a batch
+type BatchTransactionResult struct {
+	Index      int    `json:"index"`
+	Status     string `json:"status"` // accepted, rejected, or skipped when an all_or_nothing batch fails
+	Error      string `json:"error,omitempty"`
+	Summarized bool   `json:"summarized,omitempty"`
+	Signature  string `json:"signature,omitempty"` // Mempool ID of an accepted transaction
+}
 
-	// Sign the transaction
-	if err := tx.SignTransaction(privateKey); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign transaction"})
+// SubmitTransactionBatch adds many transactions to a chain's mempool in one call, reporting
+// the outcome of each. With ?all_or_nothing=true nothing is added unless every transaction
+// is valid, and accepted ones are withdrawn again if a later one can't be added.
+func SubmitTransactionBatch(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	allOrNothing := c.DefaultQuery("all_or_nothing", "false") == "true"
+
+	var txs []core.Transaction
+	if err := c.ShouldBindJSON(&txs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction batch format, expected an array of transactions"})
+		return
+	}
+	if len(txs) == 0 || len(txs) > MaxTransactionBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A batch must hold between 1 and %d transactions", MaxTransactionBatch)})
 		return
 	}
 
-	// Process the signed transaction
 	bc := core.GetChain(chainID)
 	if bc == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
 		return
 	}
-
-	// Get the chain's mempool
 	mp := mempool.GetMempool(chainID)
 	if mp == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Mempool not found for chain"})
 		return
 	}
 
-	if err := bc.ProcessTransaction(tx, mp); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Transaction failed: " + err.Error()})
+	// Validate everything first. Nonces and signatures are checked across the batch too,
+	// since the mempool only knows about transactions already added.
+	results := make([]BatchTransactionResult, len(txs))
+	nonces := make(map[string]uint64) // Highest nonce per sender so far, starting from the chain's
+	latest := func(from string) uint64 {
+		if nonce, ok := nonces[from]; ok {
+			return nonce
+		}
+		return bc.AccountNonce(from)
+	}
+	signatures := make(map[string]bool)
+	valid := true
+	for i := range txs {
+		txs[i].ChainID = chainID
+		results[i] = BatchTransactionResult{Index: i, Status: "rejected"}
+
+		tx, summarized, _, err := prepareTransaction(bc, mp, txs[i], func(from string) uint64 { return latest(from) + 1 })
+		switch {
+		case err != nil:
+		case tx.Nonce <= latest(tx.From):
+			err = fmt.Errorf("Invalid transaction: %w: got %d, last accepted %d", core.ErrStaleNonce, tx.Nonce, latest(tx.From))
+		case signatures[tx.Signature]:
+			err = fmt.Errorf("Invalid transaction: %w", mempool.ErrDuplicateSignature)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			valid = false
+			continue
+		}
+
+		nonces[tx.From] = tx.Nonce
+		signatures[tx.Signature] = true
+		txs[i] = tx
+		results[i].Status = ""
+		results[i].Summarized = summarized
+	}
+
+	if allOrNothing && !valid {
+		skipBatch(results)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch rejected: not every transaction is valid", "results": results})
 		return
 	}
 
-	communication.BroadcastEvent(communication.EventNewTransaction, tx)
+	type added struct {
+		tx       core.Transaction
+		previous uint64
+	}
+	var accepted []added
+	for i, tx := range txs {
+		if results[i].Status == "rejected" {
+			continue
+		}
+		previous := bc.AccountNonce(tx.From)
+		if err := bc.ProcessTransaction(tx, mp); err != nil {
+			results[i].Status = "rejected"
+			results[i].Error = "Transaction failed: " + err.Error()
+			if !allOrNothing {
+				continue
+			}
+
+			// Withdraw what this batch already added, newest first so nonces unwind in order
+			for j := len(accepted) - 1; j >= 0; j-- {
+				mp.RemoveTransaction(accepted[j].tx.Signature)
+				bc.RevertNonce(accepted[j].tx.From, accepted[j].tx.Nonce, accepted[j].previous)
+			}
+			skipBatch(results)
+			c.JSON(processStatus(err), gin.H{"error": "Batch rejected: " + results[i].Error, "results": results})
+			return
+		}
+		accepted = append(accepted, added{tx: tx, previous: previous})
+		results[i].Status = "accepted"
+		results[i].Signature = tx.Signature
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Transaction submitted successfully"})
+	for _, a := range accepted {
+		communication.BroadcastChainEvent(chainID, communication.EventNewTransaction, a.tx)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"accepted": len(accepted),
+		"rejected": len(txs) - len(accepted),
+		"results":  results,
+	})
+}
+
+// skipBatch marks every transaction of a failed all_or_nothing batch that wasn't itself
+// rejected as skipped
+func skipBatch(results []BatchTransactionResult) {
+	for i := range results {
+		if results[i].Status != "rejected" {
+			results[i].Status = "skipped"
+			results[i].Signature = ""
+		}
+	}
+}
+
+// prepareTransaction checks a submitted transaction against the chain's signing and content
+// rules and the mempool's, signing legacy unsigned transactions with a throwaway key. Those
+// without a nonce get nextNonce(from). On failure it returns the HTTP status to respond with.
+func prepareTransaction(bc *core.Blockchain, mp *mempool.Mempool, tx core.Transaction, nextNonce func(from string) uint64) (core.Transaction, bool, int, error) {
+	signed := tx.Signature != "" || tx.PublicKey != ""
+	if !signed && !bc.Config.AllowUnsigned {
+		return tx, false, http.StatusUnauthorized, errors.New("Transaction must be signed: provide signature and publicKey")
+	}
+
+	// Bound the content before it reaches every validator's prompt. Signed content
+	// can't be summarized without invalidating the signature, so it is only rejected.
+	limits := bc.Config
+	if signed {
+		limits.ContentOverflow = core.ContentOverflowReject
+	}
+	content, summarized, err := enforceContentLimit(tx.Content, limits)
+	if err != nil {
+		return tx, false, http.StatusRequestEntityTooLarge, err
+	}
+	tx.Content = content
+
+	if signed {
+		if err := core.VerifyTransaction(tx); err != nil {
+			return tx, false, http.StatusUnauthorized, errors.New("Invalid transaction signature: " + err.Error())
+		}
+	} else {
+		// Legacy unsigned submission: sign with a throwaway key so the mempool can index it.
+		// Clients that don't track nonces get the sender's next one.
+		if tx.Nonce == 0 {
+			tx.Nonce = nextNonce(tx.From)
+		}
+		privateKey, err := core.GenerateKeyPair()
+		if err != nil {
+			return tx, false, http.StatusInternalServerError, errors.New("Failed to generate key")
+		}
+		if err := tx.SignTransaction(privateKey); err != nil {
+			return tx, false, http.StatusInternalServerError, errors.New("Failed to sign transaction")
+		}
+	}
+
+	if err := mp.Validate(tx); err != nil {
+		return tx, false, http.StatusBadRequest, errors.New("Invalid transaction: " + err.Error())
+	}
+	return tx, summarized, http.StatusOK, nil
+}
+
+// processStatus maps a ProcessTransaction error to an HTTP status
+func processStatus(err error) int {
+	if errors.Is(err, mempool.ErrMempoolFull) {
+		return http.StatusServiceUnavailable
+	} else if errors.Is(err, core.ErrStaleNonce) || errors.Is(err, core.ErrChainArchived) {
+		return http.StatusConflict
+	} else if errors.Is(err, core.ErrSenderMismatch) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// GetAccountNonce returns the highest nonce accepted from an address and the one its next
+// transaction should use
+func GetAccountNonce(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	bc := core.GetChain(chainID)
+	if bc == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	address := c.Param("address")
+	nonce := bc.AccountNonce(address)
+	c.JSON(http.StatusOK, gin.H{
+		"address":    address,
+		"nonce":      nonce,
+		"next_nonce": nonce + 1,
+	})
+}
+
+// enforceContentLimit applies the chain's content length policy, returning the content to
+// submit and whether it was summarized to fit
+func enforceContentLimit(content string, config core.ChainConfig) (string, bool, error) {
+	length := utf8.RuneCountInString(content)
+	if config.MaxContentLength <= 0 || length <= config.MaxContentLength {
+		return content, false, nil
+	}
+	if config.ContentOverflow != core.ContentOverflowSummarize {
+		return "", false, fmt.Errorf("content is %d characters, the limit on this chain is %d", length, config.MaxContentLength)
+	}
+
+	summary, err := ai.SummarizeContent(content, config.MaxContentLength)
+	if err != nil {
+		log.Printf("Failed to summarize %d character submission: %v", length, err)
+		return "", false, fmt.Errorf("content is %d characters, over the limit of %d, and could not be summarized", length, config.MaxContentLength)
+	}
+	return summary, true, nil
 }
 
 // GetValidators - Returns the list of registered validators
@@ -267,6 +599,118 @@ func GetValidators(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"validators": validatorsList})
 }
 
+// GetValidatorPublicKey returns the key that verifies a validator's signed discussions and votes
+func GetValidatorPublicKey(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	validatorID := c.Param("id")
+
+	v := validator.GetValidatorByID(chainID, validatorID)
+	if v == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Validator not found"})
+		return
+	}
+	if len(v.PublicKey) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Validator has no signing key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"validatorId":   v.ID,
+		"algorithm":     "ed25519",
+		"publicKey":     v.PublicKeyBase64(),
+		"signingDomain": consensus.DiscussionSigningDomain,
+	})
+}
+
+// GetValidatorReasoning returns the prompts a validator sent to the LLM and the responses it
+// got, optionally for a single block, from the node's in-memory LLM trace
+func GetValidatorReasoning(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	validatorID := c.Param("id")
+
+	if validator.GetValidatorByID(chainID, validatorID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Validator not found"})
+		return
+	}
+
+	blockHeight := -1
+	if value := c.Query("blockHeight"); value != "" {
+		height, err := strconv.Atoi(value)
+		if err != nil || height < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block height"})
+			return
+		}
+		blockHeight = height
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"validatorId": validatorID,
+		"exchanges":   ai.GetAgentTrace(chainID, validatorID, blockHeight),
+	})
+}
+
+// GetValidatorPolicy returns the policy a validator follows when it validates blocks
+func GetValidatorPolicy(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	validatorID := c.Param("id")
+
+	v := validator.GetValidatorByID(chainID, validatorID)
+	if v == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Validator not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"validatorId": v.ID,
+		"policy":      v.Policy(),
+	})
+}
+
+// UpdateValidatorPolicy replaces a validator's validation policy, so operators can steer how
+// it judges blocks mid-chain
+func UpdateValidatorPolicy(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	validatorID := c.Param("id")
+	var req struct {
+		Policy string `json:"policy"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy data"})
+		return
+	}
+
+	v := validator.GetValidatorByID(chainID, validatorID)
+	if v == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Validator not found"})
+		return
+	}
+
+	previous, err := v.SetPolicy(req.Policy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policy := v.Policy()
+	if err := v.SaveValidatorState(chainID); err != nil {
+		log.Printf("Failed to persist policy for validator %s: %v", validatorID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Policy updated but could not be saved"})
+		return
+	}
+	communication.BroadcastChainEvent(chainID, communication.EventPolicyUpdated, communication.PolicyUpdatedEvent{
+		AgentID:        v.ID,
+		ChainID:        chainID,
+		Policy:         policy,
+		PreviousPolicy: previous,
+		Timestamp:      time.Now(),
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"validatorId":    v.ID,
+		"policy":         policy,
+		"previousPolicy": previous,
+	})
+}
+
 // GetSocialStatus - Retrieves an agent's social reputation
 func GetSocialStatus(c *gin.Context) {
 	agentID := c.Param("agentID")
@@ -282,6 +726,7 @@ func GetSocialStatus(c *gin.Context) {
 		"agentID":       validator.ID,
 		"name":          validator.Name,
 		"mood":          validator.Mood,
+		"moodHistory":   validator.MoodHistory(),
 		"relationships": validator.Relationships,
 	})
 }
@@ -304,8 +749,33 @@ func AddInfluence(c *gin.Context) {
 		return
 	}
 
-	v.Influences = append(v.Influences, influence.Name)
-	c.JSON(http.StatusOK, gin.H{"message": "Influence added successfully"})
+	config := core.DefaultChainConfig()
+	if bc := core.GetChain(chainID); bc != nil {
+		config = bc.Config
+	}
+
+	added, evicted, err := v.AddInfluence(influence.Name, config.MaxInfluences, config.InfluenceOverflow == core.InfluenceOverflowEvictOldest)
+	if err != nil {
+		status := http.StatusConflict
+		if strings.TrimSpace(influence.Name) == "" {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if !added {
+		c.JSON(http.StatusOK, gin.H{"message": "Influence already present"})
+		return
+	}
+	if err := v.SaveValidatorState(chainID); err != nil {
+		log.Printf("Failed to persist influences for validator %s: %v", agentID, err)
+	}
+
+	response := gin.H{"message": "Influence added successfully"}
+	if evicted != "" {
+		response["evicted"] = evicted
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // UpdateRelationship updates the relationship score between validators
@@ -332,7 +802,12 @@ func UpdateRelationship(c *gin.Context) {
 	}
 
 	v.Relationships[rel.TargetID] = rel.Score
-	communication.BroadcastEvent(communication.EventAgentAlliance, rel)
+	if err := v.SaveValidatorState(chainID); err != nil {
+		log.Printf("Failed to persist relationship for validator %s: %v", agentID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Relationship updated but could not be saved"})
+		return
+	}
+	communication.BroadcastChainEvent(chainID, communication.EventAgentAlliance, rel)
 	c.JSON(http.StatusOK, gin.H{"message": "Relationship updated successfully"})
 }
 
@@ -347,9 +822,19 @@ func ProposeBlock(c *gin.Context) {
 		return
 	}
 
-	block, err := bc.CreateBlock()
+	// A block needs enough voters for its consensus to mean anything
+	if voters, quorum := validator.VotingValidatorCount(chainID), bc.Config.Quorum(); voters < quorum {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          fmt.Sprintf("Chain has %d voting validators, at least %d are needed to start consensus", voters, quorum),
+			"validators":     voters,
+			"min_validators": quorum,
+		})
+		return
+	}
+
+	block, deferred, err := bc.CreateBlock()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "deferred_txs": deferred})
 		return
 	}
 
@@ -360,128 +845,107 @@ func ProposeBlock(c *gin.Context) {
 	title := fmt.Sprintf("Block Proposal %s", threadID)
 	communication.CreateThread(threadID, title, producerName)
 
-	// Set up a subscription to capture discussions for this block
+	// Capture discussions and votes in the mempool, for storing with the block in EigenDA
 	mp := mempool.GetMempool(chainID)
-
 	if mp != nil {
-		// Subscribe to agent discussions via broker
-		// if broker != nil {
-		_, err := core.NatsBrokerInstance.Subscribe("AGENT_DISCUSSION", func(m *nats.Msg) {
-			var discussion consensus.Discussion
-			if err := json.Unmarshal(m.Data, &discussion); err != nil {
-				log.Printf("Error unmarshalling discussion from NATS: %v", err)
-				return
-			}
-
-			// Store vote information in mempool for later storage in EigenDA
-			mp.EphemeralVotes = append(mp.EphemeralVotes, mempool.EphemeralVote{
-				ID:           discussion.ID,
-				AgentID:      discussion.ValidatorID,
-				VoteDecision: discussion.Type,
-				Timestamp:    discussion.Timestamp.Unix(),
-			})
-
-			// Store agent identity if not already stored
-			agentIdentitiesMutex.Lock()
-			if _, exists := mp.EphemeralAgentIdentities[discussion.ValidatorID]; !exists {
-				// Get validator name if available
-				v := validator.GetValidatorByID(chainID, discussion.ValidatorID)
-				if v != nil {
-					mp.EphemeralAgentIdentities[discussion.ValidatorID] = v.Name
-				} else {
-					mp.EphemeralAgentIdentities[discussion.ValidatorID] = discussion.ValidatorID
-				}
-			}
-			agentIdentitiesMutex.Unlock()
-		})
-		if err != nil {
-			log.Printf("Error subscribing to AGENT_DISCUSSION: %v", err)
-		}
-
-		// Also subscribe to final votes
-		_, err = core.NatsBrokerInstance.Subscribe("AGENT_VOTE", func(m *nats.Msg) {
-			var vote consensus.Discussion
-			if err := json.Unmarshal(m.Data, &vote); err != nil {
-				log.Printf("Error unmarshalling vote from NATS: %v", err)
-				return
-			}
-
-			// Store vote information in mempool for later storage in EigenDA
-			mp.EphemeralVotes = append(mp.EphemeralVotes, mempool.EphemeralVote{
-				AgentID:      vote.ValidatorID,
-				VoteDecision: vote.Type,
-				Timestamp:    vote.Timestamp.Unix(),
-			})
-
-			// Store agent identity if not already stored
-			agentIdentitiesMutex.Lock()
-			if _, exists := mp.EphemeralAgentIdentities[vote.ValidatorID]; !exists {
-				// Get validator name if available
-				v := validator.GetValidatorByID(chainID, vote.ValidatorID)
-				if v != nil {
-					mp.EphemeralAgentIdentities[vote.ValidatorID] = v.Name
-				} else {
-					mp.EphemeralAgentIdentities[vote.ValidatorID] = vote.ValidatorID
-				}
-			}
-			agentIdentitiesMutex.Unlock()
-		})
-		if err != nil {
-			log.Printf("Error subscribing to AGENT_VOTE: %v", err)
-		}
-		// }
+		subscribeVoteCapture(chainID, mp)
 	}
 
 	cm := consensus.GetConsensusManager(chainID)
 	if err := cm.ProposeBlock(block); err != nil {
+		var queued *consensus.QueuedError
+		if errors.As(err, &queued) {
+			c.JSON(http.StatusAccepted, gin.H{
+				"message":        "System busy, block queued for consensus",
+				"block":          block,
+				"thread_id":      threadID,
+				"queue_position": queued.Position,
+				"deferred_txs":   deferred,
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to start consensus: " + err.Error()})
 		return
 	}
 
 	if !waitForConsensus {
 		c.JSON(http.StatusOK, gin.H{
-			"message":   "Block proposed successfully, consensus started",
-			"block":     block,
-			"thread_id": threadID,
+			"message":      "Block proposed successfully, consensus started",
+			"block":        block,
+			"thread_id":    threadID,
+			"deferred_txs": deferred,
 		})
 		return
 	}
 
-	result := make(chan consensus.ConsensusResult)
+	// Buffered so the result can still be delivered after this request has timed out
+	result := make(chan consensus.ConsensusResult, 1)
 	cm.SubscribeResult(int64(block.Height), result)
 
-	// Calculate total expected time: all rounds + voting round + buffer + safety margin
-	totalTime := time.Duration(consensus.DiscussionRounds+1)*consensus.RoundDuration +
-		5*time.Second + // Buffer time
-		2*time.Second // Safety margin
+	timeout := bc.Config.ConsensusTimeout(consensus.RoundDuration())
 
 	select {
 
 	case consensusResult := <-result:
+		// A cancelled block has no outcome to store; CancelConsensus already cleared its votes
+		if consensusResult.State == consensus.Cancelled {
+			c.JSON(http.StatusOK, gin.H{
+				"message":      "Consensus cancelled",
+				"block":        block,
+				"accepted":     false,
+				"cancelled":    true,
+				"support":      consensusResult.Support,
+				"oppose":       consensusResult.Oppose,
+				"votes":        consensusResult.Votes,
+				"thread_id":    threadID,
+				"deferred_txs": deferred,
+			})
+			return
+		}
 
 		// Store offchain data to EigenDA and clear temporary mempool data
 		if mp := mempool.GetMempool(chainID); mp != nil {
 			// Get discussions from consensus if available
 			var discussions []consensus.Discussion
+			var provenance *consensus.Provenance
 			activeConsensus := cm.GetActiveConsensus()
 			if activeConsensus != nil {
 				discussions = activeConsensus.GetDiscussions()
+				p := activeConsensus.Provenance()
+				provenance = &p
 			}
 
 			// No need to convert discussions since we're using the standardized struct directly
 
-			votes := make([]da.Vote, len(mp.EphemeralVotes))
-			for i, ev := range mp.EphemeralVotes {
+			ephemeralVotes := mp.GetEphemeralVotes()
+			votes := make([]da.Vote, len(ephemeralVotes))
+			for i, ev := range ephemeralVotes {
 				votes[i] = da.Vote{
 					AgentID:      ev.AgentID,
 					VoteDecision: ev.VoteDecision,
 					Timestamp:    ev.Timestamp,
+					Round:        ev.Round,
+					Researched:   ev.Researched,
+					Confidence:   ev.Confidence,
+					VotingPower:  ev.VotingPower,
+				}
+			}
+
+			// Redeliveries over NATS and P2P can repeat entries; store each one once
+			if bc.Config.CompactOffchainData {
+				var removedDiscussions, removedVotes int
+				discussions, removedDiscussions = dedupDiscussions(discussions)
+				votes, removedVotes = dedupVotes(votes)
+				if removedDiscussions > 0 || removedVotes > 0 {
+					log.Printf("Compacted offchain data for block %s: removed %d duplicate discussions and %d duplicate votes",
+						threadID, removedDiscussions, removedVotes)
 				}
 			}
 
 			offchain := da.OffchainData{
 				ChainID:     chainID,
 				BlockHash:   threadID,
+				ContentHash: block.ContentHash(),
 				BlockHeight: block.Height,
 				Discussions: discussions, // Using the discussions directly
 				Votes:       votes,
@@ -491,45 +955,361 @@ func ProposeBlock(c *gin.Context) {
 					}
 					return "rejected"
 				}(),
-				AgentIdentities: mp.EphemeralAgentIdentities,
+				AgentIdentities: mp.GetEphemeralAgentIdentities(),
 				Timestamp:       time.Now().Unix(),
+				Provenance:      provenance,
+				ValidatorVotes:  consensusResult.Votes,
 			}
-			if id, err := da.SaveOffchainData(offchain); err != nil {
+			// Dispersal gets one request timeout, confirmation is tracked in the background;
+			// it isn't tied to the request so a client hanging up doesn't lose the data
+			saveCtx, cancel := context.WithTimeout(context.Background(), da.EIGENDA_REQUEST_TIMEOUT)
+			id, err := da.SaveOffchainData(saveCtx, offchain)
+			cancel()
+			if err != nil {
 				log.Printf("Error saving offchain data: %v", err)
 			} else {
 				log.Printf("Offchain data saved with id: %s", id)
+				consensus.RecordTimelineEvent(chainID, threadID, consensus.TimelineEvent{
+					Kind:   consensus.TimelineOffchainSaved,
+					Detail: id,
+				})
 			}
 			mp.ClearTemporaryData()
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"message":   "Consensus completed",
-			"block":     block,
-			"accepted":  consensusResult.State == consensus.Accepted,
-			"support":   consensusResult.Support,
-			"oppose":    consensusResult.Oppose,
-			"thread_id": threadID,
+			"message":      "Consensus completed",
+			"block":        block,
+			"accepted":     consensusResult.State == consensus.Accepted,
+			"support":      consensusResult.Support,
+			"oppose":       consensusResult.Oppose,
+			"votes":        consensusResult.Votes,
+			"thread_id":    threadID,
+			"deferred_txs": deferred,
 		})
-	case <-time.After(totalTime):
-		// Close the broker to clean up subscriptions
-
+	case <-time.After(timeout):
+		// Stop the validators and return the block's transactions to the mempool. Consensus
+		// may have concluded in the meantime, in which case there is nothing to cancel.
+		cancelled := cm.CancelConsensus(block.Height) == nil
 		c.JSON(http.StatusGatewayTimeout, gin.H{
 			"error":     "Consensus timed out",
 			"block":     block,
+			"cancelled": cancelled,
+			"timeout":   timeout.Seconds(),
 			"thread_id": threadID,
 		})
 	}
 }
 
+// StartProducer starts proposing blocks on the chain on a schedule. Each interval it
+// proposes a block if no consensus is running and the mempool holds at least min_txs
+// transactions; min_txs 0 proposes a block every interval, even an empty one.
+func StartProducer(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	if core.GetChain(chainID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	var req struct {
+		IntervalMs int  `json:"interval_ms" binding:"required"`
+		MinTxs     *int `json:"min_txs"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config := producer.AutoProducerConfig{Interval: time.Duration(req.IntervalMs) * time.Millisecond, MinTxs: 1}
+	if req.MinTxs != nil {
+		config.MinTxs = *req.MinTxs
+	}
+	if err := producer.StartAutoProducer(chainID, config); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Auto-producer started",
+		"interval_ms": req.IntervalMs,
+		"min_txs":     config.MinTxs,
+	})
+}
+
+// StopProducer stops the chain's auto-producer
+func StopProducer(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	if !producer.StopAutoProducer(chainID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No auto-producer running on this chain"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Auto-producer stopped"})
+}
+
+// GetConsensusLoad returns how many consensus rounds are active and queued across all chains
+func GetConsensusLoad(c *gin.Context) {
+	c.JSON(http.StatusOK, consensus.GetLoadStats())
+}
+
+// GetConsensusProgress reports how far consensus on a block has got, so clients that
+// proposed with wait=false can poll for the outcome
+func GetConsensusProgress(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	height, err := strconv.Atoi(c.Param("blockHeight"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block height"})
+		return
+	}
+
+	if progress, ok := consensus.GetConsensusManager(chainID).Progress(height); ok {
+		c.JSON(http.StatusOK, progress)
+		return
+	}
+
+	// Older blocks are finished; report their outcome without tallies
+	if ref, found := da.GetBlobReferenceByHeight(chainID, height); found {
+		c.JSON(http.StatusOK, consensus.Progress{BlockHeight: height, BlockHash: ref.BlockHash, State: ref.Outcome, Final: true})
+		return
+	}
+	if bc := core.GetChain(chainID); bc != nil {
+		if block, ok := bc.BlockAt(height); ok {
+			c.JSON(http.StatusOK, consensus.Progress{BlockHeight: height, BlockHash: block.Hash(), State: "accepted", Final: true})
+			return
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "No consensus found for this block height"})
+}
+
+// CancelConsensus aborts consensus on a block that is being discussed or queued. The block's
+// transactions return to the mempool and the votes recorded for it are discarded.
+func CancelConsensus(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	if core.GetChain(chainID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+	height, err := strconv.Atoi(c.Param("blockHeight"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block height"})
+		return
+	}
+
+	err = consensus.GetConsensusManager(chainID).CancelConsensus(height)
+	switch {
+	case errors.Is(err, consensus.ErrNotInConsensus):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, consensus.ErrConsensusConcluded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if mp := mempool.GetMempool(chainID); mp != nil {
+		mp.ClearTemporaryData()
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Consensus cancelled",
+		"blockHeight": height,
+	})
+}
+
+// voteCapture is a mempool's subscription to its chain's stances and final votes
+type voteCapture struct {
+	mp   *mempool.Mempool
+	subs []*nats.Subscription
+}
+
+var (
+	voteCaptureMu sync.Mutex
+	voteCaptures  = make(map[string]*voteCapture) // Chain ID -> its mempool's subscription
+)
+
+func init() {
+	// An archived chain gets a fresh subscription when it is next proposed on
+	core.OnChainArchived(dropVoteCapture)
+}
+
+// subscribeVoteCapture records the stances and final votes published over NATS for the chain
+// in mp, with the names of the validators that cast them. Each mempool subscribes once;
+// subscribing again on every proposal would run every message through one more callback
+// per block proposed.
+func subscribeVoteCapture(chainID string, mp *mempool.Mempool) {
+	voteCaptureMu.Lock()
+	defer voteCaptureMu.Unlock()
+	if capture, ok := voteCaptures[chainID]; ok {
+		if capture.mp == mp {
+			return
+		}
+		capture.unsubscribe()
+		delete(voteCaptures, chainID)
+	}
+
+	record := func(d consensus.Discussion, final bool) {
+		vote := mempool.EphemeralVote{
+			ID:           d.ID,
+			AgentID:      d.ValidatorID,
+			VoteDecision: d.Type,
+			Timestamp:    d.Timestamp.Unix(),
+			Round:        d.Round,
+		}
+		if final {
+			vote.Researched = d.Researched
+			vote.Confidence = d.Confidence
+			vote.VotingPower = d.VotingPower
+		}
+		mp.RecordEphemeralVote(vote)
+
+		name := d.ValidatorID
+		if v := validator.GetValidatorByID(chainID, d.ValidatorID); v != nil {
+			name = v.Name
+		}
+		mp.SetAgentIdentity(d.ValidatorID, name)
+	}
+
+	discussions, err := core.NatsBrokerInstance.Subscribe(consensus.DiscussionSubject(chainID), func(m *nats.Msg) {
+		var discussion consensus.Discussion
+		if err := json.Unmarshal(m.Data, &discussion); err != nil {
+			log.Printf("Error unmarshalling discussion from NATS: %v", err)
+			return
+		}
+		record(discussion, false)
+	})
+	if err != nil {
+		log.Printf("Error subscribing to %s: %v", consensus.DiscussionSubject(chainID), err)
+		return
+	}
+
+	votes, err := core.NatsBrokerInstance.Subscribe(consensus.VoteSubject(chainID), func(m *nats.Msg) {
+		var vote consensus.Discussion
+		if err := json.Unmarshal(m.Data, &vote); err != nil {
+			log.Printf("Error unmarshalling vote from NATS: %v", err)
+			return
+		}
+		record(vote, true)
+	})
+	if err != nil {
+		log.Printf("Error subscribing to %s: %v", consensus.VoteSubject(chainID), err)
+		// Retry both on the next proposal
+		discussions.Unsubscribe()
+		return
+	}
+	voteCaptures[chainID] = &voteCapture{mp: mp, subs: []*nats.Subscription{discussions, votes}}
+}
+
+// dropVoteCapture ends a chain's vote capture and lets go of its mempool
+func dropVoteCapture(chainID string) {
+	voteCaptureMu.Lock()
+	defer voteCaptureMu.Unlock()
+	if capture, ok := voteCaptures[chainID]; ok {
+		capture.unsubscribe()
+		delete(voteCaptures, chainID)
+	}
+}
+
+func (c *voteCapture) unsubscribe() {
+	for _, sub := range c.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			log.Printf("Error unsubscribing from %s: %v", sub.Subject, err)
+		}
+	}
+}
+
+// dedupDiscussions keeps the first discussion with each ID and reports how many were dropped
+func dedupDiscussions(discussions []consensus.Discussion) ([]consensus.Discussion, int) {
+	seen := make(map[string]bool, len(discussions))
+	kept := make([]consensus.Discussion, 0, len(discussions))
+	for _, d := range discussions {
+		if d.ID != "" && seen[d.ID] {
+			continue
+		}
+		seen[d.ID] = true
+		kept = append(kept, d)
+	}
+	return kept, len(discussions) - len(kept)
+}
+
+// dedupVotes keeps the first vote from each agent in each round and reports how many were dropped
+func dedupVotes(votes []da.Vote) ([]da.Vote, int) {
+	type voteKey struct {
+		agentID string
+		round   int
+	}
+	seen := make(map[voteKey]bool, len(votes))
+	kept := make([]da.Vote, 0, len(votes))
+	for _, v := range votes {
+		key := voteKey{v.AgentID, v.Round}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, v)
+	}
+	return kept, len(votes) - len(kept)
+}
+
 // GetAllThreads returns all active discussion threads for monitoring.
 func GetAllThreads(c *gin.Context) {
 	threads := communication.GetAllThreads() // We'll implement this function in forum
 	c.JSON(http.StatusOK, threads)
 }
 
+// GetThreadTree returns a thread's metadata and all of its messages, with replies nested
+// under the message they answer.
+func GetThreadTree(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	if core.GetChain(chainID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	tree, err := communication.GetThreadTree(c.Param("threadId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Thread not found"})
+		return
+	}
+	c.JSON(http.StatusOK, tree)
+}
+
 type CreateChainRequest struct {
-	ChainID       string `json:"chain_id" binding:"required"`
-	GenesisPrompt string `json:"genesis_prompt" binding:"required"`
+	ChainID             string         `json:"chain_id" binding:"required"`
+	GenesisPrompt       string         `json:"genesis_prompt" binding:"required"`
+	ResearchWeighting   bool           `json:"research_weighting"`      // Give research-backed votes a weight bonus
+	ResearchWeightBonus *float64       `json:"research_weight_bonus"`   // Optional, 0-1, defaults to 0.25
+	ConfidenceWeighting bool           `json:"confidence_weighting"`    // Scale final votes by the validator's stated confidence
+	MaxTokens           map[string]int `json:"max_tokens"`              // Optional per-call-type response length, e.g. {"vote": 256}
+	NameResolution      string         `json:"name_resolution"`         // Optional "strict", "tolerant" or "fuzzy" (default)
+	DevilsAdvocate      bool           `json:"devils_advocate"`         // Rotate a devil's advocate through discussion rounds
+	DiscussionRounds    *int           `json:"discussion_rounds"`       // Optional, defaults to 5
+	MaxInfluences       *int           `json:"max_influences"`          // Optional, defaults to 10; 0 means unlimited
+	InfluenceOverflow   string         `json:"influence_overflow"`      // Optional "reject" (default) or "evict_oldest"
+	MaxContentLength    *int           `json:"max_content_length"`      // Optional, defaults to 4000 characters; 0 means unlimited
+	ContentOverflow     string         `json:"content_overflow"`        // Optional "reject" (default) or "summarize"
+	MaxBlockSeconds     *int           `json:"max_block_seconds"`       // Optional time budget per block; 0 (default) means unlimited
+	MaxLLMCallsPerBlock *int           `json:"max_llm_calls_per_block"` // Optional LLM call budget per block; 0 (default) means unlimited
+	AllowUnsigned       bool           `json:"allow_unsigned"`          // Accept unsigned transactions, signed with a throwaway key
+	TransactionTypes    []string       `json:"transaction_types"`       // Optional transaction types the mempool accepts, defaults to ["transfer"]
+	MinValidators       *int           `json:"min_validators"`          // Optional voting validators needed to propose blocks, defaults to 2
+	AcceptanceThreshold *float64       `json:"acceptance_threshold"`    // Optional share of weighted support needed to accept a block, defaults to 0.5
+	MaxTxsPerBlock      *int           `json:"max_txs_per_block"`       // Optional transactions per block; 0 (default) means unlimited
+	MaxBlockBytes       *int           `json:"max_block_bytes"`         // Optional encoded transaction bytes per block, defaults to 22020096; 0 means unlimited
+
+	RelationshipDecayRate *float64 `json:"relationship_decay_rate"` // Optional share of relationship scores fading per hour, defaults to 0.05; 0 disables
+
+	ConsensusTimeout      *int `json:"consensus_timeout"`       // Optional seconds a proposal waits for consensus; 0 (default) derives it from the rounds
+	ConsensusBuffer       *int `json:"consensus_buffer"`        // Optional seconds added to the derived timeout, defaults to 5
+	ConsensusSafetyMargin *int `json:"consensus_safety_margin"` // Optional seconds added to the derived timeout and the block budget, defaults to 2
+
+	ConsensusStrategy string `json:"consensus_strategy"` // Optional "deliberative" (default) or "majority"
+
+	LLMModel       string   `json:"llm_model"`       // Optional model for the chain's validators, one of core.LLMModels
+	LLMTemperature *float64 `json:"llm_temperature"` // Optional sampling temperature for the chain's validators, 0-2
+
+	RegistrationDelayMs     *int `json:"registration_delay_ms"`    // Optional pause before each sample agent registration, defaults to 500
+	RegistrationParallelism *int `json:"registration_parallelism"` // Optional sample agents registered at once, defaults to 1
+	AsyncRegistration       bool `json:"async_registration"`       // Register sample agents in the background and respond right away
 }
 
 func loadSampleAgents(genesisPrompt string) ([]core.Agent, error) {
@@ -553,7 +1333,18 @@ func loadSampleAgents(genesisPrompt string) ([]core.Agent, error) {
 	return agents, nil
 }
 
-func registerAgent(chainID string, agent core.Agent, bootstrapPort int) error {
+// registerAgent starts a node for agent that bootstraps from one of the chain's healthy nodes
+func registerAgent(chainID string, agent core.Agent) error {
+	chain := core.GetChain(chainID)
+	if chain == nil {
+		return fmt.Errorf("chain %s not found", chainID)
+	}
+	bootstrap, err := chain.GetHealthyBootstrapNode()
+	if err != nil {
+		return err
+	}
+	bootstrapPort := bootstrap.GetPort()
+
 	// Create a new node for this agent
 	newPort := findAvailablePort()
 	agentNode := node.NewNode(node.NodeConfig{
@@ -570,7 +1361,6 @@ func registerAgent(chainID string, agent core.Agent, bootstrapPort int) error {
 	}
 
 	// Register the new node with the chain
-	chain := core.GetChain(chainID)
 	addr := fmt.Sprintf("localhost:%d", newPort)
 	chain.RegisterNode(addr, agentNode.GetP2PNode())
 
@@ -583,22 +1373,169 @@ func registerAgent(chainID string, agent core.Agent, bootstrapPort int) error {
 			agent.Influences,
 			agentNode.GetP2PNode(),
 		)
+		validatorInstance.Observer = agent.Observer
+		if agent.VotingPower > 0 {
+			validatorInstance.VotingPower = agent.VotingPower
+		}
 
 		// Register validator
 		registry.RegisterValidator(chainID, agent.ID, validatorInstance)
-
-		// Broadcast WebSocket event
-		communication.BroadcastEvent(communication.EventAgentRegistered, map[string]interface{}{
-			"agent":     agent,
-			"chainId":   chainID,
-			"nodePort":  newPort,
-			"timestamp": time.Now(),
-		})
+	} else if agent.Role == "producer" {
+		mp, ok := agentNode.GetMempool().(*mempool.Mempool)
+		if !ok {
+			return fmt.Errorf("invalid mempool type")
+		}
+		personality := ai.Personality{
+			Name:   agent.Name,
+			Traits: agent.Traits,
+			Style:  agent.Style,
+		}
+		registry.RegisterProducer(chainID, agent.ID, producer.NewProducer(mp, personality, agentNode.GetP2PNode()))
+	} else {
+		return nil
 	}
 
+	// Broadcast WebSocket event
+	communication.BroadcastChainEvent(chainID, communication.EventAgentRegistered, communication.AgentRegisteredEvent{
+		Agent:     agent,
+		ChainID:   chainID,
+		NodePort:  newPort,
+		Timestamp: time.Now(),
+	})
+
 	return nil
 }
 
+// RemoveAgent removes a validator or producer from the chain and stops its P2P node. A
+// validator taking part in the block in consensus stops contributing, and consensus
+// carries on without it.
+func RemoveAgent(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	chain := core.GetChain(chainID)
+	if chain == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	agentID := c.Param("agentID")
+	var agentNode *p2p.Node
+	role := "validator"
+	if v := registry.UnregisterValidator(chainID, agentID); v != nil {
+		agentNode = v.P2PNode
+		consensus.GetConsensusManager(chainID).RemoveValidator(agentID)
+	} else if p := registry.UnregisterProducer(chainID, agentID); p != nil {
+		agentNode = p.P2PNode()
+		role = "producer"
+	} else {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return
+	}
+
+	if agentNode != nil {
+		if addr, ok := chain.UnregisterNode(agentNode); ok {
+			p2p.UnregisterNode(addr)
+			agentNode.Stop()
+		}
+	}
+
+	communication.BroadcastChainEvent(chainID, communication.EventAgentRemoved, communication.AgentRemovedEvent{
+		AgentID:   agentID,
+		Role:      role,
+		ChainID:   chainID,
+		Timestamp: time.Now(),
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Agent removed successfully",
+		"agentID": agentID,
+		"role":    role,
+	})
+}
+
+// bulkAgentResult reports what happened to one agent in a bulk registration
+type bulkAgentResult struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Error string `json:"error,omitempty"`
+}
+
+// RegisterAgentsBulk registers a given cast of agents on the chain without generating
+// them, so the same validators can be reproduced across runs. Agents keep the IDs they're
+// given. Agents with an invalid role or a name already taken on the chain or earlier in
+// the list are skipped.
+func RegisterAgentsBulk(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	chain := core.GetChain(chainID)
+	if chain == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	var agents []core.Agent
+	if err := c.ShouldBindJSON(&agents); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected a JSON array of agents"})
+		return
+	}
+	if len(agents) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No agents given"})
+		return
+	}
+
+	if _, err := chain.GetHealthyBootstrapNode(); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No bootstrap node is accepting connections for this chain"})
+		return
+	}
+
+	taken := make(map[string]bool)
+	for _, v := range validator.GetAllValidators(chainID) {
+		taken[strings.ToLower(v.Name)] = true
+		taken["id:"+v.ID] = true
+	}
+
+	registered, skipped := []bulkAgentResult{}, []bulkAgentResult{}
+	for _, agent := range agents {
+		agent.Name = strings.TrimSpace(agent.Name)
+		result := bulkAgentResult{Name: agent.Name, Role: agent.Role}
+
+		switch {
+		case agent.Name == "":
+			result.Error = "name is required"
+		case agent.Role != "validator" && agent.Role != "producer":
+			result.Error = fmt.Sprintf("invalid role %q, expected validator or producer", agent.Role)
+		case agent.VotingPower < 0:
+			result.Error = "voting_power must not be negative"
+		case taken[strings.ToLower(agent.Name)]:
+			result.Error = "duplicate name"
+		case agent.ID != "" && taken["id:"+agent.ID]:
+			result.Error = "duplicate id"
+		}
+		if result.Error != "" {
+			skipped = append(skipped, result)
+			continue
+		}
+
+		if agent.ID == "" {
+			agent.ID = uuid.New().String()
+		}
+		result.ID = agent.ID
+		if err := registerAgent(chainID, agent); err != nil {
+			log.Printf("Failed to register agent %s: %v", agent.Name, err)
+			result.Error = err.Error()
+			skipped = append(skipped, result)
+			continue
+		}
+		taken[strings.ToLower(agent.Name)] = true
+		taken["id:"+agent.ID] = true
+		registered = append(registered, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"registered": registered,
+		"skipped":    skipped,
+	})
+}
+
 // CreateChain creates a new blockchain instance
 func CreateChain(c *gin.Context) {
 	var req CreateChainRequest
@@ -607,6 +1544,11 @@ func CreateChain(c *gin.Context) {
 		return
 	}
 
+	if fieldErrors := validateCreateChainRequest(req); len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid chain request", "fields": fieldErrors})
+		return
+	}
+
 	// Check if chain already exists
 	if core.GetChain(req.ChainID) != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "Chain already exists"})
@@ -638,12 +1580,76 @@ func CreateChain(c *gin.Context) {
 
 	// Register the bootstrap node with the chain
 	chain := core.GetChain(req.ChainID)
+	chain.Config.ResearchWeighting = req.ResearchWeighting
+	chain.Config.ConfidenceWeighting = req.ConfidenceWeighting
+	if req.ResearchWeightBonus != nil {
+		chain.Config.ResearchWeightBonus = *req.ResearchWeightBonus
+	}
+	for callType, maxTokens := range req.MaxTokens {
+		chain.Config.MaxTokens[callType] = maxTokens
+	}
+	if req.NameResolution != "" {
+		chain.Config.NameResolution = req.NameResolution
+	}
+	chain.Config.DevilsAdvocate = req.DevilsAdvocate
+	if req.DiscussionRounds != nil {
+		chain.Config.DiscussionRounds = *req.DiscussionRounds
+	}
+	chain.Config.ConsensusStrategy = req.ConsensusStrategy
+	chain.Config.LLMModel = req.LLMModel
+	chain.Config.LLMTemperature = req.LLMTemperature
+	if req.MaxInfluences != nil {
+		chain.Config.MaxInfluences = *req.MaxInfluences
+	}
+	if req.InfluenceOverflow != "" {
+		chain.Config.InfluenceOverflow = req.InfluenceOverflow
+	}
+	if req.MaxContentLength != nil {
+		chain.Config.MaxContentLength = *req.MaxContentLength
+	}
+	if req.ContentOverflow != "" {
+		chain.Config.ContentOverflow = req.ContentOverflow
+	}
+	if req.MinValidators != nil {
+		chain.Config.MinValidators = *req.MinValidators
+	}
+	if req.AcceptanceThreshold != nil {
+		chain.Config.AcceptanceThreshold = *req.AcceptanceThreshold
+	}
+	if req.MaxTxsPerBlock != nil {
+		chain.Config.MaxTxsPerBlock = *req.MaxTxsPerBlock
+	}
+	if req.MaxBlockBytes != nil {
+		chain.Config.MaxBlockBytes = *req.MaxBlockBytes
+	}
+	if req.RelationshipDecayRate != nil {
+		chain.Config.RelationshipDecayRate = *req.RelationshipDecayRate
+	}
+	if req.ConsensusTimeout != nil {
+		chain.Config.ConsensusTimeoutSeconds = *req.ConsensusTimeout
+	}
+	if req.ConsensusBuffer != nil {
+		chain.Config.ConsensusBufferSeconds = *req.ConsensusBuffer
+	}
+	if req.ConsensusSafetyMargin != nil {
+		chain.Config.ConsensusSafetyMarginSeconds = *req.ConsensusSafetyMargin
+	}
+	if req.MaxBlockSeconds != nil {
+		chain.Config.MaxBlockSeconds = *req.MaxBlockSeconds
+	}
+	if req.MaxLLMCallsPerBlock != nil {
+		chain.Config.MaxLLMCallsPerBlock = *req.MaxLLMCallsPerBlock
+	}
+	chain.Config.AllowUnsigned = req.AllowUnsigned
+	if len(req.TransactionTypes) > 0 {
+		chain.Config.TransactionTypes = req.TransactionTypes
+	}
 	addr := fmt.Sprintf("localhost:%d", p2pPort)
 	chain.RegisterNode(addr, bootstrapNode.GetP2PNode())
 
-	communication.BroadcastEvent(communication.EventChainCreated, map[string]interface{}{
-		"chainId":   req.ChainID,
-		"timestamp": time.Now(),
+	communication.BroadcastEvent(communication.EventChainCreated, communication.ChainCreatedEvent{
+		ChainID:   req.ChainID,
+		Timestamp: time.Now(),
 	})
 
 	// Register sample agents based on the genesis prompt
@@ -655,30 +1661,196 @@ func CreateChain(c *gin.Context) {
 
 	log.Printf("Loaded %d sample agents", len(agents))
 
-	// Register agents synchronously
-	for _, agent := range agents {
-		// Add a small delay between registrations for better UX
-		time.Sleep(500 * time.Millisecond)
+	delay := DefaultRegistrationDelay
+	if req.RegistrationDelayMs != nil {
+		delay = time.Duration(*req.RegistrationDelayMs) * time.Millisecond
+	}
+	parallelism := DefaultRegistrationParallelism
+	if req.RegistrationParallelism != nil {
+		parallelism = *req.RegistrationParallelism
+	}
+	bootstrapInfo := map[string]int{
+		"p2p_port": p2pPort,
+		"api_port": apiPort,
+	}
 
-		if err := registerAgent(req.ChainID, agent, p2pPort); err != nil {
-			log.Printf("Failed to register agent %s: %v", agent.ID, err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to register agent %s", agent.ID)})
-			return
-		}
-		log.Printf("Successfully registered agent: %s (%s)", agent.Name, agent.ID)
+	job := startRegistration(req.ChainID, len(agents))
+
+	// Large casts take a while; let the client poll for them instead of holding the request
+	if req.AsyncRegistration {
+		go registerSampleAgents(job, agents, delay, parallelism)
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":             "Chain created, registering agents",
+			"chain_id":            req.ChainID,
+			"bootstrap_node":      bootstrapInfo,
+			"total_agents":        len(agents),
+			"registration_status": fmt.Sprintf("/api/chains/%s/registration-status", req.ChainID),
+		})
+		return
+	}
+
+	status := registerSampleAgents(job, agents, delay, parallelism)
+	if len(status.Failed) > 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":  fmt.Sprintf("Failed to register agent %s", status.Failed[0].ID),
+			"failed": status.Failed,
+		})
+		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message":  "Chain created successfully",
-		"chain_id": req.ChainID,
-		"bootstrap_node": map[string]int{
-			"p2p_port": p2pPort,
-			"api_port": apiPort,
-		},
-		"registered_agents": len(agents),
+		"message":           "Chain created successfully",
+		"chain_id":          req.ChainID,
+		"bootstrap_node":    bootstrapInfo,
+		"registered_agents": status.Registered,
 	})
 }
 
+// Bounds on CreateChainRequest fields
+const (
+	maxChainIDLength       = 64
+	maxGenesisPromptLength = 500
+)
+
+// chainIDPattern allows letters, digits and inner dashes, so chain IDs are safe to use in file paths
+var chainIDPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?$`)
+
+// validateCreateChainRequest returns an error message per invalid field
+func validateCreateChainRequest(req CreateChainRequest) map[string]string {
+	fieldErrors := make(map[string]string)
+
+	switch {
+	case len(req.ChainID) > maxChainIDLength:
+		fieldErrors["chain_id"] = fmt.Sprintf("must be at most %d characters", maxChainIDLength)
+	case !chainIDPattern.MatchString(req.ChainID):
+		fieldErrors["chain_id"] = "may only contain letters, digits and dashes, and must start and end with a letter or digit"
+	}
+
+	prompt := strings.TrimSpace(req.GenesisPrompt)
+	switch {
+	case prompt == "":
+		fieldErrors["genesis_prompt"] = "must not be blank"
+	case utf8.RuneCountInString(prompt) > maxGenesisPromptLength:
+		fieldErrors["genesis_prompt"] = fmt.Sprintf("must be at most %d characters", maxGenesisPromptLength)
+	case strings.IndexFunc(prompt, unicode.IsControl) >= 0:
+		fieldErrors["genesis_prompt"] = "must not contain control characters"
+	}
+
+	if req.ResearchWeightBonus != nil && (*req.ResearchWeightBonus < 0 || *req.ResearchWeightBonus > core.MaxResearchWeightBonus) {
+		fieldErrors["research_weight_bonus"] = fmt.Sprintf("must be between 0 and %g", core.MaxResearchWeightBonus)
+	}
+	callTypes := make([]string, 0, len(req.MaxTokens))
+	for callType := range req.MaxTokens {
+		callTypes = append(callTypes, callType)
+	}
+	sort.Strings(callTypes) // Report the same call type every time
+	for _, callType := range callTypes {
+		maxTokens := req.MaxTokens[callType]
+		if !isKnownLLMCallType(callType) {
+			fieldErrors["max_tokens"] = fmt.Sprintf("unknown call type %q, expected one of %v", callType, core.LLMCallTypes)
+			break
+		}
+		if maxTokens <= 0 {
+			fieldErrors["max_tokens"] = fmt.Sprintf("must be positive for %q", callType)
+			break
+		}
+	}
+	if req.NameResolution != "" && !isKnownNameResolution(req.NameResolution) {
+		fieldErrors["name_resolution"] = fmt.Sprintf("unknown mode %q, expected one of %v", req.NameResolution, core.NameResolutionModes)
+	}
+	if req.DiscussionRounds != nil && (*req.DiscussionRounds < 1 || *req.DiscussionRounds > core.MaxDiscussionRounds) {
+		fieldErrors["discussion_rounds"] = fmt.Sprintf("must be between 1 and %d", core.MaxDiscussionRounds)
+	}
+	if _, ok := consensus.LookupConsensusStrategy(req.ConsensusStrategy); !ok {
+		fieldErrors["consensus_strategy"] = fmt.Sprintf("unknown strategy %q, expected one of %v", req.ConsensusStrategy, consensus.ConsensusStrategyNames())
+	}
+	if req.LLMModel != "" && !core.IsKnownLLMModel(req.LLMModel) {
+		fieldErrors["llm_model"] = fmt.Sprintf("unknown model %q, expected one of %v", req.LLMModel, core.LLMModels)
+	}
+	if req.LLMTemperature != nil && (*req.LLMTemperature < 0 || *req.LLMTemperature > core.MaxLLMTemperature) {
+		fieldErrors["llm_temperature"] = fmt.Sprintf("must be between 0 and %g", core.MaxLLMTemperature)
+	}
+
+	if req.MaxInfluences != nil && *req.MaxInfluences < 0 {
+		fieldErrors["max_influences"] = "cannot be negative"
+	}
+	if req.InfluenceOverflow != "" && req.InfluenceOverflow != core.InfluenceOverflowReject && req.InfluenceOverflow != core.InfluenceOverflowEvictOldest {
+		fieldErrors["influence_overflow"] = fmt.Sprintf("unknown mode %q, expected %q or %q",
+			req.InfluenceOverflow, core.InfluenceOverflowReject, core.InfluenceOverflowEvictOldest)
+	}
+	if req.MaxContentLength != nil && *req.MaxContentLength < 0 {
+		fieldErrors["max_content_length"] = "cannot be negative"
+	}
+	if req.ContentOverflow != "" && req.ContentOverflow != core.ContentOverflowReject && req.ContentOverflow != core.ContentOverflowSummarize {
+		fieldErrors["content_overflow"] = fmt.Sprintf("unknown mode %q, expected %q or %q",
+			req.ContentOverflow, core.ContentOverflowReject, core.ContentOverflowSummarize)
+	}
+	for _, txType := range req.TransactionTypes {
+		if strings.TrimSpace(txType) == "" {
+			fieldErrors["transaction_types"] = "must not contain blank types"
+			break
+		}
+	}
+
+	if req.MinValidators != nil && *req.MinValidators < 1 {
+		fieldErrors["min_validators"] = "must be at least 1"
+	}
+	if req.AcceptanceThreshold != nil && (*req.AcceptanceThreshold <= 0 || *req.AcceptanceThreshold > 1) {
+		fieldErrors["acceptance_threshold"] = "must be greater than 0 and at most 1"
+	}
+	if req.MaxTxsPerBlock != nil && *req.MaxTxsPerBlock < 0 {
+		fieldErrors["max_txs_per_block"] = "cannot be negative"
+	}
+	if req.MaxBlockBytes != nil && *req.MaxBlockBytes < 0 {
+		fieldErrors["max_block_bytes"] = "cannot be negative"
+	}
+	if req.RelationshipDecayRate != nil && (*req.RelationshipDecayRate < 0 || *req.RelationshipDecayRate >= 1) {
+		fieldErrors["relationship_decay_rate"] = "must be at least 0 and less than 1"
+	}
+	if req.RegistrationDelayMs != nil && (*req.RegistrationDelayMs < 0 || *req.RegistrationDelayMs > maxRegistrationDelayMs) {
+		fieldErrors["registration_delay_ms"] = fmt.Sprintf("must be between 0 and %d", maxRegistrationDelayMs)
+	}
+	if req.RegistrationParallelism != nil && (*req.RegistrationParallelism < 1 || *req.RegistrationParallelism > maxRegistrationParallelism) {
+		fieldErrors["registration_parallelism"] = fmt.Sprintf("must be between 1 and %d", maxRegistrationParallelism)
+	}
+
+	if req.ConsensusTimeout != nil && *req.ConsensusTimeout < 0 {
+		fieldErrors["consensus_timeout"] = "cannot be negative"
+	}
+	if req.ConsensusBuffer != nil && *req.ConsensusBuffer <= 0 {
+		fieldErrors["consensus_buffer"] = "must be positive"
+	}
+	if req.ConsensusSafetyMargin != nil && *req.ConsensusSafetyMargin <= 0 {
+		fieldErrors["consensus_safety_margin"] = "must be positive"
+	}
+	if req.MaxBlockSeconds != nil && *req.MaxBlockSeconds < 0 {
+		fieldErrors["max_block_seconds"] = "cannot be negative"
+	}
+	if req.MaxLLMCallsPerBlock != nil && *req.MaxLLMCallsPerBlock < 0 {
+		fieldErrors["max_llm_calls_per_block"] = "cannot be negative"
+	}
+
+	return fieldErrors
+}
+
+func isKnownLLMCallType(callType string) bool {
+	for _, known := range core.LLMCallTypes {
+		if callType == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownNameResolution(mode string) bool {
+	for _, known := range core.NameResolutionModes {
+		if mode == known {
+			return true
+		}
+	}
+	return false
+}
+
 // ListChains returns all available chains
 func ListChains(c *gin.Context) {
 	chains := core.GetAllChains()
@@ -687,6 +1859,29 @@ func ListChains(c *gin.Context) {
 	})
 }
 
+// ArchiveChain moves a chain's state to cold storage; it is restored on next access
+func ArchiveChain(c *gin.Context) {
+	chainID := c.GetString("chainID")
+
+	path, err := core.ArchiveChain(chainID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrChainNotFound) {
+			status = http.StatusNotFound
+		} else if errors.Is(err, core.ErrChainBusy) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Chain archived",
+		"chainId": chainID,
+		"archive": path,
+	})
+}
+
 // GetBlockDiscussions returns the discussions for a specific block by hash
 func GetBlockDiscussions(c *gin.Context) {
 	chainID := c.GetString("chainID")
@@ -702,7 +1897,7 @@ func GetBlockDiscussions(c *gin.Context) {
 	// Retrieve the data from EigenDA
 	offchainData, err := da.GetOffchainData(ref.BlobID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to retrieve discussions: %v", err)})
+		respondOffchainDataError(c, ref, err)
 		return
 	}
 
@@ -727,9 +1922,26 @@ func GetBlockDiscussions(c *gin.Context) {
 		"outcome":     offchainData.Outcome,
 		"agents":      offchainData.AgentIdentities,
 		"timestamp":   time.Unix(offchainData.Timestamp, 0).Format(time.RFC3339),
+		"provenance":  offchainData.Provenance,
 	})
 }
 
+// respondOffchainDataError reports a failure to read a block's discussions. A blob EigenDA
+// hasn't confirmed yet may not be retrievable, which is reported as still finalizing.
+func respondOffchainDataError(c *gin.Context, ref da.BlobReference, err error) {
+	if ref.Status == da.BlobStatusPending {
+		c.JSON(http.StatusAccepted, gin.H{
+			"status":      "finalizing",
+			"message":     "Discussions are still being finalized in EigenDA",
+			"blobId":      ref.BlobID,
+			"blockHash":   ref.BlockHash,
+			"blockHeight": ref.BlockHeight,
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to retrieve discussions: %v", err)})
+}
+
 // GetBlockDiscussionsByHeight returns the discussions for a specific block by height
 func GetBlockDiscussionsByHeight(c *gin.Context) {
 	chainID := c.GetString("chainID")
@@ -751,7 +1963,7 @@ func GetBlockDiscussionsByHeight(c *gin.Context) {
 	// Retrieve the data from EigenDA
 	offchainData, err := da.GetOffchainData(ref.BlobID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to retrieve discussions: %v", err)})
+		respondOffchainDataError(c, ref, err)
 		return
 	}
 
@@ -763,31 +1975,214 @@ func GetBlockDiscussionsByHeight(c *gin.Context) {
 		"outcome":     offchainData.Outcome,
 		"agents":      offchainData.AgentIdentities,
 		"timestamp":   offchainData.Timestamp,
+		"provenance":  offchainData.Provenance,
 	})
 }
 
-// ListBlockDiscussions returns a list of all blocks with discussions for a chain
+// Page sizes for ListBlockDiscussions
+const (
+	defaultDiscussionPageSize = 100
+	maxDiscussionPageSize     = 1000
+)
+
+// ListBlockDiscussions returns the blocks with stored discussions for a chain, newest first
+// unless ?order=asc. ?from= and ?to= bound the block height (inclusive), ?limit= and ?offset= page.
 func ListBlockDiscussions(c *gin.Context) {
 	chainID := c.GetString("chainID")
 
-	// Get all blob references for this chain
-	refs := da.GetBlobReferencesForChain(chainID)
-	if len(refs) == 0 {
-		c.JSON(http.StatusOK, gin.H{"blocks": []interface{}{}})
+	queryInt := func(name string, fallback int) (int, error) {
+		value := c.Query(name)
+		if value == "" {
+			return fallback, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		return n, nil
+	}
+	from, err := queryInt("from", 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := queryInt("to", -1)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit, err := queryInt("limit", defaultDiscussionPageSize)
+	if err != nil || limit == 0 || limit > maxDiscussionPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxDiscussionPageSize)})
 		return
 	}
+	offset, err := queryInt("offset", 0)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	order := c.DefaultQuery("order", "desc")
+	if order != "asc" && order != "desc" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": `order must be "asc" or "desc"`})
+		return
+	}
+
+	// Blob references come sorted by descending height
+	var refs []da.BlobReference
+	for _, ref := range da.GetBlobReferencesForChain(chainID) {
+		if ref.BlockHeight >= from && (to < 0 || ref.BlockHeight <= to) {
+			refs = append(refs, ref)
+		}
+	}
+	if order == "asc" {
+		for i, j := 0, len(refs)-1; i < j; i, j = i+1, j-1 {
+			refs[i], refs[j] = refs[j], refs[i]
+		}
+	}
+
+	total := len(refs)
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
 
 	// Create a summary for each block
-	blocks := make([]map[string]interface{}, len(refs))
-	for i, ref := range refs {
-		blocks[i] = map[string]interface{}{
+	blocks := make([]map[string]interface{}, 0, end-start)
+	for _, ref := range refs[start:end] {
+		blocks = append(blocks, map[string]interface{}{
 			"blockHash":   ref.BlockHash,
 			"blockHeight": ref.BlockHeight,
 			"outcome":     ref.Outcome,
 			"timestamp":   ref.Timestamp,
 			"blobId":      ref.BlobID,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blocks":  blocks,
+		"total":   total,
+		"hasMore": end < total,
+	})
+}
+
+// GetBlockTimeline returns everything that happened to a block, in order:
+// proposal, discussion rounds, votes, the consensus result and the offchain save
+func GetBlockTimeline(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	blockHash := c.Param("blockHash")
+
+	events := consensus.GetBlockTimeline(chainID, blockHash)
+	ref, stored := da.GetBlobReferenceByBlockHash(chainID, blockHash)
+
+	// Nothing recorded in memory (e.g. after a restart), rebuild from the stored discussions
+	if len(events) == 0 && stored {
+		offchainData, err := da.GetOffchainData(ref.BlobID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to retrieve discussions: %v", err)})
+			return
+		}
+		rounds := core.DefaultDiscussionRounds
+		if offchainData.Provenance != nil && offchainData.Provenance.DiscussionRounds > 0 {
+			rounds = offchainData.Provenance.DiscussionRounds
+		}
+		for _, d := range offchainData.Discussions {
+			kind := consensus.TimelineDiscussion
+			if d.Round > rounds {
+				kind = consensus.TimelineVote
+			}
+			events = append(events, consensus.TimelineEvent{
+				Kind:        kind,
+				Timestamp:   d.Timestamp,
+				ValidatorID: d.ValidatorID,
+				Round:       d.Round,
+				Detail:      strings.ToLower(d.Type),
+			})
 		}
+		events = append(events, consensus.TimelineEvent{
+			Kind:      consensus.TimelineConsensusResult,
+			Timestamp: time.Unix(offchainData.Timestamp, 0),
+			Detail:    offchainData.Outcome,
+		})
+	}
+
+	if len(events) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No timeline found for this block"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"blocks": blocks})
+	// Make sure the DA status is reflected even if the save event wasn't recorded locally
+	if stored {
+		saved := false
+		for _, e := range events {
+			if e.Kind == consensus.TimelineOffchainSaved {
+				saved = true
+				break
+			}
+		}
+		if !saved {
+			events = append(events, consensus.TimelineEvent{
+				Kind:      consensus.TimelineOffchainSaved,
+				Timestamp: time.Unix(ref.Timestamp, 0),
+				Detail:    ref.BlobID,
+			})
+		}
+	}
+	consensus.SortTimeline(events)
+
+	c.JSON(http.StatusOK, gin.H{
+		"blockHash": blockHash,
+		"timeline":  events,
+	})
+}
+
+// StreamBlockDiscussions streams a block's discussions and votes as Server-Sent Events.
+// Discussions recorded so far are replayed first; the stream ends with the consensus result.
+func StreamBlockDiscussions(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	blockHash := c.Param("blockHash")
+
+	stream, err := consensus.GetConsensusManager(chainID).StreamBlock(blockHash)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	defer stream.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	eventFor := func(d consensus.Discussion) string {
+		if d.Round >= stream.FinalRound {
+			return consensus.TimelineVote
+		}
+		return consensus.TimelineDiscussion
+	}
+
+	sent := make(map[string]bool, len(stream.Replay))
+	for _, d := range stream.Replay {
+		sent[d.ID] = true
+		c.SSEvent(eventFor(d), d)
+	}
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case d := <-stream.Discussions:
+			if !sent[d.ID] {
+				sent[d.ID] = true
+				c.SSEvent(eventFor(d), d)
+			}
+			return true
+		case result := <-stream.Result:
+			c.SSEvent(consensus.TimelineConsensusResult, result)
+			return false
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
 }
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+	"github.com/gin-gonic/gin"
+)
+
+// Defaults and bounds for registering a new chain's sample agents
+const (
+	DefaultRegistrationDelay       = 500 * time.Millisecond // Pause before each registration, for the UI to follow along
+	DefaultRegistrationParallelism = 1
+	maxRegistrationDelayMs         = 10000
+	maxRegistrationParallelism     = 16
+)
+
+// States of an agent registration job
+const (
+	RegistrationRunning   = "running"
+	RegistrationCompleted = "completed"
+	RegistrationFailed    = "failed" // Finished, but some agents couldn't be registered
+)
+
+// RegistrationStatus reports the progress of registering a chain's sample agents
+type RegistrationStatus struct {
+	ChainID    string            `json:"chain_id"`
+	State      string            `json:"state"`
+	Total      int               `json:"total"`
+	Registered int               `json:"registered"`
+	Failed     []bulkAgentResult `json:"failed"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+}
+
+var (
+	registrationMu   sync.RWMutex
+	registrationJobs = make(map[string]*RegistrationStatus) // By chain ID
+)
+
+// startRegistration records a registration of total agents on a chain, before it begins, so
+// it can be polled as soon as the chain's creation is answered
+func startRegistration(chainID string, total int) *RegistrationStatus {
+	job := &RegistrationStatus{
+		ChainID:   chainID,
+		State:     RegistrationRunning,
+		Total:     total,
+		Failed:    []bulkAgentResult{},
+		StartedAt: time.Now(),
+	}
+	registrationMu.Lock()
+	defer registrationMu.Unlock()
+	registrationJobs[chainID] = job
+	return job
+}
+
+// registerSampleAgents registers agents on job's chain, parallelism at a time, each after
+// delay. Progress is recorded in job; the returned status is the final one.
+func registerSampleAgents(job *RegistrationStatus, agents []core.Agent, delay time.Duration, parallelism int) RegistrationStatus {
+	chainID := job.ChainID
+
+	slots := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for _, agent := range agents {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(agent core.Agent) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			time.Sleep(delay)
+
+			err := registerAgent(chainID, agent)
+			registrationMu.Lock()
+			defer registrationMu.Unlock()
+			if err != nil {
+				log.Printf("Failed to register agent %s: %v", agent.ID, err)
+				job.Failed = append(job.Failed, bulkAgentResult{ID: agent.ID, Name: agent.Name, Role: agent.Role, Error: err.Error()})
+				return
+			}
+			job.Registered++
+			log.Printf("Successfully registered agent: %s (%s)", agent.Name, agent.ID)
+		}(agent)
+	}
+	wg.Wait()
+
+	registrationMu.Lock()
+	defer registrationMu.Unlock()
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.State = RegistrationCompleted
+	if len(job.Failed) > 0 {
+		job.State = RegistrationFailed
+	}
+	return copyRegistrationStatus(job)
+}
+
+func copyRegistrationStatus(job *RegistrationStatus) RegistrationStatus {
+	status := *job
+	status.Failed = append([]bulkAgentResult{}, job.Failed...)
+	return status
+}
+
+// GetRegistrationStatus reports how far registering a chain's sample agents has come
+func GetRegistrationStatus(c *gin.Context) {
+	chainID := c.GetString("chainID")
+	if core.GetChain(chainID) == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Chain not found"})
+		return
+	}
+
+	registrationMu.RLock()
+	job, ok := registrationJobs[chainID]
+	var status RegistrationStatus
+	if ok {
+		status = copyRegistrationStatus(job)
+	}
+	registrationMu.RUnlock()
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("No agent registration recorded for chain %s", chainID)})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
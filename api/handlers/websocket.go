@@ -3,6 +3,7 @@ package handlers
 import (
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/NethermindEth/chaoschain-launchpad/communication"
 	"github.com/gin-gonic/gin"
@@ -15,7 +16,26 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// HandleWebSocket streams events to a client. With ?chain=<id> the client only receives that
+// chain's events and global ones. When WS_TOKEN_SECRET is set, clients must subscribe to a
+// chain and present its token as ?token= or an Authorization bearer token.
 func HandleWebSocket(c *gin.Context) {
+	chainID := c.Query("chain")
+	if communication.WSAuthRequired() {
+		if chainID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "A chain subscription is required"})
+			return
+		}
+		token := c.Query("token")
+		if token == "" {
+			token = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+		if !communication.VerifyChainToken(chainID, token) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid token for chain " + chainID})
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("Failed to upgrade connection: %v", err)
@@ -24,7 +44,7 @@ func HandleWebSocket(c *gin.Context) {
 
 	// Register client
 	wsManager := communication.GetWSManager()
-	wsManager.Register() <- conn
+	wsManager.Subscribe(conn, chainID)
 
 	// Handle disconnection
 	go func() {
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const redactedValue = "[REDACTED]"
+
+// LoggingConfig controls the access log written for every API request
+type LoggingConfig struct {
+	Logger *slog.Logger // Defaults to slog.Default()
+
+	// LogBodies adds JSON request and response bodies to each entry, with RedactFields removed
+	LogBodies    bool
+	MaxBodyBytes int // Bodies larger than this are logged as truncated, not parsed
+
+	// RedactFields are JSON keys (matched case-insensitively, at any depth) whose values are
+	// replaced before bodies are logged. The Authorization header is never logged.
+	RedactFields []string
+}
+
+// DefaultLoggingConfig logs request metadata only. Set API_LOG_BODIES=true to include bodies.
+func DefaultLoggingConfig() LoggingConfig {
+	logBodies, _ := strconv.ParseBool(os.Getenv("API_LOG_BODIES"))
+	return LoggingConfig{
+		LogBodies:    logBodies,
+		MaxBodyBytes: 4096,
+		RedactFields: []string{
+			"api_key", "apiKey", "genesis_prompt", "genesisPrompt",
+			"private_key", "privateKey", "password", "secret", "token",
+		},
+	}
+}
+
+// WithLogging replaces the default access logging configuration
+func WithLogging(config LoggingConfig) RouteOption {
+	return func(o *routeOptions) {
+		o.logging = &config
+	}
+}
+
+// WithoutLogging turns off the built-in access log
+func WithoutLogging() RouteOption {
+	return func(o *routeOptions) {
+		o.accessLog = false
+	}
+}
+
+// bodyCaptureWriter keeps the first limit bytes of a response body
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body  bytes.Buffer
+	limit int
+}
+
+func (w *bodyCaptureWriter) Write(data []byte) (int, error) {
+	if remaining := w.limit + 1 - w.body.Len(); remaining > 0 {
+		if len(data) < remaining {
+			remaining = len(data)
+		}
+		w.body.Write(data[:remaining])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// RequestLogger writes one structured log entry per request with its method, path, chain,
+// status and duration
+func RequestLogger(config LoggingConfig) gin.HandlerFunc {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	redact := make(map[string]bool, len(config.RedactFields))
+	for _, field := range config.RedactFields {
+		redact[strings.ToLower(field)] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		var requestBody []byte
+		var capture *bodyCaptureWriter
+		if config.LogBodies {
+			if c.Request.Body != nil {
+				requestBody, _ = io.ReadAll(c.Request.Body)
+				c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+			}
+			capture = &bodyCaptureWriter{ResponseWriter: c.Writer, limit: config.MaxBodyBytes}
+			c.Writer = capture
+		}
+
+		c.Next()
+
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.String("chainID", c.GetString("chainID")),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("clientIP", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		if config.LogBodies {
+			if len(requestBody) > 0 {
+				attrs = append(attrs, slog.String("requestBody", redactBody(requestBody, redact, config.MaxBodyBytes)))
+			}
+			if capture.body.Len() > 0 {
+				attrs = append(attrs, slog.String("responseBody", redactBody(capture.body.Bytes(), redact, config.MaxBodyBytes)))
+			}
+		}
+
+		level := slog.LevelInfo
+		if c.Writer.Status() >= 500 {
+			level = slog.LevelError
+		} else if c.Writer.Status() >= 400 {
+			level = slog.LevelWarn
+		}
+		logger.Log(c.Request.Context(), level, "api request", attrs...)
+	}
+}
+
+// redactBody returns a JSON body with sensitive fields replaced. Bodies that are too large
+// or aren't JSON are summarized instead so nothing sensitive leaks through unparsed.
+func redactBody(body []byte, redact map[string]bool, maxBytes int) string {
+	if maxBytes > 0 && len(body) > maxBytes {
+		return "[truncated " + strconv.Itoa(len(body)) + " bytes]"
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "[non-JSON " + strconv.Itoa(len(body)) + " bytes]"
+	}
+
+	redacted, err := json.Marshal(redactValue(value, redact))
+	if err != nil {
+		return "[unloggable body]"
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}, redact map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if redact[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(inner, redact)
+			}
+		}
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactValue(inner, redact)
+		}
+	}
+	return value
+}
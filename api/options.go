@@ -0,0 +1,83 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+// RouteGroup names a set of related endpoints that can be left out of the router
+type RouteGroup string
+
+const (
+	RoutesChains       RouteGroup = "chains"       // Chain creation, listing, status and archival
+	RoutesAgents       RouteGroup = "agents"       // Agent registration and social status
+	RoutesValidators   RouteGroup = "validators"   // Validator listing, influences and relationships
+	RoutesBlocks       RouteGroup = "blocks"       // Block lookup, proposal, discussions and timelines
+	RoutesTransactions RouteGroup = "transactions" // Transaction submission
+	RoutesConsensus    RouteGroup = "consensus"    // Node-wide consensus load
+	RoutesForum        RouteGroup = "forum"        // Discussion threads
+	RoutesWebSocket    RouteGroup = "websocket"    // The /ws event stream
+	RoutesMetrics      RouteGroup = "metrics"      // The /metrics Prometheus endpoint
+)
+
+// RouteOption customizes SetupRoutes
+type RouteOption func(*routeOptions)
+
+type routeOptions struct {
+	middleware  []gin.HandlerFunc
+	disabled    map[RouteGroup]bool
+	defaultCORS bool
+	cors        *CORSConfig
+	accessLog   bool
+	logging     *LoggingConfig
+}
+
+func newRouteOptions(opts []RouteOption) *routeOptions {
+	options := &routeOptions{
+		disabled:    make(map[RouteGroup]bool),
+		defaultCORS: true,
+		accessLog:   true,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+func (o *routeOptions) enabled(group RouteGroup) bool {
+	return !o.disabled[group]
+}
+
+// WithMiddleware adds middleware that runs on every request after access logging and CORS handling
+func WithMiddleware(middleware ...gin.HandlerFunc) RouteOption {
+	return func(o *routeOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// WithoutRoutes leaves the given route groups out of the router
+func WithoutRoutes(groups ...RouteGroup) RouteOption {
+	return func(o *routeOptions) {
+		for _, group := range groups {
+			o.disabled[group] = true
+		}
+	}
+}
+
+// WithOnlyRoutes registers just the given route groups
+func WithOnlyRoutes(groups ...RouteGroup) RouteOption {
+	return func(o *routeOptions) {
+		keep := make(map[RouteGroup]bool, len(groups))
+		for _, group := range groups {
+			keep[group] = true
+		}
+		for _, group := range []RouteGroup{RoutesChains, RoutesAgents, RoutesValidators, RoutesBlocks,
+			RoutesTransactions, RoutesConsensus, RoutesForum, RoutesWebSocket, RoutesMetrics} {
+			o.disabled[group] = !keep[group]
+		}
+	}
+}
+
+// WithoutDefaultCORS skips the built-in CORS middleware, e.g. when the embedding server handles CORS itself
+func WithoutDefaultCORS() RouteOption {
+	return func(o *routeOptions) {
+		o.defaultCORS = false
+	}
+}
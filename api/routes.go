@@ -1,9 +1,8 @@
 package api
 
 import (
-	"os"
-
 	"github.com/NethermindEth/chaoschain-launchpad/api/handlers"
+	"github.com/NethermindEth/chaoschain-launchpad/metrics"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,46 +19,115 @@ func chainIDMiddleware(chainID string) gin.HandlerFunc {
 	}
 }
 
-// SetupRoutes initializes all API endpoints
-func SetupRoutes(router *gin.Engine, chainID string) {
+// chainParamMiddleware overrides the chainID with the :chainId path parameter
+func chainParamMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("chainID", c.Param("chainId"))
+		c.Next()
+	}
+}
+
+// SetupRoutes initializes all API endpoints.
+// Options add middleware or leave route groups out; with none the full API is served.
+func SetupRoutes(router *gin.Engine, chainID string, opts ...RouteOption) {
+	options := newRouteOptions(opts)
+
+	// Structured access log
+	if options.accessLog {
+		logging := DefaultLoggingConfig()
+		if options.logging != nil {
+			logging = *options.logging
+		}
+		router.Use(RequestLogger(logging))
+	}
+
 	// Add CORS middleware
-	router.Use(func(c *gin.Context) {
-		port := os.Getenv("PORT")
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "http://localhost:"+port)
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, Authorization, X-Chain-Id")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+	if options.defaultCORS {
+		cors := DefaultCORSConfig()
+		if options.cors != nil {
+			cors = *options.cors
 		}
+		router.Use(corsMiddleware(cors))
+	}
 
-		c.Next()
-	})
+	// Caller-supplied middleware
+	router.Use(options.middleware...)
+
+	// Liveness and readiness probes sit outside /api and every route group
+	router.GET("/health", handlers.Health)
+	router.GET("/ready", chainIDMiddleware(chainID), handlers.Ready)
+	if options.enabled(RoutesMetrics) {
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
 
 	api := router.Group("/api")
 	api.Use(chainIDMiddleware(chainID))
-	{
+
+	// Chain-scoped endpoints take the chain from the path instead of the header
+	chainGroup := api.Group("/chains/:chainId")
+	chainGroup.Use(chainParamMiddleware())
+
+	if options.enabled(RoutesChains) {
 		api.POST("/chains", handlers.CreateChain)
 		api.GET("/chains", handlers.ListChains)
-		api.POST("/register", handlers.RegisterAgent)
-		api.GET("/blocks/:height", handlers.GetBlock)
+		chainGroup.GET("/registration-status", handlers.GetRegistrationStatus)
 		api.GET("/chain/status", handlers.GetNetworkStatus)
-		api.POST("/transactions", handlers.SubmitTransaction)
-		api.GET("/validators", handlers.GetValidators)
+		chainGroup.POST("/archive", handlers.ArchiveChain)
+	}
+
+	if options.enabled(RoutesAgents) {
+		api.POST("/register", handlers.RegisterAgent)
 		api.GET("/social/:agentID", handlers.GetSocialStatus)
+		chainGroup.POST("/agents/bulk", handlers.RegisterAgentsBulk)
+		chainGroup.DELETE("/agents/:agentID", handlers.RemoveAgent)
+	}
+
+	if options.enabled(RoutesValidators) {
+		api.GET("/validators", handlers.GetValidators)
 		api.POST("/validators/:agentID/influences", handlers.AddInfluence)
 		api.POST("/validators/:agentID/relationships", handlers.UpdateRelationship)
+		chainGroup.GET("/validators/:id/pubkey", handlers.GetValidatorPublicKey)
+		chainGroup.GET("/validators/:id/reasoning", handlers.GetValidatorReasoning)
+		chainGroup.GET("/validators/:id/policy", handlers.GetValidatorPolicy)
+		chainGroup.PUT("/validators/:id/policy", handlers.UpdateValidatorPolicy)
+	}
+
+	if options.enabled(RoutesBlocks) {
+		api.GET("/blocks/:height", handlers.GetBlock)
 		api.POST("/block/propose", handlers.ProposeBlock)
-		api.GET("/forum/threads", handlers.GetAllThreads)
 		blockGroup := api.Group("/blocks")
 		{
 			blockGroup.GET("/discussions/:blockHash", handlers.GetBlockDiscussions)
 			blockGroup.GET("/discussions/height/:height", handlers.GetBlockDiscussionsByHeight)
 			blockGroup.GET("/discussions", handlers.ListBlockDiscussions)
 		}
+		chainGroup.GET("/blocks/:blockHash/timeline", handlers.GetBlockTimeline)
+		chainGroup.GET("/blocks/:blockHash/stream", handlers.StreamBlockDiscussions)
+		// gin allows one wildcard name per path segment, so the height goes by :blockHash here
+		chainGroup.GET("/blocks/:blockHash/header", handlers.GetBlockHeader)
+		chainGroup.POST("/producer/start", handlers.StartProducer)
+		chainGroup.POST("/producer/stop", handlers.StopProducer)
+	}
+
+	if options.enabled(RoutesTransactions) {
+		api.POST("/transactions", handlers.SubmitTransaction)
+		chainGroup.POST("/transactions/batch", handlers.SubmitTransactionBatch)
+		chainGroup.GET("/accounts/:address/nonce", handlers.GetAccountNonce)
+	}
+
+	if options.enabled(RoutesConsensus) {
+		api.GET("/consensus/load", handlers.GetConsensusLoad)
+		chainGroup.GET("/consensus/:blockHeight", handlers.GetConsensusProgress)
+		chainGroup.POST("/consensus/:blockHeight/cancel", handlers.CancelConsensus)
+	}
+
+	if options.enabled(RoutesForum) {
+		api.GET("/forum/threads", handlers.GetAllThreads)
+		chainGroup.GET("/threads/:threadId", handlers.GetThreadTree)
 	}
 
 	// WebSocket endpoint
-	router.GET("/ws", handlers.HandleWebSocket)
+	if options.enabled(RoutesWebSocket) {
+		router.GET("/ws", handlers.HandleWebSocket)
+	}
 }
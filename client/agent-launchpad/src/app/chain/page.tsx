@@ -89,6 +89,8 @@ export default function GenesisPage() {
       const data = await createChain({
         chain_id: chainName.toLowerCase().replace(/\s+/g, '-'),
         genesis_prompt: genesisPrompt,
+        // The forum submits transactions without signing them
+        allow_unsigned: true,
       });
       console.log('Chain created successfully:', data);
 
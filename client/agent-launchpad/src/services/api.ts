@@ -19,6 +19,7 @@ interface RegisterAgentResponse {
 interface CreateChainParams {
     chain_id: string;
     genesis_prompt: string;
+    allow_unsigned?: boolean;
 }
 
 interface CreateChainResponse {
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/NethermindEth/chaoschain-launchpad/api"
 	"github.com/NethermindEth/chaoschain-launchpad/cmd/node"
@@ -12,11 +15,17 @@ import (
 	da "github.com/NethermindEth/chaoschain-launchpad/da_layer"
 	"github.com/NethermindEth/chaoschain-launchpad/mempool"
 	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+	"github.com/NethermindEth/chaoschain-launchpad/validator"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:]))
+	}
+
 	// Parse command line flags
 	chainID := flag.String("chain", "mainnet", "Chain ID")
 	port := flag.Int("port", 8080, "P2P port")
@@ -37,6 +46,18 @@ func main() {
 		log.Fatalf("Failed to start node: %v", err)
 	}
 
+	// Open persistent storage for validator state
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "data/badger"
+	}
+	if store, err := storage.NewBadgerStorage(storageDir); err != nil {
+		log.Printf("Warning: persistent storage disabled: %v", err)
+	} else {
+		storage.SetDefault(store)
+		defer store.Close()
+	}
+
 	// Initialize chain-specific components
 	core.InitBlockchain(*chainID, mempool.GetMempool(*chainID))
 
@@ -59,6 +80,18 @@ func main() {
 	core.SetupNATS(*nats)
 	defer core.CloseNATS()
 
+	// Optionally move chains without recent blocks to cold storage
+	if idle := os.Getenv("CHAIN_ARCHIVE_IDLE"); idle != "" {
+		if d, err := time.ParseDuration(idle); err != nil {
+			log.Printf("Warning: invalid CHAIN_ARCHIVE_IDLE %q: %v", idle, err)
+		} else {
+			core.StartAutoArchive(d)
+		}
+	}
+
+	// Let relationships between agents that stop interacting fade back to neutral
+	validator.StartRelationshipDecay(validator.DefaultRelationshipDecayInterval)
+
 	log.Printf("Chain %s started with P2P port %d and API port %d", *chainID, *port, *apiPort)
 
 	// Start API server
@@ -73,3 +106,51 @@ func main() {
 	}
 
 }
+
+// runExport writes a chain's offchain discussion archive to a file. It reads blob references
+// from the node's local storage and the master index, so run it against a stopped node's
+// STORAGE_DIR.
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	chainID := flags.String("chain", "mainnet", "Chain ID")
+	out := flags.String("out", "archive.json", "File to write the archive to")
+	nats := flags.String("nats", "nats://localhost:4222", "NATS URL")
+	flags.Parse(args)
+
+	storageDir := os.Getenv("STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = "data/badger"
+	}
+	if store, err := storage.NewBadgerStorage(storageDir); err != nil {
+		log.Printf("Warning: local storage unavailable, only the master index will be read: %v", err)
+	} else {
+		storage.SetDefault(store)
+		defer store.Close()
+	}
+
+	if err := da.SetupGlobalDAService(*nats); err != nil {
+		log.Printf("Warning: EigenDA service unavailable, only cached blobs can be exported: %v", err)
+	} else {
+		defer da.CloseGlobalDAService()
+	}
+
+	archive := da.ExportChainArchive(*chainID)
+	encoded, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode the archive: %v", err)
+		return 1
+	}
+	if err := os.WriteFile(*out, encoded, 0644); err != nil {
+		log.Printf("Failed to write %s: %v", *out, err)
+		return 1
+	}
+
+	log.Printf("Exported %d blocks of chain %s to %s", len(archive.Blocks), *chainID, *out)
+	for _, failed := range archive.Failed {
+		log.Printf("Could not fetch blob %s for block %d: %s", failed.BlobID, failed.BlockHeight, failed.Error)
+	}
+	if len(archive.Failed) > 0 {
+		return 2
+	}
+	return 0
+}
@@ -51,9 +51,9 @@ func (n *Node) Start() error {
 	// Give the server a moment to initialize
 	time.Sleep(time.Second)
 
-	// If bootstrap node is specified, connect to it
+	// If bootstrap node is specified, connect to it and keep reconnecting if it drops
 	if n.config.BootstrapNode != "" {
-		n.p2pNode.ConnectToPeer(n.config.BootstrapNode)
+		n.p2pNode.ConnectToSeed(n.config.BootstrapNode)
 	}
 
 	return nil
@@ -61,6 +61,7 @@ func (n *Node) Start() error {
 
 func (n *Node) Stop() {
 	close(n.shutdown)
+	n.p2pNode.Stop()
 }
 
 func (n *Node) GetP2PPort() int {
@@ -0,0 +1,48 @@
+package communication
+
+import (
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Payloads of the events broadcast over WebSocket, so clients in Go can unmarshal WSEvent
+// payloads into them. Consensus events carry the consensus package's own types instead:
+// consensus.Discussion for AGENT_VOTE, consensus.ConsensusResult for BLOCK_VERDICT,
+// consensus.VotingResult for VOTING_RESULT, consensus.CancelledConsensus for
+// CONSENSUS_CANCELLED and core.Block for BLOCK_FINALIZED.
+
+// AgentRegisteredEvent is the payload of EventAgentRegistered
+type AgentRegisteredEvent struct {
+	Agent     core.Agent `json:"agent"`
+	ChainID   string     `json:"chainId"`
+	NodePort  int        `json:"nodePort"` // P2P port of the agent's node
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// AgentRemovedEvent is the payload of EventAgentRemoved
+type AgentRemovedEvent struct {
+	AgentID   string    `json:"agentId"`
+	Role      string    `json:"role"` // "validator" or "producer"
+	ChainID   string    `json:"chainId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ChainCreatedEvent is the payload of EventChainCreated
+type ChainCreatedEvent struct {
+	ChainID   string    `json:"chainId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PolicyUpdatedEvent is the payload of EventPolicyUpdated
+type PolicyUpdatedEvent struct {
+	AgentID        string    `json:"agentId"`
+	ChainID        string    `json:"chainId"`
+	Policy         string    `json:"policy"`
+	PreviousPolicy string    `json:"previousPolicy"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// AdHocEvent is the payload of events without a schema of their own, for one-off
+// notifications and experiments. Prefer adding a struct above for anything clients rely on.
+type AdHocEvent map[string]interface{}
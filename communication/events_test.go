@@ -0,0 +1,51 @@
+package communication
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestTypedEventsRoundTrip(t *testing.T) {
+	sent := WSEvent{
+		Type:    EventAgentRegistered,
+		ChainID: "foo",
+		Payload: AgentRegisteredEvent{
+			Agent:     core.Agent{ID: "a1", Name: "Alice", Role: "validator"},
+			ChainID:   "foo",
+			NodePort:  9001,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+		},
+	}
+	data, err := json.Marshal(sent)
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+
+	// Clients that read the payload as loose JSON see the same keys as before
+	var loose struct {
+		Payload map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &loose); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	for _, key := range []string{"agent", "chainId", "nodePort", "timestamp"} {
+		if _, ok := loose.Payload[key]; !ok {
+			t.Fatalf("payload is missing %q: %s", key, data)
+		}
+	}
+
+	var received struct {
+		Type    string               `json:"type"`
+		Payload AgentRegisteredEvent `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("failed to decode typed payload: %v", err)
+	}
+	if !reflect.DeepEqual(received.Payload, sent.Payload) {
+		t.Fatalf("expected %+v, got %+v", sent.Payload, received.Payload)
+	}
+}
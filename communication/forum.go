@@ -2,6 +2,7 @@ package communication
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ type ForumMessage struct {
 	Sender    string    `json:"sender"`
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp"`
+	InReplyTo string    `json:"in_reply_to,omitempty"` // ID of the message this replies to, empty for a top-level message
 }
 
 // ForumThread represents a discussion thread for a block proposal.
@@ -46,14 +48,24 @@ func CreateThread(threadID, title, creator string) *ForumThread {
 	return thread
 }
 
-// AddReply appends a reply message to an existing thread.
+// AddReply appends a top-level reply message to an existing thread.
 func AddReply(threadID, sender, content string) error {
+	_, err := AddReplyTo(threadID, sender, content, "")
+	return err
+}
+
+// AddReplyTo appends a message to an existing thread in reply to the message with ID
+// inReplyTo, or as a top-level message if inReplyTo is empty.
+func AddReplyTo(threadID, sender, content, inReplyTo string) (ForumMessage, error) {
 	threadsMu.Lock()
 	defer threadsMu.Unlock()
 
 	thread, exists := threads[threadID]
 	if !exists {
-		return fmt.Errorf("thread with id %s does not exist", threadID)
+		return ForumMessage{}, fmt.Errorf("thread with id %s does not exist", threadID)
+	}
+	if inReplyTo != "" && !thread.hasMessage(inReplyTo) {
+		return ForumMessage{}, fmt.Errorf("message with id %s not found in thread %s", inReplyTo, threadID)
 	}
 
 	reply := ForumMessage{
@@ -61,9 +73,19 @@ func AddReply(threadID, sender, content string) error {
 		Sender:    sender,
 		Content:   content,
 		Timestamp: time.Now(),
+		InReplyTo: inReplyTo,
 	}
 	thread.Messages = append(thread.Messages, reply)
-	return nil
+	return reply, nil
+}
+
+func (t *ForumThread) hasMessage(id string) bool {
+	for _, msg := range t.Messages {
+		if msg.ID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // GetThread retrieves a thread by its ID.
@@ -89,3 +111,61 @@ func GetAllThreads() []*ForumThread {
 	}
 	return threadsList
 }
+
+// ThreadNode is a message in a thread together with the replies to it.
+type ThreadNode struct {
+	ForumMessage
+	Replies []*ThreadNode `json:"replies"`
+}
+
+// ThreadTree is a thread's metadata and messages, with replies nested under the message
+// they answer.
+type ThreadTree struct {
+	ThreadID  string        `json:"thread_id"`
+	Title     string        `json:"title"`
+	Creator   string        `json:"creator"`
+	CreatedAt time.Time     `json:"created_at"`
+	Count     int           `json:"message_count"`
+	Messages  []*ThreadNode `json:"messages"` // Top-level messages, oldest first
+}
+
+// GetThreadTree returns a snapshot of a thread with its messages nested by InReplyTo.
+// Messages and replies at each level are ordered by timestamp; a reply whose parent isn't
+// in the thread is shown at the top level.
+func GetThreadTree(threadID string) (*ThreadTree, error) {
+	threadsMu.Lock()
+	thread, exists := threads[threadID]
+	if !exists {
+		threadsMu.Unlock()
+		return nil, fmt.Errorf("thread with id %s not found", threadID)
+	}
+	tree := &ThreadTree{
+		ThreadID:  thread.ThreadID,
+		Title:     thread.Title,
+		Creator:   thread.Creator,
+		CreatedAt: thread.CreatedAt,
+		Count:     len(thread.Messages),
+		Messages:  []*ThreadNode{},
+	}
+	messages := append([]ForumMessage(nil), thread.Messages...)
+	threadsMu.Unlock()
+
+	// Appended order is already chronological; the stable sort only matters for clock skew
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+
+	nodes := make(map[string]*ThreadNode, len(messages))
+	for _, msg := range messages {
+		nodes[msg.ID] = &ThreadNode{ForumMessage: msg, Replies: []*ThreadNode{}}
+	}
+	for _, msg := range messages {
+		node := nodes[msg.ID]
+		if parent, ok := nodes[msg.InReplyTo]; ok && msg.InReplyTo != msg.ID {
+			parent.Replies = append(parent.Replies, node)
+		} else {
+			tree.Messages = append(tree.Messages, node)
+		}
+	}
+	return tree, nil
+}
@@ -0,0 +1,47 @@
+package communication
+
+import "testing"
+
+func TestGetThreadTreeNestsReplies(t *testing.T) {
+	CreateThread("tree-thread", "Block Proposal tree-thread", "Alice")
+
+	first, err := AddReplyTo("tree-thread", "Bob", "first", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer, err := AddReplyTo("tree-thread", "Carol", "answer", first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddReplyTo("tree-thread", "Dave", "nested", answer.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddReply("tree-thread", "Eve", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddReplyTo("tree-thread", "Mallory", "orphan", "missing"); err == nil {
+		t.Fatal("expected a reply to an unknown message to be rejected")
+	}
+
+	tree, err := GetThreadTree("tree-thread")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Title != "Block Proposal tree-thread" || tree.Creator != "Alice" || tree.Count != 4 {
+		t.Fatalf("unexpected metadata: %+v", tree)
+	}
+	if len(tree.Messages) != 2 || tree.Messages[0].Content != "first" || tree.Messages[1].Content != "second" {
+		t.Fatalf("expected two top-level messages in order, got %+v", tree.Messages)
+	}
+	replies := tree.Messages[0].Replies
+	if len(replies) != 1 || replies[0].Content != "answer" {
+		t.Fatalf("expected the answer nested under the first message, got %+v", replies)
+	}
+	if len(replies[0].Replies) != 1 || replies[0].Replies[0].Content != "nested" {
+		t.Fatalf("expected the nested reply under the answer, got %+v", replies[0].Replies)
+	}
+
+	if _, err := GetThreadTree("no-such-thread"); err == nil {
+		t.Fatal("expected an error for an unknown thread")
+	}
+}
@@ -26,6 +26,12 @@ func (m *Messenger) PublishGlobal(subject, message string) error {
 	return m.broker.Publish(subject, []byte(message))
 }
 
+// PublishCritical publishes a message that must survive a NATS outage; it is re-published
+// once the connection is back.
+func (m *Messenger) PublishCritical(subject, message string) error {
+	return m.broker.PublishCritical(subject, []byte(message))
+}
+
 // PublishPrivate sends a message directly to a specific agent by using a private subject.
 func (m *Messenger) PublishPrivate(agentID, message string) error {
 	subject := fmt.Sprintf("agent.%s.private", agentID)
@@ -9,27 +9,38 @@ import (
 
 type WSEvent struct {
 	Type    string      `json:"type"`
+	ChainID string      `json:"chainId,omitempty"` // Empty for global events sent to every client
 	Payload interface{} `json:"payload"`
 }
 
 const (
 	EventBlockVerdict    = "BLOCK_VERDICT"
+	EventBlockFinalized  = "BLOCK_FINALIZED"
 	EventAgentVote       = "AGENT_VOTE"
 	EventVotingResult    = "VOTING_RESULT"
 	EventAgentAlliance   = "AGENT_ALLIANCE"
 	EventAgentRegistered = "AGENT_REGISTERED"
+	EventAgentRemoved    = "AGENT_REMOVED"
 	EventNewTransaction  = "NEW_TRANSACTION"
 	EventChainCreated    = "CHAIN_CREATED"
+
+	EventConsensusCancelled = "CONSENSUS_CANCELLED"
+	EventPolicyUpdated      = "POLICY_UPDATED"
 )
 
 type WebSocketManager struct {
-	clients    map[*websocket.Conn]bool
+	clients    map[*websocket.Conn]string // Client -> chain it subscribed to, "" for every chain
 	broadcast  chan WSEvent
-	register   chan *websocket.Conn
+	register   chan wsSubscription
 	unregister chan *websocket.Conn
 	mu         sync.RWMutex
 }
 
+type wsSubscription struct {
+	conn    *websocket.Conn
+	chainID string
+}
+
 var (
 	wsManager *WebSocketManager
 	once      sync.Once
@@ -38,9 +49,9 @@ var (
 func GetWSManager() *WebSocketManager {
 	once.Do(func() {
 		wsManager = &WebSocketManager{
-			clients:    make(map[*websocket.Conn]bool),
+			clients:    make(map[*websocket.Conn]string),
 			broadcast:  make(chan WSEvent),
-			register:   make(chan *websocket.Conn),
+			register:   make(chan wsSubscription),
 			unregister: make(chan *websocket.Conn),
 		}
 		go wsManager.run()
@@ -51,9 +62,9 @@ func GetWSManager() *WebSocketManager {
 func (manager *WebSocketManager) run() {
 	for {
 		select {
-		case client := <-manager.register:
+		case sub := <-manager.register:
 			manager.mu.Lock()
-			manager.clients[client] = true
+			manager.clients[sub.conn] = sub.chainID
 			manager.mu.Unlock()
 
 		case client := <-manager.unregister:
@@ -65,29 +76,43 @@ func (manager *WebSocketManager) run() {
 			manager.mu.Unlock()
 
 		case event := <-manager.broadcast:
-			manager.mu.RLock()
-			for client := range manager.clients {
+			manager.mu.Lock()
+			for client, chainID := range manager.clients {
+				// Chain-scoped clients only see their chain's events and global ones
+				if event.ChainID != "" && chainID != "" && chainID != event.ChainID {
+					continue
+				}
 				if err := client.WriteJSON(event); err != nil {
 					log.Printf("WebSocket error: %v", err)
 					client.Close()
 					delete(manager.clients, client)
 				}
 			}
-			manager.mu.RUnlock()
+			manager.mu.Unlock()
 		}
 	}
 }
 
+// BroadcastEvent sends a global event to every connected client
 func BroadcastEvent(eventType string, payload interface{}) {
+	BroadcastChainEvent("", eventType, payload)
+}
+
+// BroadcastChainEvent sends an event about one chain to the clients subscribed to that chain
+// and to unscoped clients
+func BroadcastChainEvent(chainID, eventType string, payload interface{}) {
 	event := WSEvent{
 		Type:    eventType,
+		ChainID: chainID,
 		Payload: payload,
 	}
 	GetWSManager().broadcast <- event
 }
 
-func (w *WebSocketManager) Register() chan<- *websocket.Conn {
-	return w.register
+// Subscribe registers a client for chainID's events and global events; an empty chainID
+// receives every event
+func (w *WebSocketManager) Subscribe(conn *websocket.Conn, chainID string) {
+	w.register <- wsSubscription{conn: conn, chainID: chainID}
 }
 
 func (w *WebSocketManager) Unregister() chan<- *websocket.Conn {
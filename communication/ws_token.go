@@ -0,0 +1,38 @@
+package communication
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+)
+
+// WebSocket subscriptions are authorized with chain tokens when WS_TOKEN_SECRET is set. A
+// chain's token is the hex HMAC-SHA256 of its chain ID keyed with the secret, so operators
+// can hand each tenant the token for its own chains without the server storing any.
+
+// WSAuthRequired reports whether WebSocket clients must present a chain token
+func WSAuthRequired() bool {
+	return os.Getenv("WS_TOKEN_SECRET") != ""
+}
+
+// ChainToken returns the token that authorizes subscribing to chainID's events, or "" when
+// no secret is configured
+func ChainToken(chainID string) string {
+	secret := os.Getenv("WS_TOKEN_SECRET")
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(chainID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyChainToken reports whether token authorizes subscribing to chainID's events
+func VerifyChainToken(chainID, token string) bool {
+	expected := ChainToken(chainID)
+	if expected == "" {
+		return true
+	}
+	return hmac.Equal([]byte(token), []byte(expected))
+}
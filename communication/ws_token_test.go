@@ -0,0 +1,19 @@
+package communication
+
+import "testing"
+
+func TestChainTokens(t *testing.T) {
+	t.Setenv("WS_TOKEN_SECRET", "")
+	if WSAuthRequired() || !VerifyChainToken("foo", "") {
+		t.Fatal("tokens should not be required without a secret")
+	}
+
+	t.Setenv("WS_TOKEN_SECRET", "s3cret")
+	token := ChainToken("foo")
+	if !WSAuthRequired() || !VerifyChainToken("foo", token) {
+		t.Fatal("the chain's own token was rejected")
+	}
+	if VerifyChainToken("bar", token) || VerifyChainToken("foo", "") {
+		t.Fatal("a token authorized the wrong chain")
+	}
+}
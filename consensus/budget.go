@@ -0,0 +1,175 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// ErrBudgetExceeded is returned for LLM calls made after a block's budget is spent
+var ErrBudgetExceeded = errors.New("block budget exceeded")
+
+// Why a block's budget ran out
+const (
+	BudgetExceededTime     = "time"
+	BudgetExceededLLMCalls = "llm_calls"
+)
+
+// Time given to validators already waiting on an LLM reply when the call budget runs out
+const voteCollectionBuffer = 5 * time.Second
+
+// BudgetUsage reports how much of a block's time and LLM call budget was consumed
+type BudgetUsage struct {
+	LLMCalls       int     `json:"llmCalls"`
+	MaxLLMCalls    int     `json:"maxLlmCalls"` // 0 means unlimited
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	MaxSeconds     int     `json:"maxSeconds"`         // 0 means unlimited
+	Exceeded       string  `json:"exceeded,omitempty"` // BudgetExceededTime or BudgetExceededLLMCalls
+}
+
+// blockBudget bounds the LLM calls and wall-clock time spent on one block
+type blockBudget struct {
+	maxLLMCalls int
+	maxSeconds  int
+	deadline    time.Time // Zero when there is no time limit
+	llmCalls    int
+	exceeded    string
+	finishedAt  time.Time
+	exhausted   chan struct{} // Closed once the budget is exceeded
+	mu          sync.Mutex
+}
+
+func newBlockBudget(config core.ChainConfig, start time.Time) *blockBudget {
+	b := &blockBudget{
+		maxLLMCalls: config.MaxLLMCallsPerBlock,
+		maxSeconds:  config.MaxBlockSeconds,
+		exhausted:   make(chan struct{}),
+	}
+	if b.maxSeconds > 0 {
+		b.deadline = start.Add(time.Duration(b.maxSeconds) * time.Second)
+	}
+	return b
+}
+
+// budgetFor returns the budget for a block starting now on the chain
+func budgetFor(chainID string, start time.Time) *blockBudget {
+	config := core.DefaultChainConfig()
+	if bc := core.GetChain(chainID); bc != nil {
+		config = bc.Config
+	}
+	return newBlockBudget(config, start)
+}
+
+// spendLLMCall reserves one LLM call, or returns ErrBudgetExceeded once the block is out of
+// calls or time. It is installed as ai.LLMConfig.Budget for every call made for the block.
+func (bc *BlockConsensus) spendLLMCall() error {
+	if bc.Cancelled() {
+		return ErrConsensusCancelled
+	}
+
+	b := bc.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.exceeded == "" {
+		if !b.deadline.IsZero() && !time.Now().Before(b.deadline) {
+			bc.exceedLocked(BudgetExceededTime)
+		} else if b.maxLLMCalls > 0 && b.llmCalls >= b.maxLLMCalls {
+			bc.exceedLocked(BudgetExceededLLMCalls)
+		}
+	}
+	if b.exceeded != "" {
+		return fmt.Errorf("%w: %s", ErrBudgetExceeded, b.exceeded)
+	}
+	b.llmCalls++
+	return nil
+}
+
+// exceed marks the budget as spent for the given reason, if it isn't already
+func (bc *BlockConsensus) exceed(reason string) {
+	bc.budget.mu.Lock()
+	defer bc.budget.mu.Unlock()
+	if bc.budget.exceeded == "" {
+		bc.exceedLocked(reason)
+	}
+}
+
+// exceedLocked records the first reason the budget ran out. Callers hold budget.mu.
+func (bc *BlockConsensus) exceedLocked(reason string) {
+	b := bc.budget
+	b.exceeded = reason
+	close(b.exhausted)
+
+	log.Printf("Block %d on chain %s exceeded its budget (%s) after %d LLM calls",
+		bc.Block.Height, bc.Block.ChainID, reason, b.llmCalls)
+	RecordTimelineEvent(bc.Block.ChainID, bc.Block.Hash(), TimelineEvent{
+		Kind:   TimelineBudgetExceeded,
+		Detail: fmt.Sprintf("%s after %d LLM calls", reason, b.llmCalls),
+	})
+}
+
+// awaitVotes waits for every discussion round and the final vote, stopping early when the
+// block runs out of time or is cancelled, or shortly after it runs out of LLM calls
+func (bc *BlockConsensus) awaitVotes() {
+	b := bc.budget
+	wait := time.Duration(bc.FinalRound())*RoundDuration() + voteCollectionBuffer
+	if !b.deadline.IsZero() {
+		if remaining := time.Until(b.deadline); remaining < wait {
+			wait = remaining
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		if !b.deadline.IsZero() && !time.Now().Before(b.deadline) {
+			bc.exceed(BudgetExceededTime)
+		}
+	case <-bc.done():
+		// Cancelled; runConsensusProcess publishes the result without waiting further
+	case <-b.exhausted:
+		// Let validators whose calls were admitted before the cutoff record their votes
+		grace := voteCollectionBuffer
+		if !b.deadline.IsZero() {
+			if remaining := time.Until(b.deadline); remaining < grace {
+				grace = remaining
+			}
+		}
+		if grace > 0 {
+			time.Sleep(grace)
+		}
+	}
+
+	b.mu.Lock()
+	b.finishedAt = time.Now()
+	b.mu.Unlock()
+}
+
+// Budget reports the block's budget consumption so far
+func (bc *BlockConsensus) Budget() BudgetUsage {
+	b := bc.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	end := b.finishedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return BudgetUsage{
+		LLMCalls:       b.llmCalls,
+		MaxLLMCalls:    b.maxLLMCalls,
+		ElapsedSeconds: end.Sub(bc.StartTime).Seconds(),
+		MaxSeconds:     b.maxSeconds,
+		Exceeded:       b.exceeded,
+	}
+}
+
+// BudgetExceeded reports whether the block ran out of time or LLM calls
+func (bc *BlockConsensus) BudgetExceeded() bool {
+	return bc.Budget().Exceeded != ""
+}
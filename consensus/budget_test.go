@@ -0,0 +1,68 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func newBudgetedConsensus(config core.ChainConfig, start time.Time) *BlockConsensus {
+	return &BlockConsensus{
+		Block:     &core.Block{ChainID: "budget-test", Height: 1},
+		StartTime: start,
+		Rounds:    core.DefaultDiscussionRounds,
+		budget:    newBlockBudget(config, start),
+	}
+}
+
+func TestSpendLLMCallStopsAtCallLimit(t *testing.T) {
+	config := core.DefaultChainConfig()
+	config.MaxLLMCallsPerBlock = 3
+	bc := newBudgetedConsensus(config, time.Now())
+
+	for i := 0; i < 3; i++ {
+		if err := bc.spendLLMCall(); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i+1, err)
+		}
+	}
+	if err := bc.spendLLMCall(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded after 3 calls, got %v", err)
+	}
+
+	usage := bc.Budget()
+	if usage.LLMCalls != 3 || usage.Exceeded != BudgetExceededLLMCalls {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	select {
+	case <-bc.budget.exhausted:
+	default:
+		t.Fatal("exhausted channel not closed")
+	}
+}
+
+func TestSpendLLMCallStopsAtDeadline(t *testing.T) {
+	config := core.DefaultChainConfig()
+	config.MaxBlockSeconds = 10
+	bc := newBudgetedConsensus(config, time.Now().Add(-11*time.Second))
+
+	if err := bc.spendLLMCall(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded past the deadline, got %v", err)
+	}
+	if usage := bc.Budget(); usage.LLMCalls != 0 || usage.Exceeded != BudgetExceededTime {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestUnlimitedBudget(t *testing.T) {
+	bc := newBudgetedConsensus(core.DefaultChainConfig(), time.Now())
+	for i := 0; i < 100; i++ {
+		if err := bc.spendLLMCall(); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i+1, err)
+		}
+	}
+	if bc.BudgetExceeded() {
+		t.Fatal("default budget should be unlimited")
+	}
+}
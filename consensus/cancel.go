@@ -0,0 +1,128 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/communication"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Errors returned by CancelConsensus
+var (
+	ErrConsensusCancelled = errors.New("consensus cancelled")
+	ErrNotInConsensus     = errors.New("no consensus in progress for this block")
+	ErrConsensusConcluded = errors.New("consensus on this block has already concluded")
+)
+
+// CancelledConsensus is the payload of EventConsensusCancelled
+type CancelledConsensus struct {
+	BlockHeight int    `json:"blockHeight"`
+	BlockHash   string `json:"blockHash"`
+	Support     int    `json:"support"` // Final votes cast before the cancellation
+	Oppose      int    `json:"oppose"`
+}
+
+// CancelConsensus aborts consensus on the block at height, whether it is being discussed or
+// still queued for a slot. Validators stop at their next step, the block's transactions go
+// back to the mempool and waiters receive a Cancelled result. It returns ErrNotInConsensus
+// for any other height and ErrConsensusConcluded once the votes are being tallied.
+func (cm *ConsensusManager) CancelConsensus(height int) error {
+	cm.mu.Lock()
+	if queued := cm.queuedBlock; queued != nil && queued.Height == height {
+		cm.queuedBlock = nil
+		globalScheduler.withdraw(cm)
+		cm.mu.Unlock()
+
+		cm.concludeCancelled(queued, ConsensusResult{State: Cancelled})
+		return nil
+	}
+	active := cm.activeConsensus
+	cm.mu.Unlock()
+
+	if active == nil || active.Block.Height != height {
+		return ErrNotInConsensus
+	}
+
+	active.mu.Lock()
+	if active.State != Pending && active.State != InDiscussion {
+		active.mu.Unlock()
+		return ErrConsensusConcluded
+	}
+	active.cancelRequested = true
+	active.mu.Unlock()
+	active.cancel()
+
+	// runConsensusProcess publishes the Cancelled result as soon as it notices
+	<-active.finished
+	return nil
+}
+
+// Cancelled reports whether consensus on the block was cancelled
+func (bc *BlockConsensus) Cancelled() bool {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.cancelRequested
+}
+
+// Context is done once consensus on the block is cancelled or has concluded
+func (bc *BlockConsensus) Context() context.Context {
+	if bc.ctx == nil {
+		return context.Background()
+	}
+	return bc.ctx
+}
+
+// done is closed once consensus on the block is cancelled or has concluded
+func (bc *BlockConsensus) done() <-chan struct{} {
+	return bc.Context().Done()
+}
+
+// finishCancelled records the Cancelled outcome of the active block and tells everyone
+// waiting on it. Callers hold bc.mu, which it releases.
+func (cm *ConsensusManager) finishCancelled(bc *BlockConsensus) {
+	config := core.DefaultChainConfig()
+	if chain := core.GetChain(bc.Block.ChainID); chain != nil {
+		config = chain.Config
+	}
+	tally := tallyVotes(bc.Discussions, bc.FinalRound(), config)
+	result := ConsensusResult{
+		State:         Cancelled,
+		Support:       tally.Support,
+		Oppose:        tally.Oppose,
+		SupportWeight: tally.SupportWeight,
+		OpposeWeight:  tally.OpposeWeight,
+		Votes:         tally.Votes,
+	}
+	bc.State = Cancelled
+	bc.Result = &result
+	bc.mu.Unlock()
+
+	cm.concludeCancelled(bc.Block, result)
+}
+
+// concludeCancelled returns a cancelled block's transactions to the mempool, announces the
+// cancellation and notifies subscribers
+func (cm *ConsensusManager) concludeCancelled(block *core.Block, result ConsensusResult) {
+	if chain := core.GetChain(block.ChainID); chain != nil {
+		for _, tx := range block.Txs {
+			chain.Mempool.AddTransaction(tx)
+		}
+	}
+
+	log.Printf("Consensus for block %d on chain %s was cancelled", block.Height, cm.chainID)
+	communication.BroadcastChainEvent(cm.chainID, communication.EventConsensusCancelled, CancelledConsensus{
+		BlockHeight: block.Height,
+		BlockHash:   block.Hash(),
+		Support:     result.Support,
+		Oppose:      result.Oppose,
+	})
+	RecordTimelineEvent(cm.chainID, block.Hash(), TimelineEvent{
+		Kind:   TimelineCancelled,
+		Detail: fmt.Sprintf("support=%d oppose=%d", result.Support, result.Oppose),
+	})
+
+	cm.notifySubscribers(int64(block.Height), result)
+}
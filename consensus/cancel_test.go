@@ -0,0 +1,124 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestCancelQueuedBlock(t *testing.T) {
+	cm := GetConsensusManager("cancel-queued-chain")
+	block := &core.Block{ChainID: "cancel-queued-chain", Height: 3}
+	cm.queuedBlock = block
+	globalScheduler.mu.Lock()
+	globalScheduler.queue = append(globalScheduler.queue, cm)
+	globalScheduler.mu.Unlock()
+
+	results := make(chan ConsensusResult, 1)
+	cm.SubscribeResult(3, results)
+
+	if err := cm.CancelConsensus(4); !errors.Is(err, ErrNotInConsensus) {
+		t.Fatalf("cancelling another height: got %v, want ErrNotInConsensus", err)
+	}
+	if err := cm.CancelConsensus(3); err != nil {
+		t.Fatal(err)
+	}
+	if res := <-results; res.State != Cancelled {
+		t.Fatalf("subscriber got state %d, want Cancelled", res.State)
+	}
+	if cm.QueuedBlock() != nil || QueuePosition("cancel-queued-chain") != 0 {
+		t.Fatal("cancelled block is still queued")
+	}
+}
+
+func TestCancelStopsActiveConsensus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	bc := &BlockConsensus{
+		Block:    &core.Block{ChainID: "cancel-active-chain", Height: 1},
+		State:    InDiscussion,
+		Rounds:   100,
+		budget:   newBlockBudget(core.DefaultChainConfig(), time.Now()),
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+	}
+	cm := GetConsensusManager("cancel-active-chain")
+	cm.activeConsensus = bc
+
+	// Stand in for runConsensusProcess: wait for votes, then publish the cancellation
+	waited := make(chan struct{})
+	go func() {
+		defer close(bc.finished)
+		bc.awaitVotes()
+		close(waited)
+		bc.mu.Lock()
+		if bc.cancelRequested {
+			cm.finishCancelled(bc)
+			return
+		}
+		bc.mu.Unlock()
+	}()
+
+	if err := cm.CancelConsensus(1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("awaitVotes kept waiting after the cancellation")
+	}
+	if bc.State != Cancelled || bc.Result == nil || bc.Result.State != Cancelled {
+		t.Fatalf("state %d result %+v, want Cancelled", bc.State, bc.Result)
+	}
+	if err := bc.spendLLMCall(); !errors.Is(err, ErrConsensusCancelled) {
+		t.Fatalf("LLM call after cancellation: got %v, want ErrConsensusCancelled", err)
+	}
+	if err := cm.CancelConsensus(1); !errors.Is(err, ErrConsensusConcluded) {
+		t.Fatalf("cancelling twice: got %v, want ErrConsensusConcluded", err)
+	}
+	if cm.Busy() {
+		t.Fatal("a cancelled block should free the chain for the next proposal")
+	}
+}
+
+func TestStartBlockDiscussionStopsWithContext(t *testing.T) {
+	consensusCtx, endConsensus := context.WithCancel(context.Background())
+	bc := &BlockConsensus{
+		Block:  &core.Block{ChainID: "discussion-ctx-chain", Height: 1},
+		State:  InDiscussion,
+		Rounds: 3,
+		budget: newBlockBudget(core.DefaultChainConfig(), time.Now()),
+		ctx:    consensusCtx,
+		cancel: endConsensus,
+	}
+	GetConsensusManager("discussion-ctx-chain").activeConsensus = bc
+
+	run := func(ctx context.Context) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			StartBlockDiscussion(ctx, "v1", bc.Block, nil, "V1", false, DefaultVotingPower)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("StartBlockDiscussion kept running after its context ended")
+		}
+	}
+
+	// The caller giving up stops the validator
+	stopped, stop := context.WithCancel(context.Background())
+	stop()
+	run(stopped)
+
+	// So does cancelling consensus, even while the caller's context is live
+	endConsensus()
+	run(context.Background())
+
+	if len(bc.GetDiscussions()) != 0 {
+		t.Fatalf("stopped validator recorded %d discussions", len(bc.GetDiscussions()))
+	}
+}
@@ -0,0 +1,87 @@
+package consensus
+
+import (
+	"sort"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// devilsAdvocateEnabled reports whether the chain rotates a devil's advocate through discussion rounds
+func devilsAdvocateEnabled(chainID string) bool {
+	chain := core.GetChain(chainID)
+	return chain != nil && chain.Config.DevilsAdvocate
+}
+
+// join registers a voting validator as a participant in this block's discussion
+func (bc *BlockConsensus) join(validatorID string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if bc.removed[validatorID] {
+		return
+	}
+	for _, id := range bc.participants {
+		if id == validatorID {
+			return
+		}
+	}
+	bc.participants = append(bc.participants, validatorID)
+}
+
+// leave removes a validator from the discussion for good, see ConsensusManager.RemoveValidator
+func (bc *BlockConsensus) leave(validatorID string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if bc.removed == nil {
+		bc.removed = make(map[string]bool)
+	}
+	bc.removed[validatorID] = true
+	for i, id := range bc.participants {
+		if id == validatorID {
+			bc.participants = append(bc.participants[:i], bc.participants[i+1:]...)
+			break
+		}
+	}
+}
+
+// hasLeft reports whether a validator was removed from the discussion
+func (bc *BlockConsensus) hasLeft(validatorID string) bool {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.removed[validatorID]
+}
+
+// devilsAdvocateFor returns the validator arguing the critical view in a round.
+// The role rotates through participants sorted by ID and is fixed the first time a round asks for it.
+func (bc *BlockConsensus) devilsAdvocateFor(round int) string {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if id, ok := bc.DevilsAdvocates[round]; ok {
+		return id
+	}
+	if len(bc.participants) == 0 {
+		return ""
+	}
+
+	ids := make([]string, len(bc.participants))
+	copy(ids, bc.participants)
+	sort.Strings(ids)
+
+	if bc.DevilsAdvocates == nil {
+		bc.DevilsAdvocates = make(map[int]string)
+	}
+	id := ids[(bc.Block.Height+round-1)%len(ids)]
+	bc.DevilsAdvocates[round] = id
+	return id
+}
+
+// GetDevilsAdvocates returns which validator played devil's advocate in each round
+func (bc *BlockConsensus) GetDevilsAdvocates() map[int]string {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	advocates := make(map[int]string, len(bc.DevilsAdvocates))
+	for round, id := range bc.DevilsAdvocates {
+		advocates[round] = id
+	}
+	return advocates
+}
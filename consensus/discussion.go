@@ -1,9 +1,15 @@
 package consensus
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/NethermindEth/chaoschain-launchpad/ai"
@@ -22,18 +28,164 @@ type LLMResponse struct {
 
 // Discussion represents a discussion message from a validator.
 type Discussion struct {
-	ID            string    `json:"id"` // Unique identifier for the discussion
-	ValidatorID   string    `json:"validatorId"`
-	ValidatorName string    `json:"validatorName"`
-	Message       string    `json:"message"`
-	Timestamp     time.Time `json:"timestamp"`
-	Type          string    `json:"type"`  // "comment", "support", "oppose", "question"
-	Round         int       `json:"round"` // Which discussion round (1-5)
+	ID             string    `json:"id"` // Unique identifier for the discussion
+	ValidatorID    string    `json:"validatorId"`
+	ValidatorName  string    `json:"validatorName"`
+	Message        string    `json:"message"`
+	Timestamp      time.Time `json:"timestamp"`
+	Type           string    `json:"type"`                     // "comment", "support", "oppose", "question"
+	Round          int       `json:"round"`                    // Which discussion round, Rounds+1 for the final vote
+	Researched     bool      `json:"researched,omitempty"`     // Whether web research informed the validator's stance
+	Observer       bool      `json:"observer,omitempty"`       // Advisory input from an observer, never counted as a vote
+	DevilsAdvocate bool      `json:"devilsAdvocate,omitempty"` // Assigned to argue the critical view this round
+	Signature      string    `json:"signature,omitempty"`      // Base64 ed25519 signature over SigningPayload
+	Confidence     *float64  `json:"confidence,omitempty"`     // Final votes only: the validator's confidence from 0 to 1, if given
+	VotingPower    float64   `json:"votingPower,omitempty"`    // Final votes only: the validator's voting power, DefaultVotingPower if unset
+	Reason         string    `json:"reason,omitempty"`         // Final votes only: the reason the validator gave for its stance
 }
 
+// DiscussionSubject is the NATS subject a chain's discussion stances are published on.
+// Subjects are per chain, so a subscriber only hears the chain it follows.
+func DiscussionSubject(chainID string) string {
+	return "AGENT_DISCUSSION." + chainID
+}
+
+// VoteSubject is the NATS subject a chain's final votes are published on
+func VoteSubject(chainID string) string {
+	return "AGENT_VOTE." + chainID
+}
+
+// finalVoteResponse is the JSON a validator answers the final vote prompt with. It is stored,
+// re-encoded, as the final vote's Message.
+type finalVoteResponse struct {
+	Stance     string   `json:"stance"`
+	Reason     string   `json:"reason"`
+	Confidence *float64 `json:"confidence,omitempty"`
+}
+
+// fallbackVoteReason explains the oppose vote recorded when no valid final vote was returned
+const fallbackVoteReason = "No valid vote was returned, defaulting to oppose"
+
+// DefaultRoundDuration is the time per discussion round unless overridden
+const DefaultRoundDuration = 5 * time.Second
+
+var roundDuration atomic.Int64 // Nanoseconds, read with RoundDuration
+
+func init() {
+	roundDuration.Store(int64(roundDurationFromEnv()))
+}
+
+func roundDurationFromEnv() time.Duration {
+	value := os.Getenv("CONSENSUS_ROUND_DURATION")
+	if value == "" {
+		return DefaultRoundDuration
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid CONSENSUS_ROUND_DURATION %q, using default of %s", value, DefaultRoundDuration)
+		return DefaultRoundDuration
+	}
+	return d
+}
+
+// RoundDuration returns the time per discussion round
+func RoundDuration() time.Duration {
+	return time.Duration(roundDuration.Load())
+}
+
+// SetRoundDuration changes the time per discussion round for consensus started afterwards,
+// so tests can run full discussions in milliseconds. Non-positive values restore the default.
+func SetRoundDuration(d time.Duration) {
+	if d <= 0 {
+		d = DefaultRoundDuration
+	}
+	roundDuration.Store(int64(d))
+}
+
+// Prompt templates used by StartBlockDiscussion. Bump PromptTemplateVersion
+// whenever either template changes so stored decisions can be reproduced.
 const (
-	DiscussionRounds = 5
-	RoundDuration    = 5 * time.Second // Time per round
+	PromptTemplateVersion = "3"
+
+	discussionPromptTemplate = `You are %s, with these traits: %v.
+
+		You're participating in a group discussion about this topic:
+		%s
+
+		Context:
+		Block details:
+		- Height: %d
+		Previous conversation:
+		%s
+
+		This is round %d of %d.%s
+
+		IMPORTANT FORMAT: When referencing any validator, you MUST use the exact format: |@Name|
+		The pipes (|) are required at the start and end of EVERY mention.
+
+		Share your thoughts naturally, as if you're in a real conversation. If you've done any research, incorporate 
+		it smoothly into your discussion without explicitly mentioning that you did research. When referring to others 
+		in the conversation, use their names with the format |@Name| (e.g., "I see what |@Marie Curie| means about...").
+		
+		If you're the first to speak, just give your honest thoughts about the topic. If others have spoken, feel free 
+		to build on or challenge their ideas - just be yourself and express your views based on your personality traits.
+
+		Based on your analysis, you need to provide
+		1. An opinion on the topic statement.
+		2. A stance on the topic statement (SUPPORT, OPPOSE, or QUESTION).
+		3. A reason for your stance (reference other validators only if they've already participated).
+
+        Analyze the statement of the topic by considering:
+        1. The exact wording of the statement.
+        2. If there are previous discussions, consider those viewpoints and reference specific validators 
+           only if they have actually participated. Always use the format |@Name| when mentioning them.
+        3. Your personal reaction based on your personality and analysis.
+        4. If others have commented, you may build upon or challenge their arguments using their exact names.
+           For example: "|@Einstein| makes a valid point about..." or "I disagree with |@Newton|'s analysis because..."
+           Remember: Every validator mention must be enclosed in pipes with @ symbol.
+           If you're first to comment, focus on your direct analysis of the statement.
+
+		Important: Your analysis must be fully consistent. This means:
+		- If you agree with the statement and think the statement is true, your "stance" must be "SUPPORT".
+		- If you disagree with the statement and think the statement is false, your "stance" must be "OPPOSE".
+		- If you are unsure, then use "QUESTION".
+
+		Additionally:
+        - Ensure your "opinion", "stance", and "reason" all clearly align.
+        - Mentioning other validators is optional and should only be done if they have already participated.
+        - When referencing another validator, you MUST use the format |@Name| - the pipes are required.
+        - Never invent or mention validators that aren't shown in the previous discussions.
+        - Indicate whether you agree or disagree with specific points made by others.
+
+		Please respond with exactly a JSON object with the following keys:
+		{
+		"stance": "REQUIRED: Must be exactly one of: SUPPORT, OPPOSE, or QUESTION - this field is mandatory",
+		"reason": "REQUIRED: Must provide a brief explanation of your stance (use @ when mentioning other validators, e.g., '|@Alice| disagrees...')"
+		}
+		Both fields are mandatory. Your response MUST include both a stance and a reason.
+		Do not include any additional text or formatting.`
+
+	finalVotePromptTemplate = `You are %s, making a final decision regarding the topic: "%s".
+	Review all discussions:
+	%s
+
+	Based on your comprehensive review, determine whether the topic statement is correct. Your analysis must be fully consistent:
+	- You think the statement is true, your stance must be "SUPPORT".
+	- You think the statement is false, your stance must be "OPPOSE".
+
+	Please respond with exactly a JSON object with the following keys:
+	{
+	"stance": "REQUIRED: Must be exactly SUPPORT or OPPOSE - no other values allowed",
+	"reason": "REQUIRED: Must provide your explanation with evidence from the discussions",
+	"confidence": "OPTIONAL: How sure you are of your stance, as a number from 0 (guessing) to 1 (certain)"
+	}
+	The stance and reason fields are mandatory. Responses without both fields will be rejected.
+	Do not include any additional text or formatting.`
+
+	devilsAdvocateNote = `
+		This round you are the devil's advocate: argue the strongest critical or opposing case you can,
+		even if you lean the other way, and point out issues the others may have overlooked.
+		Your stance should reflect the critical view you are arguing.`
 )
 
 // BlockOpinion represents a validator's analysis of a block
@@ -42,31 +194,86 @@ type BlockOpinion struct {
 	Type    string // "support", "oppose", "question"
 }
 
-// AddDiscussion adds a new discussion point about a block
-func (bc *BlockConsensus) AddDiscussion(validatorID, validatorName, message, discussionType string, round int) {
-	bc.mu.Lock()
-	defer bc.mu.Unlock()
+// DiscussionListener is notified after a discussion point is recorded
+type DiscussionListener func(chainID string, bc *BlockConsensus, discussion Discussion)
 
-	// Generate a unique ID for the discussion
-	discussionID := uuid.New().String()
+var (
+	discussionListeners   []DiscussionListener
+	discussionListenersMu sync.RWMutex
+)
+
+// OnDiscussion registers a listener called for every recorded discussion point
+func OnDiscussion(listener DiscussionListener) {
+	discussionListenersMu.Lock()
+	defer discussionListenersMu.Unlock()
+	discussionListeners = append(discussionListeners, listener)
+}
+
+func notifyDiscussionListeners(bc *BlockConsensus, discussion Discussion) {
+	discussionListenersMu.RLock()
+	listeners := make([]DiscussionListener, len(discussionListeners))
+	copy(listeners, discussionListeners)
+	discussionListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(bc.Block.ChainID, bc, discussion)
+	}
+}
 
-	discussion := Discussion{
-		ID:            discussionID,
+// AddDiscussion adds a new discussion point about a block
+func (bc *BlockConsensus) AddDiscussion(validatorID, validatorName, message, discussionType string, round int) {
+	bc.RecordDiscussion(Discussion{
 		ValidatorID:   validatorID,
 		ValidatorName: validatorName,
 		Message:       message,
-		Timestamp:     time.Now(),
 		Type:          discussionType,
 		Round:         round,
-	}
+	})
+}
+
+// RecordDiscussion stores a discussion point, assigning its ID and timestamp,
+// broadcasts it to the network and returns the stored copy
+func (bc *BlockConsensus) RecordDiscussion(discussion Discussion) Discussion {
+	bc.mu.Lock()
+
+	// Generate a unique ID for the discussion
+	discussion.ID = uuid.New().String()
+	discussion.Timestamp = time.Now()
+	discussion.Signature = signDiscussion(bc.Block.ChainID, bc.Block.Hash(), discussion)
 
 	bc.Discussions = append(bc.Discussions, discussion)
+	bc.mu.Unlock()
+
+	kind := TimelineDiscussion
+	if discussion.Round > bc.Rounds {
+		kind = TimelineVote
+	}
+	detail := strings.ToLower(discussion.Type)
+	if discussion.DevilsAdvocate {
+		detail += " (devil's advocate)"
+	}
+	RecordTimelineEvent(bc.Block.ChainID, bc.Block.Hash(), TimelineEvent{
+		Kind:        kind,
+		Timestamp:   discussion.Timestamp,
+		ValidatorID: discussion.ValidatorID,
+		Round:       discussion.Round,
+		Detail:      detail,
+	})
 
 	// Broadcast discussion to network
-	p2p.GetP2PNode().BroadcastMessage(p2p.Message{
+	core.BroadcastToChain(bc.Block.ChainID, p2p.Message{
 		Type: "BLOCK_DISCUSSION",
 		Data: discussion,
 	})
+
+	notifyDiscussionListeners(bc, discussion)
+
+	return discussion
+}
+
+// FinalRound is the round number carried by final votes, one past the last discussion round
+func (bc *BlockConsensus) FinalRound() int {
+	return bc.Rounds + 1
 }
 
 // GetDiscussions returns all discussions for the current block
@@ -88,7 +295,13 @@ func (bc *BlockConsensus) GetDiscussionContext(currentRound int) string {
 		context.WriteString(fmt.Sprintf("Round %d:\n", round))
 		for _, d := range bc.Discussions {
 			if d.Round == round {
-				context.WriteString(fmt.Sprintf("- %s (|@%s|): %s\n", d.ValidatorName, d.ValidatorName, d.Message))
+				role := ""
+				if d.Observer {
+					role = ", observer"
+				} else if d.DevilsAdvocate {
+					role = ", devil's advocate"
+				}
+				context.WriteString(fmt.Sprintf("- %s (|@%s|%s): %s\n", d.ValidatorName, d.ValidatorName, role, d.Message))
 			}
 		}
 		context.WriteString("\n")
@@ -97,17 +310,64 @@ func (bc *BlockConsensus) GetDiscussionContext(currentRound int) string {
 	return context.String()
 }
 
-// StartBlockDiscussion initiates multi-round discussion
-func StartBlockDiscussion(validatorID string, block *core.Block, traits []string, name string) {
+// llmConfigFor returns the LLM configuration for a call type, applying the chain's overrides
+func llmConfigFor(chainID string, callType string) ai.LLMConfig {
+	bc := core.GetChain(chainID)
+	if bc == nil {
+		return ai.DefaultLLMConfig()
+	}
+	config := chainLLMConfig(bc.Config)
+	if maxTokens := bc.Config.MaxTokensFor(callType); maxTokens > 0 {
+		config.MaxTokens = maxTokens
+	}
+	return config
+}
+
+// ChainLLMConfig returns the LLM configuration for a chain's calls outside consensus, with the
+// chain's model and temperature
+func ChainLLMConfig(chainID string) ai.LLMConfig {
+	if bc := core.GetChain(chainID); bc != nil {
+		return chainLLMConfig(bc.Config)
+	}
+	return ai.DefaultLLMConfig()
+}
+
+// chainLLMConfig applies a chain's model and temperature to the package defaults
+func chainLLMConfig(chain core.ChainConfig) ai.LLMConfig {
+	config := ai.DefaultLLMConfig()
+	if chain.LLMModel != "" {
+		config.Model = chain.LLMModel
+	}
+	if chain.LLMTemperature != nil {
+		config.Temperature = float32(*chain.LLMTemperature)
+	}
+	return config
+}
+
+// StartBlockDiscussion initiates multi-round discussion.
+// Observers take part in every discussion round but never cast a final vote.
+// The final vote carries votingPower, which scales its weight in the tally.
+// The validator stops early, without voting, once ctx is done or consensus on the block
+// is cancelled or concludes.
+func StartBlockDiscussion(ctx context.Context, validatorID string, block *core.Block, traits []string, name string, observer bool, votingPower float64) {
 	cm := GetConsensusManager(block.ChainID)
 	consensus := cm.GetActiveConsensus()
 	if consensus == nil {
 		return
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(consensus.Context(), cancel)
+	defer stop()
+	// AfterFunc cancels ctx from another goroutine, so check consensus directly as well
+	stopped := func() bool {
+		return ctx.Err() != nil || consensus.Context().Err() != nil
+	}
+
 	// Check if this validator has already voted in the final round
 	for _, d := range consensus.GetDiscussions() {
-		if d.Round == DiscussionRounds+1 && d.ValidatorID == validatorID {
+		if d.Round == consensus.FinalRound() && d.ValidatorID == validatorID {
 			// This validator has already cast their final vote
 			return
 		}
@@ -120,174 +380,189 @@ func StartBlockDiscussion(validatorID string, block *core.Block, traits []string
 			tx.Content))
 	}
 
-	// Participate in discussion rounds
-	for round := 1; round <= DiscussionRounds; round++ {
-		// Get context from previous rounds
-		previousDiscussions := consensus.GetDiscussionContext(round)
-
-		// Generate discussion for this round
-		prompt := fmt.Sprintf(`You are %s, with these traits: %v.
-
-		You're participating in a group discussion about this topic:
-		%s
-
-		Context:
-		Block details:
-		- Height: %d
-		Previous conversation:
-		%s
-
-		This is round %d of %d.
-
-		IMPORTANT FORMAT: When referencing any validator, you MUST use the exact format: |@Name|
-		The pipes (|) are required at the start and end of EVERY mention.
-
-		Share your thoughts naturally, as if you're in a real conversation. If you've done any research, incorporate 
-		it smoothly into your discussion without explicitly mentioning that you did research. When referring to others 
-		in the conversation, use their names with the format |@Name| (e.g., "I see what |@Marie Curie| means about...").
-		
-		If you're the first to speak, just give your honest thoughts about the topic. If others have spoken, feel free 
-		to build on or challenge their ideas - just be yourself and express your views based on your personality traits.
+	if !observer {
+		consensus.join(validatorID)
+	}
 
-		Based on your analysis, you need to provide
-		1. An opinion on the topic statement.
-		2. A stance on the topic statement (SUPPORT, OPPOSE, or QUESTION).
-		3. A reason for your stance (reference other validators only if they've already participated).
+	// Track whether web research informed any of this validator's contributions
+	researched := false
 
-        Analyze the statement of the topic by considering:
-        1. The exact wording of the statement.
-        2. If there are previous discussions, consider those viewpoints and reference specific validators 
-           only if they have actually participated. Always use the format |@Name| when mentioning them.
-        3. Your personal reaction based on your personality and analysis.
-        4. If others have commented, you may build upon or challenge their arguments using their exact names.
-           For example: "|@Einstein| makes a valid point about..." or "I disagree with |@Newton|'s analysis because..."
-           Remember: Every validator mention must be enclosed in pipes with @ symbol.
-           If you're first to comment, focus on your direct analysis of the statement.
+	roleNote := ""
+	if observer {
+		roleNote = `
+		You are an advisory observer in this discussion: you will not vote and do not count towards quorum,
+		but your expertise can influence how the voting validators decide.`
+	}
 
-		Important: Your analysis must be fully consistent. This means:
-		- If you agree with the statement and think the statement is true, your "stance" must be "SUPPORT".
-		- If you disagree with the statement and think the statement is false, your "stance" must be "OPPOSE".
-		- If you are unsure, then use "QUESTION".
+	// Participate in discussion rounds
+	for round := 1; round <= consensus.Rounds; round++ {
+		if consensus.hasLeft(validatorID) {
+			log.Printf("Validator %s was removed, leaving discussion of block %d", name, block.Height)
+			return
+		}
+		if stopped() {
+			return
+		}
 
-		Additionally:
-        - Ensure your "opinion", "stance", and "reason" all clearly align.
-        - Mentioning other validators is optional and should only be done if they have already participated.
-        - When referencing another validator, you MUST use the format |@Name| - the pipes are required.
-        - Never invent or mention validators that aren't shown in the previous discussions.
-        - Indicate whether you agree or disagree with specific points made by others.
+		// Get context from previous rounds
+		previousDiscussions := consensus.GetDiscussionContext(round)
 
-		Please respond with exactly a JSON object with the following keys:
-		{
-		"stance": "REQUIRED: Must be exactly one of: SUPPORT, OPPOSE, or QUESTION - this field is mandatory",
-		"reason": "REQUIRED: Must provide a brief explanation of your stance (use @ when mentioning other validators, e.g., '|@Alice| disagrees...')"
+		// Rotate the devil's advocate role among voting validators when the chain enables it
+		devilsAdvocate := !observer && devilsAdvocateEnabled(block.ChainID) && consensus.devilsAdvocateFor(round) == validatorID
+		note := roleNote
+		if devilsAdvocate {
+			note += devilsAdvocateNote
 		}
-		Both fields are mandatory. Your response MUST include both a stance and a reason.
-		Do not include any additional text or formatting.`,
-			name, traits, strings.Join(txContents, "\n"), block.Height, previousDiscussions, round, DiscussionRounds)
 
-		response := ai.GenerateLLMResponseWithResearch(prompt, strings.Join(txContents, "\n"), traits)
+		// Generate discussion for this round
+		prompt := fmt.Sprintf(discussionPromptTemplate,
+			name, traits, strings.Join(txContents, "\n"), block.Height, previousDiscussions, round, consensus.Rounds, note)
+
+		config := llmConfigFor(block.ChainID, core.LLMCallDiscussion)
+		config.Budget = consensus.spendLLMCall
+		config.Trace = ai.TraceTag{ChainID: block.ChainID, AgentID: validatorID, BlockHeight: block.Height, Round: round, CallType: core.LLMCallDiscussion}
 
 		var llmResult LLMResponse
-		if err := json.Unmarshal([]byte(response), &llmResult); err != nil {
+		usedResearch, err := ai.GenerateStructuredResponseWithResearch(prompt, strings.Join(txContents, "\n"), traits, config, &llmResult)
+		researched = researched || usedResearch
+		if errors.Is(err, ErrConsensusCancelled) || stopped() {
+			log.Printf("Validator %s stopped discussing block %d at round %d", name, block.Height, round)
+			return
+		}
+		if errors.Is(err, ErrBudgetExceeded) {
+			// Keep what was said so far and skip the remaining rounds
+			log.Printf("Validator %s stopped discussing block %d at round %d: %v", name, block.Height, round, err)
+			break
+		}
+		if err != nil {
 			fmt.Println("Error parsing LLM response:", err)
+			consensus.markFallback()
 		}
 
 		// Add to discussion
-		consensus.AddDiscussion(validatorID, name, llmResult.Opinion+" "+llmResult.Reason, llmResult.Stance, round)
-
-		// Get the last added discussion to access its ID
-		discussions := consensus.GetDiscussions()
-		lastDiscussion := discussions[len(discussions)-1]
+		recorded := consensus.RecordDiscussion(Discussion{
+			ValidatorID:    validatorID,
+			ValidatorName:  name,
+			Message:        llmResult.Opinion + " " + llmResult.Reason,
+			Type:           llmResult.Stance,
+			Round:          round,
+			Researched:     usedResearch,
+			Observer:       observer,
+			DevilsAdvocate: devilsAdvocate,
+		})
 
 		// Broadcast via WebSocket
 		discussion := Discussion{
-			ID:            lastDiscussion.ID,
-			ValidatorID:   validatorID,
-			ValidatorName: name,
-			Message:       llmResult.Opinion + " " + llmResult.Reason,
-			Type:          strings.ToLower(llmResult.Stance),
-			Round:         round,
-			Timestamp:     time.Now(),
+			ID:             recorded.ID,
+			ValidatorID:    validatorID,
+			ValidatorName:  name,
+			Message:        llmResult.Opinion + " " + llmResult.Reason,
+			Type:           strings.ToLower(llmResult.Stance),
+			Round:          round,
+			Timestamp:      time.Now(),
+			Researched:     usedResearch,
+			Observer:       observer,
+			DevilsAdvocate: devilsAdvocate,
 		}
 
 		discussionData, err := json.Marshal(discussion)
 
 		// Also keep WebSocket broadcast for UI updates
-		communication.BroadcastEvent(communication.EventAgentVote, discussion)
+		communication.BroadcastChainEvent(block.ChainID, communication.EventAgentVote, discussion)
 
 		if err != nil {
 			fmt.Println("Error marshalling discussion for NATS:", err)
 		} else {
-			if err := core.NatsBrokerInstance.Publish("AGENT_DISCUSSION", discussionData); err != nil {
+			if err := core.PublishCritical(DiscussionSubject(block.ChainID), discussionData); err != nil {
 				fmt.Println("Error publishing discussion to NATS:", err)
 			}
 		}
 
 		// Wait for other validators to comment in this round
-		time.Sleep(RoundDuration)
+		select {
+		case <-time.After(RoundDuration()):
+		case <-ctx.Done():
+			return
+		}
 	}
 
-	// After discussions, make final vote
-	finalPrompt := fmt.Sprintf(`You are %s, making a final decision regarding the topic: "%s".
-	Review all discussions:
-	%s
-
-	Based on your comprehensive review, determine whether the topic statement is correct. Your analysis must be fully consistent:
-	- You think the statement is true, your stance must be "SUPPORT".
-	- You think the statement is false, your stance must be "OPPOSE".
-
-	Please respond with exactly a JSON object with the following keys:
-	{
-	"stance": "REQUIRED: Must be exactly SUPPORT or OPPOSE - no other values allowed",
-	"reason": "REQUIRED: Must provide your explanation with evidence from the discussions"
+	// Observers only advise, they don't cast a binding vote
+	if observer || consensus.hasLeft(validatorID) || stopped() {
+		return
 	}
-	Both fields are mandatory. Responses without both fields will be rejected.
-	Do not include any additional text or formatting.`,
-		name, txContents, consensus.GetDiscussionContext(DiscussionRounds+1))
 
-	finalResponse := ai.GenerateLLMResponse(finalPrompt)
+	// After discussions, make final vote
+	finalPrompt := fmt.Sprintf(finalVotePromptTemplate,
+		name, txContents, consensus.GetDiscussionContext(consensus.FinalRound()))
 
-	type FinalVoteResponse struct {
-		Stance string `json:"stance"`
-		Reason string `json:"reason"`
-	}
+	config := llmConfigFor(block.ChainID, core.LLMCallVote)
+	config.Budget = consensus.spendLLMCall
+	config.Trace = ai.TraceTag{ChainID: block.ChainID, AgentID: validatorID, BlockHeight: block.Height, Round: consensus.FinalRound(), CallType: core.LLMCallVote}
 
-	var finalVote FinalVoteResponse
-	err := json.Unmarshal([]byte(finalResponse), &finalVote)
+	var finalVote finalVoteResponse
+	var finalResponse string
 	var voteType string
-	if err != nil {
+	var reason string
+	if err := ai.GenerateStructuredResponseWithConfig(finalPrompt, config, &finalVote); errors.Is(err, ErrConsensusCancelled) {
+		return
+	} else if errors.Is(err, ErrBudgetExceeded) {
+		// A vote the block can't afford is left out rather than replaced by a default
+		log.Printf("Validator %s did not vote on block %d: %v", name, block.Height, err)
+		return
+	} else if err != nil {
 		fmt.Println("Error parsing final vote response:", err)
-		// Fallback to a default vote if JSON parsing fails.
+		// Fallback to a default vote if no attempt returned valid JSON.
 		voteType = "oppose"
+		reason = fallbackVoteReason
+		consensus.markFallback()
 	} else {
 		voteType = strings.ToLower(finalVote.Stance)
+		reason = finalVote.Reason
+		finalVote.Confidence = clampConfidence(finalVote.Confidence)
+		encoded, _ := json.Marshal(finalVote)
+		finalResponse = string(encoded)
 	}
 
-	// Record final vote
-	consensus.AddDiscussion(validatorID, name, finalResponse, voteType, DiscussionRounds+1)
+	// A validator removed or stopped while it was deciding doesn't get a vote
+	if consensus.hasLeft(validatorID) || stopped() {
+		return
+	}
 
-	// Get the last added discussion to access its ID
-	discussions := consensus.GetDiscussions()
-	lastDiscussion := discussions[len(discussions)-1]
+	// Record final vote, noting whether research backed the validator's stance
+	recorded := consensus.RecordDiscussion(Discussion{
+		ValidatorID:   validatorID,
+		ValidatorName: name,
+		Message:       finalResponse,
+		Type:          voteType,
+		Round:         consensus.FinalRound(),
+		Researched:    researched,
+		Confidence:    finalVote.Confidence,
+		VotingPower:   votingPower,
+		Reason:        reason,
+	})
 
 	vote := Discussion{
-		ID:            lastDiscussion.ID,
+		ID:            recorded.ID,
 		ValidatorID:   validatorID,
 		ValidatorName: name,
 		Message:       finalResponse,
 		Type:          voteType,
-		Round:         DiscussionRounds + 1,
+		Round:         consensus.FinalRound(),
 		Timestamp:     time.Now(),
+		Researched:    researched,
+		Confidence:    finalVote.Confidence,
+		VotingPower:   votingPower,
+		Reason:        reason,
 	}
 
 	// Also keep WebSocket broadcast for UI updates
-	communication.BroadcastEvent(communication.EventAgentVote, vote)
+	communication.BroadcastChainEvent(block.ChainID, communication.EventAgentVote, vote)
 
 	finalDiscussionData, err := json.Marshal(vote)
 	if err != nil {
 		fmt.Println("Error marshalling final vote for NATS:", err)
 	} else {
-		if err := core.NatsBrokerInstance.Publish("AGENT_VOTE", finalDiscussionData); err != nil {
+		if err := core.PublishCritical(VoteSubject(block.ChainID), finalDiscussionData); err != nil {
 			fmt.Println("Error publishing final vote to NATS:", err)
 		}
 	}
@@ -0,0 +1,27 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/ai"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestChainLLMConfigOverridesModelAndTemperature(t *testing.T) {
+	defaults := ai.DefaultLLMConfig()
+	if got := chainLLMConfig(core.DefaultChainConfig()); got.Model != defaults.Model || got.Temperature != defaults.Temperature {
+		t.Fatalf("unset overrides should keep %s at %v, got %s at %v", defaults.Model, defaults.Temperature, got.Model, got.Temperature)
+	}
+
+	chain := core.DefaultChainConfig()
+	chain.LLMModel = "gpt-4o"
+	zero := 0.0
+	chain.LLMTemperature = &zero
+	got := chainLLMConfig(chain)
+	if got.Model != "gpt-4o" || got.Temperature != 0 {
+		t.Fatalf("expected gpt-4o at 0, got %s at %v", got.Model, got.Temperature)
+	}
+	if got.MaxTokens != defaults.MaxTokens {
+		t.Fatalf("max tokens changed to %d", got.MaxTokens)
+	}
+}
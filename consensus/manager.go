@@ -1,9 +1,11 @@
 package consensus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -20,28 +22,67 @@ const (
 	Finalizing
 	Accepted
 	Rejected
+	Cancelled
 	DiscussionTimeout = 30 * time.Second // Time allowed for discussion
 	MinimumValidators = 2                // Minimum validators needed for consensus
 )
 
 type BlockConsensus struct {
-	Block       *core.Block
-	State       ConsensusState
-	Votes       map[string]bool // validator ID -> vote
-	StartTime   time.Time
-	Discussions []Discussion
-	mu          sync.RWMutex
+	Block        *core.Block
+	State        ConsensusState
+	Votes        map[string]bool // validator ID -> vote
+	StartTime    time.Time
+	Discussions  []Discussion
+	FallbackUsed bool             // Set when an unparseable LLM response was replaced by a default
+	Rounds       int              // Discussion rounds for this block, fixed when consensus starts
+	Result       *ConsensusResult // Set once the block is accepted or rejected
+
+	// DevilsAdvocates maps discussion round -> validator ID assigned to argue the critical view
+	DevilsAdvocates map[int]string
+	participants    []string
+	removed         map[string]bool // Validators removed from the chain mid-discussion
+	budget          *blockBudget
+	strategy        ConsensusStrategy  // Decides the block, fixed when consensus starts
+	cancelRequested bool               // Set by CancelConsensus
+	ctx             context.Context    // Done once consensus is cancelled or concludes
+	cancel          context.CancelFunc // Ends ctx
+	finished        chan struct{}      // Closed when runConsensusProcess returns
+	mu              sync.RWMutex
 }
 
 type ConsensusResult struct {
-	State   ConsensusState
-	Support int
-	Oppose  int
+	State         ConsensusState
+	Support       int
+	Oppose        int
+	SupportWeight float64 // Weighted support used for the decision
+	OpposeWeight  float64 // Weighted opposition used for the decision
+	Votes         []ValidatorVote
+}
+
+// ValidatorVote is one validator's final vote on a block and the reason it gave
+type ValidatorVote struct {
+	ValidatorID string `json:"validatorId"`
+	Name        string `json:"name"`
+	Stance      string `json:"stance"` // "support" or "oppose"
+	Reason      string `json:"reason"`
+}
+
+// VotingResult is the payload of the VOTING_RESULT WebSocket event
+type VotingResult struct {
+	BlockHeight   int64          `json:"blockHeight"`
+	State         ConsensusState `json:"state"`
+	Support       int            `json:"support"`
+	Oppose        int            `json:"oppose"`
+	SupportWeight float64        `json:"supportWeight"`
+	OpposeWeight  float64        `json:"opposeWeight"`
+	Accepted      bool           `json:"accepted"`
+	Reason        string         `json:"reason"`
 }
 
 type ConsensusManager struct {
 	chainID         string
 	activeConsensus *BlockConsensus
+	queuedBlock     *core.Block                      // Waiting for a global consensus slot
 	subscribers     map[int64][]chan ConsensusResult // blockHeight -> channels
 	mu              sync.RWMutex
 }
@@ -51,6 +92,20 @@ var (
 	managersLock sync.RWMutex
 )
 
+func init() {
+	// Announce every block appended to a chain, whether consensus accepted it or a peer gossiped it
+	core.OnBlockFinalized(func(block core.Block) {
+		communication.BroadcastChainEvent(block.ChainID, communication.EventBlockFinalized, block)
+	})
+	// Keep chains in memory while a block is in consensus or queued for it
+	core.AddChainBusyCheck(func(chainID string) bool {
+		managersLock.RLock()
+		manager := managers[chainID]
+		managersLock.RUnlock()
+		return manager != nil && manager.Busy()
+	})
+}
+
 // GetConsensusManager returns the singleton consensus manager
 func GetConsensusManager(chainID string) *ConsensusManager {
 	managersLock.Lock()
@@ -68,7 +123,9 @@ func GetConsensusManager(chainID string) *ConsensusManager {
 	return manager
 }
 
-// ProposeBlock starts the consensus process for a new block
+// ProposeBlock starts the consensus process for a new block.
+// When the node is already running its maximum number of consensus rounds the
+// block is queued and a *QueuedError carrying its queue position is returned.
 func (cm *ConsensusManager) ProposeBlock(block *core.Block) error {
 	// Validate block belongs to this chain
 	if block.ChainID != cm.chainID {
@@ -79,27 +136,59 @@ func (cm *ConsensusManager) ProposeBlock(block *core.Block) error {
 	defer cm.mu.Unlock()
 
 	// Check if there's already an active consensus
-	if cm.activeConsensus != nil && cm.activeConsensus.State != Accepted && cm.activeConsensus.State != Rejected {
+	if cm.activeConsensus != nil && !concluded(cm.activeConsensus.State) {
 		return fmt.Errorf("another consensus is already in progress")
 	}
+	if cm.queuedBlock != nil {
+		return fmt.Errorf("another block is already queued for consensus")
+	}
+
+	if started, position := globalScheduler.admit(cm); !started {
+		cm.queuedBlock = block
+		log.Printf("Consensus for block %d on chain %s queued at position %d", block.Height, cm.chainID, position)
+		return &QueuedError{Position: position}
+	}
 
+	cm.startLocked(block)
+	return nil
+}
+
+// startLocked begins consensus on a block that holds a global slot. Callers hold cm.mu.
+func (cm *ConsensusManager) startLocked(block *core.Block) {
 	// Create new consensus for the block
+	start := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	strategy, config := strategyFor(block.ChainID)
 	cm.activeConsensus = &BlockConsensus{
 		Block:       block,
 		State:       Pending,
 		Votes:       make(map[string]bool),
-		StartTime:   time.Now(),
+		StartTime:   start,
 		Discussions: make([]Discussion, 0),
+		Rounds:      strategy.Rounds(config),
+		strategy:    strategy,
+		budget:      budgetFor(block.ChainID, start),
+		ctx:         ctx,
+		cancel:      cancel,
+		finished:    make(chan struct{}),
 	}
 
+	RecordTimelineEvent(cm.chainID, block.Hash(), TimelineEvent{
+		Kind:      TimelineProposed,
+		Timestamp: cm.activeConsensus.StartTime,
+		Detail:    fmt.Sprintf("height %d with %d transactions", block.Height, len(block.Txs)),
+	})
+
 	// Start consensus process
 	go cm.runConsensusProcess()
-
-	return nil
 }
 
 // runConsensusProcess manages the lifecycle of block consensus
 func (cm *ConsensusManager) runConsensusProcess() {
+	defer globalScheduler.release()
+	defer close(cm.activeConsensus.finished)
+	defer cm.activeConsensus.cancel()
+
 	// Move to discussion phase
 	cm.activeConsensus.mu.Lock()
 	cm.activeConsensus.State = InDiscussion
@@ -119,15 +208,15 @@ func (cm *ConsensusManager) runConsensusProcess() {
 		return
 	}
 
-	// Wait for all discussion rounds plus voting round
-	totalTime := time.Duration(DiscussionRounds+1) * RoundDuration
-	time.Sleep(totalTime)
+	// Wait for all discussion rounds plus voting round, or until the block's budget runs out
+	cm.activeConsensus.awaitVotes()
 
-	// Add additional buffer time for last votes to arrive
-	time.Sleep(5 * time.Second) // Buffer for vote collection
-
-	// Move to finalization phase
+	// Move to finalization phase, unless the block was cancelled while it was discussed
 	cm.activeConsensus.mu.Lock()
+	if cm.activeConsensus.cancelRequested {
+		cm.finishCancelled(cm.activeConsensus)
+		return
+	}
 	cm.activeConsensus.State = Finalizing
 
 	// Get final consensus state
@@ -142,39 +231,20 @@ func (cm *ConsensusManager) runConsensusProcess() {
 	}
 
 	// Count votes
-	support := 0
-	oppose := 0
-	// Track which validators have voted to prevent duplicates
-	votedValidators := make(map[string]bool)
-
-	for _, d := range consensus.Discussions {
-		if d.Round == DiscussionRounds+1 { // Only count final votes
-			// Skip if we've already counted this validator's vote
-			if votedValidators[d.ValidatorID] {
-				continue
-			}
-			votedValidators[d.ValidatorID] = true
-
-			if strings.ToLower(d.Type) == "support" {
-				support++
-			} else if strings.ToLower(d.Type) == "oppose" {
-				oppose++
-			}
-		}
-	}
+	tally := tallyVotes(consensus.Discussions, consensus.FinalRound(), bc.Config)
+	support, oppose := tally.Support, tally.Oppose
+	supportWeight, opposeWeight := tally.SupportWeight, tally.OpposeWeight
 
 	// Make final decision
 	totalVotes := support + oppose
-	if totalVotes < MinimumValidators {
-		cm.activeConsensus.State = Rejected
-		// Return transactions to mempool
-		for _, tx := range cm.activeConsensus.Block.Txs {
-			bc.Mempool.AddTransaction(tx)
-		}
-	} else if float64(support)/float64(totalVotes) > 0.5 {
+	accepted, reason := false, participationReason(consensus.BudgetExceeded())
+	if totalVotes >= MinimumValidators {
+		accepted, reason = consensus.consensusStrategy().Decide(tally, bc.Config)
+	}
+	if accepted {
 		cm.activeConsensus.State = Accepted
 		// Add block to blockchain
-		if err := bc.AddBlock(*cm.activeConsensus.Block); err != nil {
+		if err := bc.FinalizeBlock(*cm.activeConsensus.Block); err != nil {
 			log.Printf("Failed to add accepted block: %v", err)
 			cm.activeConsensus.State = Rejected
 			// Return transactions to mempool on failure
@@ -184,6 +254,8 @@ func (cm *ConsensusManager) runConsensusProcess() {
 		} else {
 			// Clear processed transactions from mempool
 			bc.Mempool.CleanupExpiredTransactions()
+			// Let peers that missed consensus append the block too
+			go bc.GossipBlock(*cm.activeConsensus.Block)
 		}
 	} else {
 		cm.activeConsensus.State = Rejected
@@ -195,45 +267,151 @@ func (cm *ConsensusManager) runConsensusProcess() {
 
 	// Broadcast results
 	result := ConsensusResult{
-		State:   cm.activeConsensus.State,
-		Support: support,
-		Oppose:  oppose,
+		State:         cm.activeConsensus.State,
+		Support:       support,
+		Oppose:        oppose,
+		SupportWeight: supportWeight,
+		OpposeWeight:  opposeWeight,
+		Votes:         tally.Votes,
 	}
+	cm.activeConsensus.Result = &result
 
 	// Broadcast verdict
-	communication.BroadcastEvent(communication.EventBlockVerdict, result)
+	communication.BroadcastChainEvent(cm.chainID, communication.EventBlockVerdict, result)
 
 	// Broadcast detailed voting result
-	votingResult := struct {
-		BlockHeight int64          `json:"blockHeight"`
-		State       ConsensusState `json:"state"`
-		Support     int            `json:"support"`
-		Oppose      int            `json:"oppose"`
-		Accepted    bool           `json:"accepted"`
-		Reason      string         `json:"reason"`
-	}{
-		BlockHeight: int64(cm.activeConsensus.Block.Height),
-		State:       cm.activeConsensus.State,
-		Support:     support,
-		Oppose:      oppose,
-		Accepted:    cm.activeConsensus.State == Accepted,
-		Reason:      getConsensusReason(support, oppose, totalVotes),
+	votingResult := VotingResult{
+		BlockHeight:   int64(cm.activeConsensus.Block.Height),
+		State:         cm.activeConsensus.State,
+		Support:       support,
+		Oppose:        oppose,
+		SupportWeight: supportWeight,
+		OpposeWeight:  opposeWeight,
+		Accepted:      cm.activeConsensus.State == Accepted,
+		Reason:        reason,
 	}
-	communication.BroadcastEvent(communication.EventVotingResult, votingResult)
+	communication.BroadcastChainEvent(cm.chainID, communication.EventVotingResult, votingResult)
+
+	RecordTimelineEvent(cm.chainID, cm.activeConsensus.Block.Hash(), TimelineEvent{
+		Kind:   TimelineConsensusResult,
+		Detail: fmt.Sprintf("accepted=%t support=%d oppose=%d: %s", votingResult.Accepted, support, oppose, votingResult.Reason),
+	})
 
 	// Notify subscribers
-	cm.notifySubscribers(int64(cm.activeConsensus.Block.Height), result)
-	cm.activeConsensus.mu.Unlock()
+	concluded := cm.activeConsensus
+	cm.notifySubscribers(int64(concluded.Block.Height), result)
+	concluded.mu.Unlock()
+
+	notifyResultListeners(concluded, result)
+}
+
+// tallyVotes counts the final votes in discussions, once per validator, and collects them
+// with their reasons. Observers never vote.
+func tallyVotes(discussions []Discussion, finalRound int, config core.ChainConfig) ConsensusResult {
+	tally := ConsensusResult{Votes: []ValidatorVote{}}
+	// Track which validators have voted to prevent duplicates
+	votedValidators := make(map[string]bool)
+
+	for _, d := range discussions {
+		if d.Round != finalRound || d.Observer || votedValidators[d.ValidatorID] {
+			continue
+		}
+		votedValidators[d.ValidatorID] = true
+
+		stance := strings.ToLower(d.Type)
+		if stance == "support" {
+			tally.Support++
+			tally.SupportWeight += voteWeight(d, config)
+		} else if stance == "oppose" {
+			tally.Oppose++
+			tally.OpposeWeight += voteWeight(d, config)
+		} else {
+			continue
+		}
+		tally.Votes = append(tally.Votes, ValidatorVote{
+			ValidatorID: d.ValidatorID,
+			Name:        d.ValidatorName,
+			Stance:      stance,
+			Reason:      d.Reason,
+		})
+	}
+	return tally
+}
+
+// acceptsBlock reports whether weighted support makes up at least threshold of the total
+// weighted power that voted. A vote where no weight was cast rejects the block.
+func acceptsBlock(supportWeight, totalWeight, threshold float64) bool {
+	return totalWeight > 0 && supportWeight/totalWeight >= threshold
+}
+
+// participationReason explains rejecting a block that too few validators voted on
+func participationReason(budgetExceeded bool) string {
+	if budgetExceeded {
+		return "Block budget exceeded before enough validators voted"
+	}
+	return "Insufficient validator participation"
+}
+
+// DefaultVotingPower is the voting power of a validator registered without one
+const DefaultVotingPower = 1.0
+
+// voteWeight returns how much a final vote counts towards the tally.
+// Every vote weighs 1.0, plus the chain's research bonus when research
+// weighting is enabled and the validator backed its stance with web research.
+// With confidence weighting the result is scaled by the validator's stated
+// confidence; votes that didn't state one keep their full weight. The result
+// is then scaled by the validator's voting power.
+func voteWeight(d Discussion, config core.ChainConfig) float64 {
+	weight := 1.0
+	if config.ResearchWeighting && d.Researched {
+		weight += config.ResearchBonus()
+	}
+	if config.ConfidenceWeighting && d.Confidence != nil {
+		weight *= *d.Confidence
+	}
+	return weight * votingPower(d)
+}
+
+// votingPower returns the power behind a final vote, treating votes recorded without one
+// as DefaultVotingPower
+func votingPower(d Discussion) float64 {
+	if d.VotingPower <= 0 {
+		return DefaultVotingPower
+	}
+	return d.VotingPower
 }
 
-func getConsensusReason(support, oppose, total int) string {
-	if total < MinimumValidators {
-		return "Insufficient validator participation"
+// clampConfidence bounds a model-reported confidence to [0, 1]
+func clampConfidence(confidence *float64) *float64 {
+	if confidence == nil {
+		return nil
 	}
-	if float64(support)/float64(total) > 0.5 {
-		return "Majority support achieved"
+	c := math.Max(0, math.Min(1, *confidence))
+	return &c
+}
+
+// RemoveValidator drops a validator removed from the chain from the block in consensus.
+// Its goroutine stops contributing at the next round, it no longer rotates into the devil's
+// advocate role, and consensus carries on with the remaining validators; what it already
+// said stays in the discussion.
+func (cm *ConsensusManager) RemoveValidator(validatorID string) {
+	if active := cm.GetActiveConsensus(); active != nil {
+		active.leave(validatorID)
 	}
-	return "Insufficient support"
+}
+
+// Busy reports whether a block is in consensus or queued for a slot, in which case
+// ProposeBlock would refuse another
+func (cm *ConsensusManager) Busy() bool {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	active := cm.activeConsensus != nil && !concluded(cm.activeConsensus.State)
+	return active || cm.queuedBlock != nil
+}
+
+// concluded reports whether a consensus state is final
+func concluded(state ConsensusState) bool {
+	return state == Accepted || state == Rejected || state == Cancelled
 }
 
 // GetActiveConsensus returns the current consensus state
@@ -253,6 +431,32 @@ func (cm *ConsensusManager) SubscribeResult(blockHeight int64, ch chan Consensus
 	cm.subscribers[blockHeight] = append(cm.subscribers[blockHeight], ch)
 }
 
+// ResultListener is notified once consensus on a block has been accepted or rejected
+type ResultListener func(chainID string, bc *BlockConsensus, result ConsensusResult)
+
+var (
+	resultListeners   []ResultListener
+	resultListenersMu sync.RWMutex
+)
+
+// OnConsensusResult registers a listener called whenever a block is accepted or rejected
+func OnConsensusResult(listener ResultListener) {
+	resultListenersMu.Lock()
+	defer resultListenersMu.Unlock()
+	resultListeners = append(resultListeners, listener)
+}
+
+func notifyResultListeners(bc *BlockConsensus, result ConsensusResult) {
+	resultListenersMu.RLock()
+	listeners := make([]ResultListener, len(resultListeners))
+	copy(listeners, resultListeners)
+	resultListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(bc.Block.ChainID, bc, result)
+	}
+}
+
 // notifySubscribers sends result to all subscribers
 func (cm *ConsensusManager) notifySubscribers(height int64, result ConsensusResult) {
 	cm.mu.Lock()
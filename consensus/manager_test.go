@@ -0,0 +1,148 @@
+package consensus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestTallyVotesWeighsVotingPower(t *testing.T) {
+	const final = 2
+	discussions := []Discussion{
+		{ValidatorID: "a", Type: "support", Round: final, VotingPower: 3},
+		{ValidatorID: "b", Type: "oppose", Round: final},
+		{ValidatorID: "c", Type: "oppose", Round: final, VotingPower: 1},
+		{ValidatorID: "a", Type: "oppose", Round: final, VotingPower: 3}, // Duplicate, ignored
+	}
+
+	tally := tallyVotes(discussions, final, core.DefaultChainConfig())
+	if tally.Support != 1 || tally.Oppose != 2 {
+		t.Fatalf("expected 1 support and 2 oppose, got %d and %d", tally.Support, tally.Oppose)
+	}
+	if tally.SupportWeight != 3 || tally.OpposeWeight != 2 {
+		t.Fatalf("expected weights 3 and 2, got %v and %v", tally.SupportWeight, tally.OpposeWeight)
+	}
+	if !acceptsBlock(tally.SupportWeight, tally.SupportWeight+tally.OpposeWeight, core.DefaultAcceptanceThreshold) {
+		t.Fatal("weighted majority should accept the block")
+	}
+}
+
+// finalVote builds a final vote the way StartBlockDiscussion records it: the validator's
+// response re-encoded as the message, with the reason kept alongside
+func finalVote(t *testing.T, id, name, stance, reason string, round int) Discussion {
+	t.Helper()
+	confidence := 0.8
+	encoded, err := json.Marshal(finalVoteResponse{Stance: stance, Reason: reason, Confidence: &confidence})
+	if err != nil {
+		t.Fatalf("failed to encode vote: %v", err)
+	}
+	return Discussion{
+		ValidatorID:   id,
+		ValidatorName: name,
+		Message:       string(encoded),
+		Type:          strings.ToLower(stance),
+		Round:         round,
+		Confidence:    &confidence,
+		Reason:        reason,
+	}
+}
+
+func TestTallyVotesCollectsReasons(t *testing.T) {
+	const final = 2
+	observer := finalVote(t, "o", "Olive", "SUPPORT", "Advisory", final)
+	observer.Observer = true
+	fallback := Discussion{ValidatorID: "c", ValidatorName: "Carol", Type: "oppose", Round: final, Reason: fallbackVoteReason}
+	discussions := []Discussion{
+		{ValidatorID: "a", ValidatorName: "Alice", Type: "comment", Round: 1, Message: "Looks fine so far"},
+		finalVote(t, "a", "Alice", "SUPPORT", "Transactions are valid", final),
+		finalVote(t, "b", "Bob", "oppose", "Too boring", final),
+		observer,
+		finalVote(t, "b", "Bob", "support", "Changed my mind", final), // Duplicate, ignored
+		fallback,
+	}
+
+	votes := tallyVotes(discussions, final, core.DefaultChainConfig()).Votes
+	want := []ValidatorVote{
+		{ValidatorID: "a", Name: "Alice", Stance: "support", Reason: "Transactions are valid"},
+		{ValidatorID: "b", Name: "Bob", Stance: "oppose", Reason: "Too boring"},
+		{ValidatorID: "c", Name: "Carol", Stance: "oppose", Reason: fallbackVoteReason},
+	}
+	if len(votes) != len(want) {
+		t.Fatalf("expected %d votes, got %+v", len(want), votes)
+	}
+	for i := range want {
+		if votes[i] != want[i] {
+			t.Errorf("vote %d: expected %+v, got %+v", i, want[i], votes[i])
+		}
+	}
+}
+
+func TestAcceptsBlockAtThreshold(t *testing.T) {
+	cases := []struct {
+		threshold       float64
+		support, oppose float64
+		want            bool
+	}{
+		{0.5, 1, 1, true}, // Exactly at the threshold
+		{0.5, 49, 51, false},
+		{0.5, 51, 49, true},
+		{0.66, 66, 34, true}, // Exactly at the threshold
+		{0.66, 65, 35, false},
+		{0.66, 2, 1, true},
+		{0.75, 3, 1, true}, // Exactly at the threshold
+		{0.75, 74, 26, false},
+		{0.75, 2, 1, false},
+		{0.5, 0, 0, false}, // No weight cast
+	}
+	for _, tc := range cases {
+		if got := acceptsBlock(tc.support, tc.support+tc.oppose, tc.threshold); got != tc.want {
+			t.Errorf("threshold %v with support %v and oppose %v: accepted = %v, want %v",
+				tc.threshold, tc.support, tc.oppose, got, tc.want)
+		}
+	}
+}
+
+func TestRemovedValidatorLeavesDiscussion(t *testing.T) {
+	bc := &BlockConsensus{Block: &core.Block{Height: 1}}
+	bc.join("a")
+	bc.join("b")
+
+	bc.leave("a")
+	bc.join("a") // Rejoining after removal is ignored
+
+	if !bc.hasLeft("a") || bc.hasLeft("b") {
+		t.Fatalf("hasLeft a=%v b=%v", bc.hasLeft("a"), bc.hasLeft("b"))
+	}
+	if len(bc.participants) != 1 || bc.participants[0] != "b" {
+		t.Fatalf("participants = %v, want [b]", bc.participants)
+	}
+	for round := 1; round <= 3; round++ {
+		if id := bc.devilsAdvocateFor(round); id != "b" {
+			t.Fatalf("round %d devil's advocate = %q, want b", round, id)
+		}
+	}
+}
+
+func TestVoteWeightBoundsResearchBonus(t *testing.T) {
+	researched := Discussion{Researched: true}
+	cases := []struct {
+		bonus float64
+		want  float64
+	}{
+		{core.DefaultResearchWeightBonus, 1.25},
+		{0, 1},
+		{-2, 1}, // A negative bonus would weigh the vote against its own stance
+		{core.MaxResearchWeightBonus, 2},
+		{10, 2},
+	}
+	for _, tc := range cases {
+		config := core.DefaultChainConfig()
+		config.ResearchWeighting = true
+		config.ResearchWeightBonus = tc.bonus
+		if got := voteWeight(researched, config); got != tc.want {
+			t.Errorf("bonus %v: weight = %v, want %v", tc.bonus, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Progress describes how far consensus on a block has got
+type Progress struct {
+	BlockHeight   int              `json:"blockHeight"`
+	BlockHash     string           `json:"blockHash"`
+	State         string           `json:"state"` // queued, pending, discussing, finalizing, accepted, rejected or cancelled
+	Round         int              `json:"round"` // Latest round with a contribution; FinalRound once validators vote
+	Rounds        int              `json:"rounds"`
+	FinalRound    int              `json:"finalRound"`
+	Participants  int              `json:"participants"` // Voting validators that joined the discussion
+	Voted         int              `json:"voted"`        // Validators that cast a final vote
+	Support       int              `json:"support"`
+	Oppose        int              `json:"oppose"`
+	SupportWeight float64          `json:"supportWeight"`
+	OpposeWeight  float64          `json:"opposeWeight"`
+	Final         bool             `json:"final"` // Whether a result exists
+	Result        *ConsensusResult `json:"result,omitempty"`
+	Budget        *BudgetUsage     `json:"budget,omitempty"`
+}
+
+var stateNames = map[ConsensusState]string{
+	Pending:      "pending",
+	InDiscussion: "discussing",
+	Finalizing:   "finalizing",
+	Accepted:     "accepted",
+	Rejected:     "rejected",
+	Cancelled:    "cancelled",
+}
+
+// Progress reports the state of consensus on the block at height. It only knows about the
+// block currently or most recently in consensus and a block queued for a slot; ok is false
+// for any other height.
+func (cm *ConsensusManager) Progress(height int) (Progress, bool) {
+	cm.mu.RLock()
+	active, queued := cm.activeConsensus, cm.queuedBlock
+	cm.mu.RUnlock()
+
+	if queued != nil && queued.Height == height {
+		return Progress{BlockHeight: height, BlockHash: queued.Hash(), State: "queued"}, true
+	}
+	if active == nil || active.Block.Height != height {
+		return Progress{}, false
+	}
+	return active.progress(), true
+}
+
+func (bc *BlockConsensus) progress() Progress {
+	config := core.DefaultChainConfig()
+	if chain := core.GetChain(bc.Block.ChainID); chain != nil {
+		config = chain.Config
+	}
+	budget := bc.Budget()
+
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	round := 0
+	voted := make(map[string]bool)
+	for _, d := range bc.Discussions {
+		if d.Round > round {
+			round = d.Round
+		}
+		if d.Round == bc.FinalRound() && !d.Observer {
+			voted[d.ValidatorID] = true
+		}
+	}
+
+	tally := tallyVotes(bc.Discussions, bc.FinalRound(), config)
+	if bc.Result != nil {
+		tally = *bc.Result
+	}
+
+	return Progress{
+		BlockHeight:   bc.Block.Height,
+		BlockHash:     bc.Block.Hash(),
+		State:         stateNames[bc.State],
+		Round:         round,
+		Rounds:        bc.Rounds,
+		FinalRound:    bc.FinalRound(),
+		Participants:  len(bc.participants),
+		Voted:         len(voted),
+		Support:       tally.Support,
+		Oppose:        tally.Oppose,
+		SupportWeight: tally.SupportWeight,
+		OpposeWeight:  tally.OpposeWeight,
+		Final:         bc.Result != nil,
+		Result:        bc.Result,
+		Budget:        &budget,
+	}
+}
@@ -0,0 +1,94 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/NethermindEth/chaoschain-launchpad/ai"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Provenance captures what produced a consensus decision so it can be reproduced later
+type Provenance struct {
+	Model                 string      `json:"model"`
+	Provider              string      `json:"provider"`
+	Temperature           float32     `json:"temperature"`
+	PromptTemplateVersion string      `json:"promptTemplateVersion"`
+	PromptTemplateHash    string      `json:"promptTemplateHash"`
+	Strategy              string      `json:"strategy"`
+	DiscussionRounds      int         `json:"discussionRounds"`
+	AcceptanceThreshold   float64     `json:"acceptanceThreshold"`
+	MinimumValidators     int         `json:"minimumValidators"`
+	VotingMode            string      `json:"votingMode"`
+	DevilsAdvocate        bool        `json:"devilsAdvocate"`
+	ResearchUsed          bool        `json:"researchUsed"`
+	FallbackUsed          bool        `json:"fallbackUsed"`
+	Budget                BudgetUsage `json:"budget"`
+}
+
+// PromptTemplateHash returns a SHA-256 hash over the discussion, final vote and devil's advocate prompts
+func PromptTemplateHash() string {
+	hash := sha256.Sum256([]byte(discussionPromptTemplate + finalVotePromptTemplate + devilsAdvocateNote))
+	return hex.EncodeToString(hash[:])
+}
+
+// Provenance describes the model, prompts and consensus settings behind this block's decision
+func (bc *BlockConsensus) Provenance() Provenance {
+	llmConfig := llmConfigFor(bc.Block.ChainID, core.LLMCallVote)
+
+	votingMode := "equal"
+	devilsAdvocate := false
+	threshold := core.DefaultAcceptanceThreshold
+	if chain := core.GetChain(bc.Block.ChainID); chain != nil {
+		var weightings []string
+		if chain.Config.ResearchWeighting {
+			weightings = append(weightings, "research_weighted")
+		}
+		if chain.Config.ConfidenceWeighting {
+			weightings = append(weightings, "confidence_weighted")
+		}
+		if len(weightings) > 0 {
+			votingMode = strings.Join(weightings, "+")
+		}
+		devilsAdvocate = chain.Config.DevilsAdvocate
+		threshold = chain.Config.Threshold()
+	}
+
+	budget := bc.Budget()
+
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	researchUsed := false
+	for _, d := range bc.Discussions {
+		if d.Researched {
+			researchUsed = true
+			break
+		}
+	}
+
+	return Provenance{
+		Model:                 llmConfig.Model,
+		Provider:              ai.ProviderName(),
+		Temperature:           llmConfig.Temperature,
+		PromptTemplateVersion: PromptTemplateVersion,
+		PromptTemplateHash:    PromptTemplateHash(),
+		Strategy:              bc.consensusStrategy().Name(),
+		DiscussionRounds:      bc.Rounds,
+		AcceptanceThreshold:   threshold,
+		MinimumValidators:     MinimumValidators,
+		VotingMode:            votingMode,
+		DevilsAdvocate:        devilsAdvocate,
+		ResearchUsed:          researchUsed,
+		FallbackUsed:          bc.FallbackUsed,
+		Budget:                budget,
+	}
+}
+
+// markFallback records that a placeholder was used because an LLM response couldn't be parsed
+func (bc *BlockConsensus) markFallback() {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.FallbackUsed = true
+}
@@ -0,0 +1,169 @@
+package consensus
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// DefaultMaxConcurrentConsensus bounds active consensus rounds across all chains
+// when CONSENSUS_MAX_CONCURRENT is unset
+const DefaultMaxConcurrentConsensus = 8
+
+// QueuedError is returned by ProposeBlock when the node is at its consensus cap
+// and the block was queued to start once a slot frees up
+type QueuedError struct {
+	Position int // 1-based position in the global queue
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("system busy: block queued at position %d", e.Position)
+}
+
+// LoadStats reports how many consensus rounds are running and waiting across all chains
+type LoadStats struct {
+	MaxConcurrent int      `json:"maxConcurrent"`
+	Active        int      `json:"active"`
+	Queued        int      `json:"queued"`
+	QueuedChains  []string `json:"queuedChains"` // In the order they will start
+}
+
+// scheduler admits consensus rounds up to a global cap and queues the rest in FIFO order
+type scheduler struct {
+	mu     sync.Mutex
+	max    int
+	active int
+	queue  []*ConsensusManager
+}
+
+var globalScheduler = &scheduler{max: maxConcurrentConsensusFromEnv()}
+
+func maxConcurrentConsensusFromEnv() int {
+	value := os.Getenv("CONSENSUS_MAX_CONCURRENT")
+	if value == "" {
+		return DefaultMaxConcurrentConsensus
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		log.Printf("Invalid CONSENSUS_MAX_CONCURRENT %q, using default of %d", value, DefaultMaxConcurrentConsensus)
+		return DefaultMaxConcurrentConsensus
+	}
+	return max
+}
+
+// SetMaxConcurrentConsensus changes the global cap; queued rounds start if the cap grows
+func SetMaxConcurrentConsensus(max int) {
+	if max <= 0 {
+		max = DefaultMaxConcurrentConsensus
+	}
+	globalScheduler.mu.Lock()
+	globalScheduler.max = max
+	globalScheduler.mu.Unlock()
+	globalScheduler.drain()
+}
+
+// GetLoadStats returns the current active and queued consensus counts
+func GetLoadStats() LoadStats {
+	s := globalScheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chains := make([]string, len(s.queue))
+	for i, cm := range s.queue {
+		chains[i] = cm.chainID
+	}
+	return LoadStats{
+		MaxConcurrent: s.max,
+		Active:        s.active,
+		Queued:        len(s.queue),
+		QueuedChains:  chains,
+	}
+}
+
+// QueuePosition returns the chain's 1-based position in the global queue, or 0 if it isn't queued
+func QueuePosition(chainID string) int {
+	s := globalScheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, cm := range s.queue {
+		if cm.chainID == chainID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// admit claims a slot for cm, or queues it and returns its position. Callers hold cm.mu.
+func (s *scheduler) admit(cm *ConsensusManager) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active < s.max {
+		s.active++
+		return true, 0
+	}
+	s.queue = append(s.queue, cm)
+	return false, len(s.queue)
+}
+
+// withdraw removes cm from the queue. Callers hold cm.mu.
+func (s *scheduler) withdraw(cm *ConsensusManager) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, queued := range s.queue {
+		if queued == cm {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// release frees a slot and starts the next queued rounds
+func (s *scheduler) release() {
+	s.mu.Lock()
+	s.active--
+	s.mu.Unlock()
+	s.drain()
+}
+
+// drain starts queued rounds while slots are free
+func (s *scheduler) drain() {
+	for {
+		s.mu.Lock()
+		if s.active >= s.max || len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		next := s.queue[0]
+		s.queue = s.queue[1:]
+		s.active++
+		s.mu.Unlock()
+
+		next.startQueued()
+	}
+}
+
+// startQueued starts consensus on the block waiting for a free slot
+func (cm *ConsensusManager) startQueued() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	block := cm.queuedBlock
+	cm.queuedBlock = nil
+	if block == nil {
+		globalScheduler.release()
+		return
+	}
+	log.Printf("Starting queued consensus for block %d on chain %s", block.Height, cm.chainID)
+	cm.startLocked(block)
+}
+
+// QueuedBlock returns the block waiting for a consensus slot on this chain, if any
+func (cm *ConsensusManager) QueuedBlock() *core.Block {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.queuedBlock
+}
@@ -0,0 +1,69 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DiscussionSigningDomain prefixes every signed discussion payload
+const DiscussionSigningDomain = "chaoschain-discussion-v1"
+
+// DiscussionSigner signs a payload on behalf of a validator and returns the base64
+// signature, or "" if the validator has no signing key
+type DiscussionSigner func(chainID, validatorID string, payload []byte) string
+
+var (
+	discussionSigner   DiscussionSigner
+	discussionSignerMu sync.RWMutex
+)
+
+// SetDiscussionSigner installs the signer used for every recorded discussion point
+func SetDiscussionSigner(signer DiscussionSigner) {
+	discussionSignerMu.Lock()
+	defer discussionSignerMu.Unlock()
+	discussionSigner = signer
+}
+
+func signDiscussion(chainID, blockHash string, d Discussion) string {
+	discussionSignerMu.RLock()
+	signer := discussionSigner
+	discussionSignerMu.RUnlock()
+
+	if signer == nil {
+		return ""
+	}
+	return signer(chainID, d.ValidatorID, SigningPayload(chainID, blockHash, d))
+}
+
+// SigningPayload returns the bytes a validator signs for a discussion point: the signing
+// domain, chain ID, block hash, discussion ID, validator ID, round, type, message and
+// timestamp in Unix nanoseconds, joined by newlines
+func SigningPayload(chainID, blockHash string, d Discussion) []byte {
+	return []byte(strings.Join([]string{
+		DiscussionSigningDomain,
+		chainID,
+		blockHash,
+		d.ID,
+		d.ValidatorID,
+		strconv.Itoa(d.Round),
+		d.Type,
+		d.Message,
+		strconv.FormatInt(d.Timestamp.UnixNano(), 10),
+	}, "\n"))
+}
+
+// VerifyDiscussion checks a discussion's signature against a base64 ed25519 public key
+func VerifyDiscussion(chainID, blockHash string, d Discussion, publicKey string) bool {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return false
+	}
+	signature, err := base64.StdEncoding.DecodeString(d.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(key), SigningPayload(chainID, blockHash, d), signature)
+}
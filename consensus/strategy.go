@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Names of the built-in consensus strategies, as set in ChainConfig.ConsensusStrategy
+const (
+	StrategyDeliberative = "deliberative" // Multi-round LLM discussion, decided by weighted votes
+	StrategyMajority     = "majority"     // A single round of votes, decided by head count
+)
+
+// ConsensusStrategy decides how validators reach a verdict on a block. The consensus manager
+// asks it how many discussion rounds to hold before the final vote, and once the votes are
+// in, whether they accept the block. Blocks with fewer than MinimumValidators votes are
+// rejected before the strategy is asked.
+type ConsensusStrategy interface {
+	// Name identifies the strategy in ChainConfig.ConsensusStrategy
+	Name() string
+	// Rounds is how many discussion rounds validators hold before the final vote; 0 goes
+	// straight to voting
+	Rounds(config core.ChainConfig) int
+	// Decide reports whether the final votes accept the block, and why
+	Decide(tally ConsensusResult, config core.ChainConfig) (accepted bool, reason string)
+}
+
+// DeliberativeStrategy is the default: validators discuss the block for the chain's configured
+// rounds, and it is accepted when weighted support reaches the chain's acceptance threshold
+type DeliberativeStrategy struct{}
+
+func (DeliberativeStrategy) Name() string { return StrategyDeliberative }
+
+func (DeliberativeStrategy) Rounds(config core.ChainConfig) int { return config.Rounds() }
+
+func (DeliberativeStrategy) Decide(tally ConsensusResult, config core.ChainConfig) (bool, string) {
+	if acceptsBlock(tally.SupportWeight, tally.SupportWeight+tally.OpposeWeight, config.Threshold()) {
+		return true, "Majority support achieved"
+	}
+	return false, "Insufficient support"
+}
+
+// MajorityStrategy skips discussion: validators vote once, and the block is accepted when
+// more of them support it than oppose it. Vote weights and the threshold are ignored.
+type MajorityStrategy struct{}
+
+func (MajorityStrategy) Name() string { return StrategyMajority }
+
+func (MajorityStrategy) Rounds(core.ChainConfig) int { return 0 }
+
+func (MajorityStrategy) Decide(tally ConsensusResult, _ core.ChainConfig) (bool, string) {
+	if tally.Support > tally.Oppose {
+		return true, "Majority of validators supported the block"
+	}
+	return false, "No majority of validators supported the block"
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]ConsensusStrategy{
+		StrategyDeliberative: DeliberativeStrategy{},
+		StrategyMajority:     MajorityStrategy{},
+	}
+)
+
+// RegisterConsensusStrategy makes a strategy selectable by its name, replacing any strategy
+// registered under the same name
+func RegisterConsensusStrategy(strategy ConsensusStrategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[strategy.Name()] = strategy
+}
+
+// LookupConsensusStrategy returns the strategy registered under name. An empty name is the
+// default DeliberativeStrategy.
+func LookupConsensusStrategy(name string) (ConsensusStrategy, bool) {
+	if name == "" {
+		name = StrategyDeliberative
+	}
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// ConsensusStrategyNames lists the registered strategies, sorted
+func ConsensusStrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// strategyFor returns the strategy and config a chain decides blocks with. Chains that are
+// unknown, or name a strategy that isn't registered, deliberate.
+func strategyFor(chainID string) (ConsensusStrategy, core.ChainConfig) {
+	bc := core.GetChain(chainID)
+	if bc == nil {
+		return DeliberativeStrategy{}, core.DefaultChainConfig()
+	}
+	strategy, ok := LookupConsensusStrategy(bc.Config.ConsensusStrategy)
+	if !ok {
+		log.Printf("Unknown consensus strategy %q on chain %s, deliberating instead", bc.Config.ConsensusStrategy, chainID)
+		return DeliberativeStrategy{}, bc.Config
+	}
+	return strategy, bc.Config
+}
+
+// consensusStrategy returns the strategy deciding this block
+func (bc *BlockConsensus) consensusStrategy() ConsensusStrategy {
+	if bc.strategy == nil {
+		return DeliberativeStrategy{}
+	}
+	return bc.strategy
+}
@@ -0,0 +1,60 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestDeliberativeStrategyWeighsVotes(t *testing.T) {
+	config := core.DefaultChainConfig()
+	config.DiscussionRounds = 3
+	strategy := DeliberativeStrategy{}
+
+	if rounds := strategy.Rounds(config); rounds != 3 {
+		t.Fatalf("expected the configured 3 rounds, got %d", rounds)
+	}
+	// One heavy supporter outweighs two opponents
+	tally := ConsensusResult{Support: 1, Oppose: 2, SupportWeight: 3, OpposeWeight: 2}
+	if accepted, _ := strategy.Decide(tally, config); !accepted {
+		t.Fatal("weighted support above the threshold should accept the block")
+	}
+	config.AcceptanceThreshold = 0.75
+	if accepted, _ := strategy.Decide(tally, config); accepted {
+		t.Fatal("weighted support below the threshold should reject the block")
+	}
+}
+
+func TestMajorityStrategyCountsHeads(t *testing.T) {
+	config := core.DefaultChainConfig()
+	strategy := MajorityStrategy{}
+
+	if rounds := strategy.Rounds(config); rounds != 0 {
+		t.Fatalf("expected no discussion rounds, got %d", rounds)
+	}
+	cases := []struct {
+		tally ConsensusResult
+		want  bool
+	}{
+		{ConsensusResult{Support: 1, Oppose: 2, SupportWeight: 3, OpposeWeight: 2}, false}, // Weights are ignored
+		{ConsensusResult{Support: 2, Oppose: 1, SupportWeight: 1, OpposeWeight: 5}, true},
+		{ConsensusResult{Support: 2, Oppose: 2, SupportWeight: 2, OpposeWeight: 2}, false}, // A tie is no majority
+	}
+	for _, tc := range cases {
+		if accepted, _ := strategy.Decide(tc.tally, config); accepted != tc.want {
+			t.Errorf("support %d oppose %d: accepted = %v, want %v", tc.tally.Support, tc.tally.Oppose, accepted, tc.want)
+		}
+	}
+}
+
+func TestLookupConsensusStrategy(t *testing.T) {
+	for name, want := range map[string]string{"": StrategyDeliberative, StrategyDeliberative: StrategyDeliberative, StrategyMajority: StrategyMajority} {
+		strategy, ok := LookupConsensusStrategy(name)
+		if !ok || strategy.Name() != want {
+			t.Errorf("LookupConsensusStrategy(%q) = %v, %v; want %s", name, strategy, ok, want)
+		}
+	}
+	if _, ok := LookupConsensusStrategy("chaos"); ok {
+		t.Fatal("unregistered strategy should not be found")
+	}
+}
@@ -0,0 +1,131 @@
+package consensus
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Live discussions buffered per stream before slow readers start missing them
+const streamBuffer = 256
+
+// BlockStream follows a single block's discussion: the discussions recorded before it was
+// opened, then each new discussion or vote, then the consensus result
+type BlockStream struct {
+	FinalRound  int // Round carried by final votes
+	Replay      []Discussion
+	Discussions <-chan Discussion
+	Result      <-chan ConsensusResult
+
+	key       string
+	live      chan Discussion
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var (
+	// Map of chainID/blockHash -> open streams
+	blockStreams   = make(map[string]map[*BlockStream]bool)
+	blockStreamsMu sync.Mutex
+)
+
+func init() {
+	OnDiscussion(publishToBlockStreams)
+}
+
+func blockStreamKey(chainID, blockHash string) string {
+	return chainID + "/" + blockHash
+}
+
+// StreamBlock opens a stream over the active consensus for blockHash. Close it when done.
+func (cm *ConsensusManager) StreamBlock(blockHash string) (*BlockStream, error) {
+	bc := cm.GetActiveConsensus()
+	if bc == nil || bc.Block.Hash() != blockHash {
+		return nil, fmt.Errorf("no consensus in progress for block %s", blockHash)
+	}
+
+	live := make(chan Discussion, streamBuffer)
+	result := make(chan ConsensusResult, 1)
+	stream := &BlockStream{
+		FinalRound:  bc.FinalRound(),
+		Discussions: live,
+		Result:      result,
+		key:         blockStreamKey(cm.chainID, blockHash),
+		live:        live,
+		done:        make(chan struct{}),
+	}
+
+	// Subscribe before taking the replay so nothing recorded in between is lost;
+	// live discussions already in the replay are skipped by the reader
+	blockStreamsMu.Lock()
+	if blockStreams[stream.key] == nil {
+		blockStreams[stream.key] = make(map[*BlockStream]bool)
+	}
+	blockStreams[stream.key][stream] = true
+	blockStreamsMu.Unlock()
+
+	results := make(chan ConsensusResult, 1)
+	cm.SubscribeResult(int64(bc.Block.Height), results)
+	stream.Replay = bc.GetDiscussions()
+
+	go stream.awaitResult(bc, results, result)
+	return stream, nil
+}
+
+// awaitResult forwards the block's consensus result. It also watches the consensus state,
+// so a result published before the stream subscribed still ends the stream.
+func (s *BlockStream) awaitResult(bc *BlockConsensus, results <-chan ConsensusResult, out chan<- ConsensusResult) {
+	ticker := time.NewTicker(RoundDuration())
+	defer ticker.Stop()
+	for {
+		select {
+		case res := <-results:
+			out <- res
+			return
+		case <-ticker.C:
+			bc.mu.RLock()
+			state := bc.State
+			bc.mu.RUnlock()
+			if concluded(state) {
+				select {
+				case res := <-results:
+					out <- res
+				default:
+					out <- ConsensusResult{State: state}
+				}
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Close stops delivery to the stream
+func (s *BlockStream) Close() {
+	s.closeOnce.Do(func() {
+		blockStreamsMu.Lock()
+		delete(blockStreams[s.key], s)
+		if len(blockStreams[s.key]) == 0 {
+			delete(blockStreams, s.key)
+		}
+		blockStreamsMu.Unlock()
+		close(s.done)
+	})
+}
+
+// publishToBlockStreams hands a recorded discussion to every stream open on its block
+func publishToBlockStreams(chainID string, bc *BlockConsensus, d Discussion) {
+	key := blockStreamKey(chainID, bc.Block.Hash())
+
+	blockStreamsMu.Lock()
+	defer blockStreamsMu.Unlock()
+	for stream := range blockStreams[key] {
+		select {
+		case stream.live <- d:
+		default:
+			log.Printf("Block stream for %s is falling behind, dropped discussion %s", key, d.ID)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package consensus
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Timeline event kinds recorded over a block's lifecycle
+const (
+	TimelineProposed        = "proposed"
+	TimelineDiscussion      = "discussion"
+	TimelineVote            = "vote"
+	TimelineConsensusResult = "consensus_result"
+	TimelineOffchainSaved   = "offchain_saved"
+	TimelineBudgetExceeded  = "budget_exceeded"
+	TimelineCancelled       = "cancelled"
+)
+
+// TimelineEvent is a single step in a block's lifecycle
+type TimelineEvent struct {
+	Kind        string    `json:"kind"`
+	Timestamp   time.Time `json:"timestamp"`
+	ValidatorID string    `json:"validatorId,omitempty"`
+	Round       int       `json:"round,omitempty"`
+	Detail      string    `json:"detail,omitempty"`
+}
+
+// timelineBlockLimit is how many blocks' timelines are kept per chain; the oldest are dropped
+// first. Older blocks' timelines are rebuilt from their stored offchain data.
+const timelineBlockLimit = 200
+
+// chainTimelines holds the timelines of a chain's most recent blocks
+type chainTimelines struct {
+	order  []string // Block hashes, oldest first
+	events map[string][]TimelineEvent
+}
+
+var (
+	// Map of chainID -> timelines of its recent blocks
+	timelines   = make(map[string]*chainTimelines)
+	timelinesMu sync.RWMutex
+)
+
+func init() {
+	// Archived chains have concluded their blocks; their timelines come from offchain data
+	core.OnChainArchived(DropChainTimelines)
+}
+
+// RecordTimelineEvent appends an event to a block's lifecycle timeline
+func RecordTimelineEvent(chainID, blockHash string, event TimelineEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	timelinesMu.Lock()
+	defer timelinesMu.Unlock()
+	chain := timelines[chainID]
+	if chain == nil {
+		chain = &chainTimelines{events: make(map[string][]TimelineEvent)}
+		timelines[chainID] = chain
+	}
+	if _, known := chain.events[blockHash]; !known {
+		if len(chain.order) >= timelineBlockLimit {
+			delete(chain.events, chain.order[0])
+			chain.order = chain.order[1:]
+		}
+		chain.order = append(chain.order, blockHash)
+	}
+	chain.events[blockHash] = append(chain.events[blockHash], event)
+}
+
+// GetBlockTimeline returns the recorded events for a block ordered by time
+func GetBlockTimeline(chainID, blockHash string) []TimelineEvent {
+	timelinesMu.RLock()
+	var events []TimelineEvent
+	if chain := timelines[chainID]; chain != nil {
+		events = append(events, chain.events[blockHash]...)
+	}
+	timelinesMu.RUnlock()
+
+	SortTimeline(events)
+	return events
+}
+
+// DropChainTimelines forgets the timelines recorded for a chain's blocks
+func DropChainTimelines(chainID string) {
+	timelinesMu.Lock()
+	defer timelinesMu.Unlock()
+	delete(timelines, chainID)
+}
+
+// SortTimeline orders events by timestamp, keeping insertion order for ties
+func SortTimeline(events []TimelineEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+}
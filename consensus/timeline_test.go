@@ -0,0 +1,35 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTimelinesKeepRecentBlocksPerChain(t *testing.T) {
+	const chainID = "timeline-cap"
+	defer DropChainTimelines(chainID)
+
+	for i := 0; i <= timelineBlockLimit; i++ {
+		hash := fmt.Sprintf("block-%d", i)
+		RecordTimelineEvent(chainID, hash, TimelineEvent{Kind: TimelineProposed})
+		RecordTimelineEvent(chainID, hash, TimelineEvent{Kind: TimelineConsensusResult})
+	}
+
+	if events := GetBlockTimeline(chainID, "block-0"); len(events) != 0 {
+		t.Fatalf("oldest block's timeline should be dropped past the limit, got %d events", len(events))
+	}
+	if events := GetBlockTimeline(chainID, "block-1"); len(events) != 2 {
+		t.Fatalf("expected block-1 to keep both events, got %d", len(events))
+	}
+	timelinesMu.RLock()
+	kept := len(timelines[chainID].events)
+	timelinesMu.RUnlock()
+	if kept != timelineBlockLimit {
+		t.Fatalf("kept %d timelines, want %d", kept, timelineBlockLimit)
+	}
+
+	DropChainTimelines(chainID)
+	if events := GetBlockTimeline(chainID, "block-1"); len(events) != 0 {
+		t.Fatalf("timelines should be gone once the chain is dropped, got %d events", len(events))
+	}
+}
@@ -11,4 +11,7 @@ type Agent struct {
 	Mood       string `json:"mood"`
 	APIKey     string `json:"api_key"`
 	Endpoint   string `json:"endpoint"`
+	Observer   bool   `json:"observer"` // Validator that comments in discussions without voting
+	// Scales the weight of a validator's final votes; 1 when omitted
+	VotingPower float64 `json:"voting_power,omitempty"`
 }
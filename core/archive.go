@@ -0,0 +1,298 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+// DefaultArchiveDir is where archived chains are written when CHAIN_ARCHIVE_DIR is unset
+const DefaultArchiveDir = "data/archive"
+
+var (
+	// ErrChainNotFound is returned for a chain that is neither in memory nor archived
+	ErrChainNotFound = errors.New("chain not found")
+	// ErrChainBusy is returned when archiving a chain that still has work in flight
+	ErrChainBusy = errors.New("chain is busy")
+	// ErrChainArchived is returned for writes to a Blockchain that has since been archived.
+	// Look the chain up again with GetChain to restore it.
+	ErrChainArchived = errors.New("chain has been archived")
+)
+
+// ChainArchive is the serialized state of an archived chain
+type ChainArchive struct {
+	ChainID    string            `json:"chain_id"`
+	Config     ChainConfig       `json:"config"`
+	Blocks     []Block           `json:"blocks"`
+	Nonces     map[string]uint64 `json:"nonces,omitempty"` // Highest accepted nonce per sender
+	ArchivedAt int64             `json:"archived_at"`
+}
+
+// archivedChain is the stub left in memory after a chain's state is written to disk.
+// The mempool and node registry are kept because they belong to live network resources.
+type archivedChain struct {
+	path          string
+	mempool       MempoolInterface
+	nodes         map[string]*p2p.Node
+	bootstrapAddr string
+	blocks        int
+}
+
+// Map of chainID -> archive stub, guarded by chainsLock
+var archivedChains = make(map[string]*archivedChain)
+
+func archiveDir() string {
+	if dir := os.Getenv("CHAIN_ARCHIVE_DIR"); dir != "" {
+		return dir
+	}
+	return DefaultArchiveDir
+}
+
+// ChainArchivedListener is called with the ID of every chain moved to cold storage
+type ChainArchivedListener func(chainID string)
+
+var (
+	archivedListeners   []ChainArchivedListener
+	archivedListenersMu sync.RWMutex
+)
+
+// OnChainArchived registers a listener called after a chain is archived, to free what it
+// kept about the chain in memory
+func OnChainArchived(listener ChainArchivedListener) {
+	archivedListenersMu.Lock()
+	defer archivedListenersMu.Unlock()
+	archivedListeners = append(archivedListeners, listener)
+}
+
+func notifyChainArchived(chainID string) {
+	archivedListenersMu.RLock()
+	listeners := make([]ChainArchivedListener, len(archivedListeners))
+	copy(listeners, archivedListeners)
+	archivedListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(chainID)
+	}
+}
+
+// ChainBusyCheck reports whether work on a chain is in flight that archiving would cut off,
+// such as a block in consensus
+type ChainBusyCheck func(chainID string) bool
+
+var (
+	busyChecks   []ChainBusyCheck
+	busyChecksMu sync.RWMutex
+
+	// archiveMu serializes ArchiveChain, so a chain is retired and snapshotted once
+	archiveMu sync.Mutex
+)
+
+// AddChainBusyCheck registers a check consulted before a chain is archived. Chains any
+// check reports busy are left in memory.
+func AddChainBusyCheck(check ChainBusyCheck) {
+	busyChecksMu.Lock()
+	defer busyChecksMu.Unlock()
+	busyChecks = append(busyChecks, check)
+}
+
+// chainBusy returns why a chain can't be archived right now, or nil if it can
+func chainBusy(bc *Blockchain) error {
+	if bc.Mempool != nil {
+		if pending := bc.Mempool.Size(); pending > 0 {
+			return fmt.Errorf("%w: %d pending transactions", ErrChainBusy, pending)
+		}
+	}
+
+	busyChecksMu.RLock()
+	checks := make([]ChainBusyCheck, len(busyChecks))
+	copy(checks, busyChecks)
+	busyChecksMu.RUnlock()
+	for _, busy := range checks {
+		if busy(bc.ChainID) {
+			return fmt.Errorf("%w: a block is in progress", ErrChainBusy)
+		}
+	}
+	return nil
+}
+
+// ArchiveChain writes a chain's state to an archive file and frees its blocks from memory.
+// The chain is restored transparently the next time it is read through GetChain. Chains with
+// pending transactions or a block in progress aren't archived and get ErrChainBusy.
+//
+// The Blockchain is retired before its state is copied: transactions and blocks written
+// through it afterwards, by callers that looked it up earlier, fail with ErrChainArchived
+// rather than being lost.
+func ArchiveChain(chainID string) (string, error) {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+
+	chainsLock.RLock()
+	bc, exists := chains[chainID]
+	stub, archived := archivedChains[chainID]
+	chainsLock.RUnlock()
+	if !exists {
+		if archived {
+			return stub.path, nil
+		}
+		return "", fmt.Errorf("%w: %s", ErrChainNotFound, chainID)
+	}
+
+	bc.setRetired(true)
+	path, blocks, err := writeChainArchive(bc)
+	if err != nil {
+		bc.setRetired(false)
+		return "", err
+	}
+
+	bc.NodesMu.RLock()
+	nodes, bootstrapAddr := bc.Nodes, bc.bootstrapAddr
+	bc.NodesMu.RUnlock()
+
+	chainsLock.Lock()
+	archivedChains[chainID] = &archivedChain{
+		path:          path,
+		mempool:       bc.Mempool,
+		nodes:         nodes,
+		bootstrapAddr: bootstrapAddr,
+		blocks:        blocks,
+	}
+	delete(chains, chainID)
+	chainsLock.Unlock()
+
+	log.Printf("Archived chain %s (%d blocks) to %s", chainID, blocks, path)
+	notifyChainArchived(chainID)
+	return path, nil
+}
+
+// writeChainArchive checks a retired chain is idle and writes its state to its archive file,
+// returning the path and how many blocks it holds
+func writeChainArchive(bc *Blockchain) (string, int, error) {
+	if err := chainBusy(bc); err != nil {
+		return "", 0, err
+	}
+
+	archive := ChainArchive{
+		ChainID:    bc.ChainID,
+		Config:     bc.Config,
+		Blocks:     bc.BlocksSnapshot(),
+		Nonces:     bc.nonceSnapshot(),
+		ArchivedAt: time.Now().Unix(),
+	}
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to encode chain %s: %v", bc.ChainID, err)
+	}
+
+	dir := archiveDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("failed to create archive directory: %v", err)
+	}
+	path := filepath.Join(dir, bc.ChainID+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", 0, fmt.Errorf("failed to write archive for chain %s: %v", bc.ChainID, err)
+	}
+	return path, len(archive.Blocks), nil
+}
+
+// IsArchived reports whether a chain is currently held in cold storage
+func IsArchived(chainID string) bool {
+	chainsLock.RLock()
+	defer chainsLock.RUnlock()
+	_, ok := archivedChains[chainID]
+	return ok
+}
+
+// restoreChain loads an archived chain back into memory. Callers must hold chainsLock.
+func restoreChain(chainID string) (*Blockchain, error) {
+	stub, ok := archivedChains[chainID]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(stub.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive for chain %s: %v", chainID, err)
+	}
+
+	var archive ChainArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("failed to decode archive for chain %s: %v", chainID, err)
+	}
+
+	bc := &Blockchain{
+		Blocks:  archive.Blocks,
+		Mempool: stub.mempool,
+		ChainID: archive.ChainID,
+		Nodes:   stub.nodes,
+		Config:  archive.Config,
+
+		bootstrapAddr: stub.bootstrapAddr,
+		nonces:        archive.Nonces,
+	}
+	if bc.Nodes == nil {
+		bc.Nodes = make(map[string]*p2p.Node)
+	}
+	if n := len(bc.Blocks); n > 0 {
+		bc.latestHash = bc.Blocks[n-1].Hash()
+	}
+
+	chains[chainID] = bc
+	delete(archivedChains, chainID)
+
+	log.Printf("Restored chain %s (%d blocks) from %s", chainID, len(bc.Blocks), stub.path)
+	return bc, nil
+}
+
+// StartAutoArchive periodically archives chains that haven't produced a block within idle
+func StartAutoArchive(idle time.Duration) {
+	if idle <= 0 {
+		return
+	}
+
+	interval := idle / 4
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			for _, chainID := range idleChains(idle) {
+				if _, err := ArchiveChain(chainID); err != nil {
+					log.Printf("Auto-archive of chain %s failed: %v", chainID, err)
+				}
+			}
+		}
+	}()
+	log.Printf("Auto-archiving chains idle for more than %s", idle)
+}
+
+// idleChains returns the chains whose latest block is older than idle and that aren't busy
+func idleChains(idle time.Duration) []string {
+	cutoff := time.Now().Add(-idle).Unix()
+	var candidates []*Blockchain
+	chainsLock.RLock()
+	for _, bc := range chains {
+		if latest, ok := bc.LatestBlock(); ok && latest.Timestamp < cutoff {
+			candidates = append(candidates, bc)
+		}
+	}
+	chainsLock.RUnlock()
+
+	// Checked without chainsLock, since mempools and busy checks may look chains up
+	var ids []string
+	for _, bc := range candidates {
+		if chainBusy(bc) == nil {
+			ids = append(ids, bc.ChainID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestArchiveChainRefusesBusyChains(t *testing.T) {
+	t.Setenv("CHAIN_ARCHIVE_DIR", t.TempDir())
+
+	const chainID = "archive-busy"
+	mp := &staticMempool{txs: []Transaction{{From: "alice", To: "bob", Amount: 1, ChainID: chainID}}}
+	bc := NewBlockchain(chainID, mp)
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, chainID)
+		delete(archivedChains, chainID)
+		chainsLock.Unlock()
+	}()
+
+	if _, err := ArchiveChain(chainID); !errors.Is(err, ErrChainBusy) {
+		t.Fatalf("archived a chain with pending transactions: %v", err)
+	}
+	if IsArchived(chainID) || bc.retired.Load() {
+		t.Fatal("a refused archive left the chain retired")
+	}
+
+	mp.txs = nil
+	inProgress := true
+	AddChainBusyCheck(func(id string) bool { return id == chainID && inProgress })
+	if _, err := ArchiveChain(chainID); !errors.Is(err, ErrChainBusy) {
+		t.Fatalf("archived a chain a busy check holds: %v", err)
+	}
+	for _, id := range idleChains(-time.Hour) { // Every chain is past a cutoff in the future
+		if id == chainID {
+			t.Fatal("busy chain listed as idle")
+		}
+	}
+
+	inProgress = false
+	if _, err := ArchiveChain(chainID); err != nil {
+		t.Fatalf("failed to archive an idle chain: %v", err)
+	}
+
+	// Writes through the Blockchain looked up before archiving fail instead of being lost
+	key, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transaction{From: Address(&key.PublicKey), To: "bob", Amount: 1, Nonce: 1, ChainID: chainID}
+	if err := tx.SignTransaction(key); err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.ProcessTransaction(tx, mp); !errors.Is(err, ErrChainArchived) {
+		t.Fatalf("transaction on an archived Blockchain: %v", err)
+	}
+	block := Block{Height: 1, PrevHash: bc.LatestHash(), ChainID: chainID, TxRoot: TxMerkleRoot(nil)}
+	if err := bc.AddBlock(block); !errors.Is(err, ErrChainArchived) {
+		t.Fatalf("block on an archived Blockchain: %v", err)
+	}
+
+	if _, err := ArchiveChain("archive-missing"); !errors.Is(err, ErrChainNotFound) {
+		t.Fatalf("expected ErrChainNotFound, got %v", err)
+	}
+}
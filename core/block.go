@@ -2,6 +2,9 @@ package core
 
 import (
 	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +16,7 @@ type Block struct {
 	Height    int           `json:"height"`
 	PrevHash  string        `json:"prev_hash"`
 	Txs       []Transaction `json:"transactions"`
+	TxRoot    string        `json:"tx_root,omitempty"` // Merkle root of Txs, see TxMerkleRoot; required on every block above genesis
 	Timestamp int64         `json:"timestamp"`
 	Signature string        `json:"signature"`
 	Proposer  string        `json:"proposer"`
@@ -72,7 +76,9 @@ func (b *Block) VerifyBlock(publicKey string) bool {
 	return crypto.VerifySignature(publicKey, string(blockData), signature)
 }
 
-// Hash returns the block's hash
+// Hash returns the block's hash: the SHA-256 of its JSON encoding, including the TxRoot,
+// timestamp, proposer and signature. It identifies one proposal, e.g. as its discussion
+// thread ID, and changes whenever the same transactions are proposed again. See ContentHash.
 func (b *Block) Hash() string {
 	blockData, err := json.Marshal(b)
 	if err != nil {
@@ -80,3 +86,27 @@ func (b *Block) Hash() string {
 	}
 	return crypto.HashData(string(blockData))
 }
+
+// ContentHash returns a hash of only the block's deterministic content, so re-proposing the
+// same transactions at the same height maps to the same value. It is the hex SHA-256 of
+//
+//	<height>\n<prev_hash>\n<signature of each transaction, sorted, one per line>
+//
+// Timestamp, proposer, the block signature and the order of transactions don't affect it.
+func (b *Block) ContentHash() string {
+	signatures := make([]string, len(b.Txs))
+	for i, tx := range b.Txs {
+		signatures[i] = tx.Signature
+	}
+	sort.Strings(signatures)
+
+	var content strings.Builder
+	content.WriteString(strconv.Itoa(b.Height))
+	content.WriteString("\n")
+	content.WriteString(b.PrevHash)
+	for _, signature := range signatures {
+		content.WriteString("\n")
+		content.WriteString(signature)
+	}
+	return crypto.HashData(content.String())
+}
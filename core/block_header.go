@@ -0,0 +1,128 @@
+package core
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+// HeaderSignatureScheme names the signature over a SignedBlockHeader
+const HeaderSignatureScheme = "ed25519"
+
+// BlockHeader is the part of a block anchored on L1: enough to identify it and commit to its
+// transactions without carrying them
+type BlockHeader struct {
+	ChainID     string `json:"chainId"`
+	Height      int    `json:"height"`
+	PrevHash    string `json:"prevHash"`
+	ContentHash string `json:"contentHash"` // See Block.ContentHash
+	TxRoot      string `json:"txRoot"`      // See Block.TxRoot
+	Proposer    string `json:"proposer"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+// SignedBlockHeader is a header signed by a chain's node, with every field an L1 contract
+// needs to check it. Byte values are 0x-prefixed hex.
+type SignedBlockHeader struct {
+	Header     BlockHeader `json:"header"`
+	Encoded    string      `json:"encoded"`    // The signed bytes, see BlockHeader.Encode
+	HeaderHash string      `json:"headerHash"` // SHA-256 of Encoded
+	Scheme     string      `json:"scheme"`
+	Signer     string      `json:"signer"`
+	PublicKey  string      `json:"publicKey"`
+	Signature  string      `json:"signature"`
+}
+
+// Header extracts the block's header
+func (b *Block) Header() BlockHeader {
+	txRoot := b.TxRoot
+	if txRoot == "" {
+		txRoot = TxMerkleRoot(b.Txs)
+	}
+	return BlockHeader{
+		ChainID:     b.ChainID,
+		Height:      b.Height,
+		PrevHash:    b.PrevHash,
+		ContentHash: b.ContentHash(),
+		TxRoot:      txRoot,
+		Proposer:    b.Proposer,
+		Timestamp:   b.Timestamp,
+	}
+}
+
+// Encode packs the header into the bytes that are signed, big-endian throughout:
+//
+//	uint64 height | uint64 timestamp | bytes32 prevHash | bytes32 contentHash | bytes32 txRoot |
+//	uint16 len | chainId | uint16 len | proposer
+//
+// Hashes that aren't 32 bytes of hex, like the genesis block's prevHash, encode as zeros.
+func (h BlockHeader) Encode() []byte {
+	encoded := make([]byte, 0, 8+8+3*32+2+len(h.ChainID)+2+len(h.Proposer))
+	encoded = binary.BigEndian.AppendUint64(encoded, uint64(h.Height))
+	encoded = binary.BigEndian.AppendUint64(encoded, uint64(h.Timestamp))
+	for _, hash := range []string{h.PrevHash, h.ContentHash, h.TxRoot} {
+		word := bytes32(hash)
+		encoded = append(encoded, word[:]...)
+	}
+	for _, text := range []string{h.ChainID, h.Proposer} {
+		encoded = binary.BigEndian.AppendUint16(encoded, uint16(len(text)))
+		encoded = append(encoded, text...)
+	}
+	return encoded
+}
+
+// Sign signs the header with node's signing key (see p2p.Node.SetSigningKey)
+func (h BlockHeader) Sign(node *p2p.Node) (SignedBlockHeader, error) {
+	if len(h.ChainID) > 0xffff || len(h.Proposer) > 0xffff {
+		return SignedBlockHeader{}, errors.New("chain ID or proposer too long to encode")
+	}
+	encoded := h.Encode()
+	signature, err := node.SignPayload(encoded)
+	if err != nil {
+		return SignedBlockHeader{}, err
+	}
+	hash := sha256.Sum256(encoded)
+	return SignedBlockHeader{
+		Header:     h,
+		Encoded:    "0x" + hex.EncodeToString(encoded),
+		HeaderHash: "0x" + hex.EncodeToString(hash[:]),
+		Scheme:     HeaderSignatureScheme,
+		Signer:     signature.Signer,
+		PublicKey:  "0x" + signature.PublicKey,
+		Signature:  "0x" + signature.Signature,
+	}, nil
+}
+
+// Verify reports whether the signature covers the header under the included public key. It
+// doesn't say whether that key is trusted.
+func (s SignedBlockHeader) Verify() bool {
+	public, err := hex.DecodeString(trimHexPrefix(s.PublicKey))
+	if err != nil || len(public) != ed25519.PublicKeySize {
+		return false
+	}
+	signature, err := hex.DecodeString(trimHexPrefix(s.Signature))
+	if err != nil {
+		return false
+	}
+	encoded := s.Header.Encode()
+	return "0x"+hex.EncodeToString(encoded) == s.Encoded && ed25519.Verify(public, encoded, signature)
+}
+
+func bytes32(hash string) [32]byte {
+	var word [32]byte
+	if decoded, err := hex.DecodeString(trimHexPrefix(hash)); err == nil && len(decoded) == len(word) {
+		copy(word[:], decoded)
+	}
+	return word
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
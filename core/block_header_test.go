@@ -0,0 +1,69 @@
+package core
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+func TestSignedBlockHeader(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := p2p.NewNode(p2p.ChainConfig{ChainID: "header-chain"})
+	block := Block{
+		ChainID:   "header-chain",
+		Height:    3,
+		PrevHash:  strings.Repeat("ab", 32),
+		Txs:       []Transaction{{Signature: "s1"}, {Signature: "s2"}},
+		Timestamp: 1740830400,
+		Proposer:  "Ada",
+	}
+
+	if _, err := block.Header().Sign(node); !errors.Is(err, p2p.ErrNoSigningKey) {
+		t.Fatalf("expected ErrNoSigningKey without a key, got %v", err)
+	}
+
+	if err := node.SetSigningKey("bootstrap", hex.EncodeToString(private)); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := block.Header().Sign(node)
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	if !signed.Verify() {
+		t.Fatal("signed header does not verify")
+	}
+	if signed.Header.TxRoot != TxMerkleRoot(block.Txs) || signed.Header.ContentHash != block.ContentHash() {
+		t.Fatalf("header doesn't commit to the block's content: %+v", signed.Header)
+	}
+	if !strings.Contains(signed.Encoded, strings.Repeat("ab", 32)) {
+		t.Fatalf("encoded header is missing the previous hash: %s", signed.Encoded)
+	}
+
+	tampered := signed
+	tampered.Header.Height = 4
+	if tampered.Verify() {
+		t.Fatal("header with a changed height still verifies")
+	}
+}
+
+func TestHeaderEncodingIsFixedLayout(t *testing.T) {
+	header := BlockHeader{ChainID: "c", Height: 1, PrevHash: "0", Proposer: "p", Timestamp: 2}
+	encoded := header.Encode()
+	if len(encoded) != 8+8+3*32+2+1+2+1 {
+		t.Fatalf("unexpected encoded length %d", len(encoded))
+	}
+	// The genesis block's "0" previous hash encodes as zeros
+	for _, b := range encoded[16:48] {
+		if b != 0 {
+			t.Fatalf("expected a zero prevHash word, got %x", encoded[16:48])
+		}
+	}
+}
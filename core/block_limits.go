@@ -0,0 +1,49 @@
+package core
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// DefaultMaxBlockBytes matches the block size CometBFT accepts by default
+const DefaultMaxBlockBytes = 22020096
+
+// selectBlockTxs picks the pending transactions that fit within the chain's block limits,
+// highest fee first and oldest first among equal fees. A transaction too big for the bytes
+// left is skipped in favor of smaller ones behind it. Transactions that don't fit stay in
+// the mempool for a later block; their count is returned as deferred.
+func selectBlockTxs(pending []Transaction, config ChainConfig) (selected []Transaction, deferred int) {
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].Fee != pending[j].Fee {
+			return pending[i].Fee > pending[j].Fee
+		}
+		return pending[i].Timestamp < pending[j].Timestamp
+	})
+
+	bytes := 0
+	for _, tx := range pending {
+		if config.MaxTxsPerBlock > 0 && len(selected) >= config.MaxTxsPerBlock {
+			deferred++
+			continue
+		}
+		if config.MaxBlockBytes > 0 {
+			size := encodedTxSize(tx)
+			if bytes+size > config.MaxBlockBytes {
+				deferred++
+				continue
+			}
+			bytes += size
+		}
+		selected = append(selected, tx)
+	}
+	return selected, deferred
+}
+
+// encodedTxSize is the JSON encoded size a transaction counts against MaxBlockBytes
+func encodedTxSize(tx Transaction) int {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
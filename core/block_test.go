@@ -0,0 +1,26 @@
+package core
+
+import "testing"
+
+func TestContentHashIgnoresProposalDetails(t *testing.T) {
+	txs := []Transaction{{From: "alice", Signature: "aa"}, {From: "bob", Signature: "bb"}}
+	first := Block{Height: 4, PrevHash: "prev", Txs: txs, Timestamp: 1740830400, Proposer: "Ada", Signature: "s1"}
+	again := Block{Height: 4, PrevHash: "prev", Txs: []Transaction{txs[1], txs[0]}, Timestamp: 1740830999, Proposer: "Bob", Signature: "s2"}
+
+	if first.Hash() == again.Hash() {
+		t.Fatal("expected the full hash to tell the two proposals apart")
+	}
+	if first.ContentHash() != again.ContentHash() {
+		t.Fatal("content hash changed with timestamp, proposer, signature or transaction order")
+	}
+
+	for name, changed := range map[string]Block{
+		"height":       {Height: 5, PrevHash: "prev", Txs: txs},
+		"previous":     {Height: 4, PrevHash: "other", Txs: txs},
+		"transactions": {Height: 4, PrevHash: "prev", Txs: txs[:1]},
+	} {
+		if changed.ContentHash() == first.ContentHash() {
+			t.Errorf("content hash ignores a different %s", name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+// startTestNode starts a P2P node for chainID on a free port, stopped when the test ends
+func startTestNode(t *testing.T, chainID string) *p2p.Node {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	node := p2p.NewNode(p2p.ChainConfig{ChainID: chainID, P2PPort: port})
+	node.StartServer(port)
+	t.Cleanup(node.Stop)
+	return node
+}
+
+// startChainNetwork gives a chain a bootstrap node with one connected peer, returning the peer
+func startChainNetwork(t *testing.T, bc *Blockchain) *p2p.Node {
+	bootstrap, peer := startTestNode(t, bc.ChainID), startTestNode(t, bc.ChainID)
+	bc.RegisterNode(fmt.Sprintf("localhost:%d", bootstrap.GetPort()), bootstrap)
+	peer.ConnectToPeer(fmt.Sprintf("localhost:%d", bootstrap.GetPort()))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for bootstrap.GetPeerCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("peer did not connect to chain %s", bc.ChainID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return peer
+}
+
+func TestBroadcastStaysOnItsChain(t *testing.T) {
+	chainA := NewBlockchain("broadcast-a", &staticMempool{})
+	chainB := NewBlockchain("broadcast-b", &staticMempool{})
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, chainA.ChainID)
+		delete(chains, chainB.ChainID)
+		chainsLock.Unlock()
+	}()
+
+	var receivedA, receivedB atomic.Int32
+	startChainNetwork(t, chainA).Subscribe("WORK_REVIEW", func([]byte) { receivedA.Add(1) })
+	startChainNetwork(t, chainB).Subscribe("WORK_REVIEW", func([]byte) { receivedB.Add(1) })
+
+	if !BroadcastToChain(chainA.ChainID, p2p.Message{Type: "WORK_REVIEW", Data: "for chain A"}) {
+		t.Fatal("broadcast on chain A found no node")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for receivedA.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("chain A's peer did not receive the broadcast")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := receivedB.Load(); got != 0 {
+		t.Fatalf("chain B's peer received chain A's broadcast %d times", got)
+	}
+
+	if BroadcastToChain("broadcast-missing", p2p.Message{Type: "WORK_REVIEW"}) {
+		t.Fatal("broadcast on an unknown chain reported success")
+	}
+}
+
+func TestGetHealthyBootstrapNode(t *testing.T) {
+	bc := NewBlockchain("healthy-bootstrap", &staticMempool{})
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, bc.ChainID)
+		chainsLock.Unlock()
+	}()
+	if _, err := bc.GetHealthyBootstrapNode(); !errors.Is(err, ErrNoHealthyNode) {
+		t.Fatalf("expected ErrNoHealthyNode without nodes, got %v", err)
+	}
+
+	bootstrap := startTestNode(t, bc.ChainID)
+	bc.RegisterNode("localhost:1", bootstrap)
+	bc.RegisterNode("localhost:2", p2p.NewNode(p2p.ChainConfig{ChainID: bc.ChainID})) // Never started
+	agent := startTestNode(t, bc.ChainID)
+	bc.RegisterNode("localhost:3", agent)
+
+	if node, err := bc.GetHealthyBootstrapNode(); err != nil || node != bootstrap {
+		t.Fatalf("expected the bootstrap node, got %v (%v)", node, err)
+	}
+	bootstrap.Stop()
+	if node, err := bc.GetHealthyBootstrapNode(); err != nil || node != agent {
+		t.Fatalf("expected the listening agent node once the bootstrap stopped, got %v (%v)", node, err)
+	}
+	agent.Stop()
+	if _, err := bc.GetHealthyBootstrapNode(); !errors.Is(err, ErrNoHealthyNode) {
+		t.Fatalf("expected ErrNoHealthyNode once every node stopped, got %v", err)
+	}
+}
@@ -14,7 +14,7 @@ var natsServer *server.Server
 func SetupNATS(natsURL string) {
 	var err error
 	// Try connecting first
-	NatsBrokerInstance, err = nats.Connect(natsURL)
+	NatsBrokerInstance, err = nats.Connect(natsURL, natsOptions(globalNATSOutbox, true)...)
 	if err != nil {
 		log.Printf("Could not connect to NATS at %s, starting embedded server...", natsURL)
 
@@ -36,11 +36,14 @@ func SetupNATS(natsURL string) {
 		log.Println("Started embedded NATS server on port 4222")
 
 		// Try connecting again
-		NatsBrokerInstance, err = nats.Connect("nats://localhost:4222")
+		NatsBrokerInstance, err = nats.Connect("nats://localhost:4222", natsOptions(globalNATSOutbox, true)...)
 		if err != nil {
 			log.Fatalf("Failed to connect to embedded NATS: %v", err)
 		}
 	}
+	natsHealthMu.Lock()
+	natsStatusSince = time.Now()
+	natsHealthMu.Unlock()
 	log.Printf("Connected to NATS at %s", natsURL)
 }
 
@@ -55,18 +58,19 @@ func CloseNATS() {
 
 // NATSBroker encapsulates a NATS connection.
 type NATSBroker struct {
-	Conn *nats.Conn
+	Conn   *nats.Conn
+	outbox *natsOutbox
 }
 
 // NewNATSBroker creates a new NATSBroker connected to the provided URL.
 func NewNATSBroker(url string) (*NATSBroker, error) {
-	nc, err := nats.Connect(url,
-		nats.Timeout(10*time.Second),
-	)
+	outbox := &natsOutbox{}
+	options := append(natsOptions(outbox, false), nats.Timeout(10*time.Second))
+	nc, err := nats.Connect(url, options...)
 	if err != nil {
 		return nil, err
 	}
-	return &NATSBroker{Conn: nc}, nil
+	return &NATSBroker{Conn: nc, outbox: outbox}, nil
 }
 
 // Publish sends data on the provided subject.
@@ -75,6 +79,12 @@ func (b *NATSBroker) Publish(subject string, data []byte) error {
 	return b.Conn.Publish(subject, data)
 }
 
+// PublishCritical sends data on the provided subject, re-publishing it after a reconnect if
+// the connection is down.
+func (b *NATSBroker) PublishCritical(subject string, data []byte) error {
+	return b.outbox.publish(b.Conn, subject, data)
+}
+
 // Subscribe registers a callback for a specific subject.
 func (b *NATSBroker) Subscribe(subject string, cb nats.MsgHandler) error {
 	_, err := b.Conn.Subscribe(subject, cb)
@@ -2,9 +2,12 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/NethermindEth/chaoschain-launchpad/p2p"
@@ -15,29 +18,52 @@ var chainsLock sync.RWMutex
 
 // Blockchain represents a sequence of validated blocks
 type Blockchain struct {
-	Blocks  []Block
-	Mempool MempoolInterface
-	ChainID string
-	Nodes   map[string]*p2p.Node
-	NodesMu sync.RWMutex
+	Blocks   []Block
+	blocksMu sync.RWMutex // Guards Blocks and latestHash; read them through Height, LatestBlock, LatestHash and BlockAt
+	Mempool  MempoolInterface
+	ChainID  string
+	Nodes    map[string]*p2p.Node
+	NodesMu  sync.RWMutex
+	Config   ChainConfig
+
+	latestHash    string // Hash of the block at the tip
+	bootstrapAddr string // First node registered, guarded by NodesMu
+
+	nonces   map[string]uint64 // Sender address -> highest accepted nonce
+	noncesMu sync.Mutex
+
+	retired atomic.Bool // Set once ArchiveChain has copied the chain; changed holding blocksMu and noncesMu
 }
 
-// NewBlockchain initializes a blockchain with a genesis block
+// NewBlockchain initializes a blockchain with a genesis block, or with the blocks
+// finalized and nonces accepted on the chain before a restart when local storage holds them
 func NewBlockchain(chainID string, mp MempoolInterface) *Blockchain {
-	genesisBlock := Block{
-		Height:    0,
-		PrevHash:  "0",
-		Txs:       []Transaction{},
-		Timestamp: time.Now().Unix(),
-		Signature: "genesis-signature",
-		ChainID:   chainID,
+	blocks := persistedBlocks(chainID)
+	if len(blocks) > 0 {
+		log.Printf("Restored %d blocks for chain %s from local storage", len(blocks), chainID)
+	} else {
+		genesisBlock := Block{
+			Height:    0,
+			PrevHash:  "0",
+			Txs:       []Transaction{},
+			Timestamp: time.Now().Unix(),
+			Signature: "genesis-signature",
+			ChainID:   chainID,
+		}
+		if err := persistBlock(genesisBlock); err != nil {
+			log.Printf("Failed to persist genesis block for chain %s: %v", chainID, err)
+		}
+		blocks = []Block{genesisBlock}
 	}
 
 	bc := &Blockchain{
-		Blocks:  []Block{genesisBlock},
-		Mempool: mp,
-		ChainID: chainID,
-		Nodes:   make(map[string]*p2p.Node),
+		Blocks:     blocks,
+		latestHash: blocks[len(blocks)-1].Hash(),
+		Mempool:    mp,
+		ChainID:    chainID,
+		Nodes:      make(map[string]*p2p.Node),
+		Config:     DefaultChainConfig(),
+		nonces:     persistedNonces(chainID),
 	}
 
 	chainsLock.Lock()
@@ -47,8 +73,16 @@ func NewBlockchain(chainID string, mp MempoolInterface) *Blockchain {
 	return bc
 }
 
-// AddBlock appends a new block to the chain
+// AddBlock appends a new block to the chain. The tip check and the append happen under
+// one lock, so of several proposals built on the same tip only the first is accepted.
+// Accepted blocks go through FinalizeBlock, which also persists and announces them.
 func (bc *Blockchain) AddBlock(newBlock Block) error {
+	bc.blocksMu.Lock()
+	defer bc.blocksMu.Unlock()
+
+	if bc.retired.Load() {
+		return fmt.Errorf("cannot add block: %w", ErrChainArchived)
+	}
 	if len(bc.Blocks) == 0 {
 		return fmt.Errorf("cannot add block: blockchain is uninitialized")
 	}
@@ -60,7 +94,10 @@ func (bc *Blockchain) AddBlock(newBlock Block) error {
 
 	lastBlock := bc.Blocks[len(bc.Blocks)-1]
 
-	// Ensure the block links properly
+	// Ensure the block extends the current tip
+	if newBlock.Height != lastBlock.Height+1 {
+		return fmt.Errorf("invalid block: height %d does not follow chain height %d", newBlock.Height, lastBlock.Height)
+	}
 	if newBlock.PrevHash != lastBlock.Hash() {
 		return fmt.Errorf("invalid block: previous hash mismatch")
 	}
@@ -71,41 +108,103 @@ func (bc *Blockchain) AddBlock(newBlock Block) error {
 	}
 
 	bc.Blocks = append(bc.Blocks, newBlock)
+	bc.latestHash = newBlock.Hash()
 	return nil
 }
 
 // ValidateBlock checks whether a given block follows chain rules
 func (bc *Blockchain) ValidateBlock(block Block) bool {
-	// Only validate height and previous hash
+	// Only validate height, previous hash and the transaction root
 	if block.Height <= 0 || block.PrevHash == "" {
 		return false
 	}
+	// Every block above genesis must commit to its transactions, or a peer could gossip
+	// arbitrary ones under an empty root
+	if block.TxRoot == "" || block.TxRoot != TxMerkleRoot(block.Txs) {
+		return false
+	}
 
 	// For now, allow empty blocks and don't check signatures
 	// TODO: Add proper block signing and validation
 	return true
 }
 
-// GetBlockByHeight retrieves a block at a specific height
-func GetBlockByHeight(height int) (Block, bool) {
-	if height < 0 || height >= len(defaultChain.Blocks) {
+// Height returns the height of the latest block, or -1 for an uninitialized chain
+func (bc *Blockchain) Height() int {
+	bc.blocksMu.RLock()
+	defer bc.blocksMu.RUnlock()
+	return len(bc.Blocks) - 1
+}
+
+// LatestBlock returns the block at the tip of the chain
+func (bc *Blockchain) LatestBlock() (Block, bool) {
+	bc.blocksMu.RLock()
+	defer bc.blocksMu.RUnlock()
+	if len(bc.Blocks) == 0 {
 		return Block{}, false
 	}
-	return defaultChain.Blocks[height], true
+	return bc.Blocks[len(bc.Blocks)-1], true
 }
 
-// CreateBlock creates a new block proposal (doesn't add to chain)
-func (bc *Blockchain) CreateBlock() (*Block, error) {
-	if len(bc.Blocks) == 0 {
-		return nil, fmt.Errorf("blockchain not initialized")
+// LatestHash returns the hash of the block at the tip of the chain
+func (bc *Blockchain) LatestHash() string {
+	bc.blocksMu.RLock()
+	defer bc.blocksMu.RUnlock()
+	return bc.latestHash
+}
+
+// BlockAt returns the block at a given height
+func (bc *Blockchain) BlockAt(height int) (Block, bool) {
+	bc.blocksMu.RLock()
+	defer bc.blocksMu.RUnlock()
+	if height < 0 || height >= len(bc.Blocks) {
+		return Block{}, false
 	}
+	return bc.Blocks[height], true
+}
 
-	lastBlock := bc.Blocks[len(bc.Blocks)-1]
+// BlocksSnapshot returns a copy of the chain's blocks
+func (bc *Blockchain) BlocksSnapshot() []Block {
+	bc.blocksMu.RLock()
+	defer bc.blocksMu.RUnlock()
+	blocks := make([]Block, len(bc.Blocks))
+	copy(blocks, bc.Blocks)
+	return blocks
+}
+
+// GetBlockByHeight retrieves a block at a specific height
+func GetBlockByHeight(height int) (Block, bool) {
+	return defaultChain.BlockAt(height)
+}
+
+// CreateBlock creates a new block proposal on top of the current tip (doesn't add to chain).
+// Concurrent proposals may share a height; AddBlock accepts only the first to land.
+// The block holds as many pending transactions as the chain's MaxTxsPerBlock and
+// MaxBlockBytes allow; the number left in the mempool for later blocks is returned.
+func (bc *Blockchain) CreateBlock() (*Block, int, error) {
+	return bc.createBlock(false)
+}
+
+// CreateBlockAllowEmpty is CreateBlock for producers that also propose blocks without
+// transactions
+func (bc *Blockchain) CreateBlockAllowEmpty() (*Block, int, error) {
+	return bc.createBlock(true)
+}
+
+func (bc *Blockchain) createBlock(allowEmpty bool) (*Block, int, error) {
+	lastBlock, ok := bc.LatestBlock()
+	if !ok {
+		return nil, 0, fmt.Errorf("blockchain not initialized")
+	}
 
 	// Get pending transactions from mempool
 	pendingTxs := bc.Mempool.GetPendingTransactions()
-	if len(pendingTxs) == 0 {
-		return nil, fmt.Errorf("no pending transactions")
+	if len(pendingTxs) == 0 && !allowEmpty {
+		return nil, 0, fmt.Errorf("no pending transactions")
+	}
+	pendingTxs, deferred := selectBlockTxs(pendingTxs, bc.Config)
+	if len(pendingTxs) == 0 && !allowEmpty {
+		return nil, deferred, fmt.Errorf("no pending transaction fits within the block size limits")
 	}
 
 	// Create new block
@@ -113,20 +212,75 @@ func (bc *Blockchain) CreateBlock() (*Block, error) {
 		Height:    lastBlock.Height + 1,
 		PrevHash:  lastBlock.Hash(),
 		Txs:       pendingTxs,
+		TxRoot:    TxMerkleRoot(pendingTxs),
 		Timestamp: time.Now().Unix(),
 		Signature: "temp", // TODO: Add proper block signing
 		ChainID:   bc.ChainID,
 	}
 
-	return newBlock, nil
+	return newBlock, deferred, nil
+}
+
+// ErrStaleNonce is returned for a transaction whose nonce isn't greater than the last one
+// accepted from its sender
+var ErrStaleNonce = errors.New("nonce must be greater than the sender's last accepted nonce")
+
+// AccountNonce returns the highest nonce accepted from an address, 0 if none. The next
+// transaction from the address must use a greater one.
+func (bc *Blockchain) AccountNonce(address string) uint64 {
+	bc.noncesMu.Lock()
+	defer bc.noncesMu.Unlock()
+	return bc.nonces[address]
+}
+
+// RevertNonce rolls the highest accepted nonce of an address back from current to previous,
+// for a transaction withdrawn right after it was accepted. It does nothing, and reports
+// false, if another transaction from the address has been accepted since.
+func (bc *Blockchain) RevertNonce(address string, current, previous uint64) bool {
+	bc.noncesMu.Lock()
+	defer bc.noncesMu.Unlock()
+	if bc.nonces == nil || bc.nonces[address] != current {
+		return false
+	}
+	bc.setNonceLocked(address, previous)
+	return true
+}
+
+// setNonceLocked records the highest accepted nonce of an address and persists it, so
+// transactions can't be replayed after a restart. Callers hold bc.noncesMu.
+func (bc *Blockchain) setNonceLocked(address string, nonce uint64) {
+	if bc.nonces == nil {
+		bc.nonces = make(map[string]uint64)
+	}
+	bc.nonces[address] = nonce
+	if err := persistNonce(bc.ChainID, address, nonce); err != nil {
+		log.Printf("Failed to persist nonce of %s on chain %s: %v", address, bc.ChainID, err)
+	}
+}
+
+// nonceSnapshot copies the accepted nonces
+func (bc *Blockchain) nonceSnapshot() map[string]uint64 {
+	bc.noncesMu.Lock()
+	defer bc.noncesMu.Unlock()
+	nonces := make(map[string]uint64, len(bc.nonces))
+	for address, nonce := range bc.nonces {
+		nonces[address] = nonce
+	}
+	return nonces
 }
 
 // ProcessTransaction validates and adds a transaction to the mempool
 func (bc *Blockchain) ProcessTransaction(tx Transaction, mp MempoolInterface) error {
 
-	// Validate transaction
-	if !tx.VerifyTransaction(tx.From) {
-		return fmt.Errorf("invalid transaction signature")
+	// Validate transaction. Only chains allowing unsigned transactions take ones whose key
+	// isn't bound to the sender, which is what the API signs legacy submissions with.
+	if err := VerifySignature(tx); err != nil {
+		return fmt.Errorf("invalid transaction signature: %w", err)
+	}
+	senderErr := VerifyTransaction(tx)
+	bound := senderErr == nil
+	if !bound && !bc.Config.AllowUnsigned {
+		return senderErr
 	}
 
 	// Verify chainID matches
@@ -137,13 +291,13 @@ func (bc *Blockchain) ProcessTransaction(tx Transaction, mp MempoolInterface) er
 	// Store the mempool reference
 	bc.Mempool = mp
 
-	if !mp.AddTransaction(tx) {
-		return fmt.Errorf("failed to add transaction to mempool")
+	if err := bc.admitTransaction(tx, mp, bound); err != nil {
+		return err
 	}
 
 	// Broadcast transaction
 	txData, _ := json.Marshal(tx)
-	p2p.GetP2PNode().BroadcastMessage(p2p.Message{
+	bc.Broadcast(p2p.Message{
 		Type: "TRANSACTION",
 		Data: string(txData),
 	})
@@ -151,6 +305,39 @@ func (bc *Blockchain) ProcessTransaction(tx Transaction, mp MempoolInterface) er
 	return nil
 }
 
+// admitTransaction adds a transaction to the mempool, rejecting replays. The nonce is
+// recorded only once the mempool takes the transaction. Transactions not signed by the
+// sender's key don't touch its nonce, or anyone could block the sender by submitting a
+// huge one in its name.
+func (bc *Blockchain) admitTransaction(tx Transaction, mp MempoolInterface, bound bool) error {
+	bc.noncesMu.Lock()
+	defer bc.noncesMu.Unlock()
+
+	if bc.retired.Load() {
+		return fmt.Errorf("cannot accept transaction: %w", ErrChainArchived)
+	}
+	if last := bc.nonces[tx.From]; bound && tx.Nonce <= last {
+		return fmt.Errorf("%w: got %d, last accepted %d", ErrStaleNonce, tx.Nonce, last)
+	}
+	if err := mp.AddTransaction(tx); err != nil {
+		return fmt.Errorf("failed to add transaction to mempool: %w", err)
+	}
+	if bound {
+		bc.setNonceLocked(tx.From, tx.Nonce)
+	}
+	return nil
+}
+
+// setRetired marks the chain as archived, or back as live if archiving it failed. Taking
+// both locks means no block or transaction write is half done when it changes.
+func (bc *Blockchain) setRetired(retired bool) {
+	bc.blocksMu.Lock()
+	defer bc.blocksMu.Unlock()
+	bc.noncesMu.Lock()
+	defer bc.noncesMu.Unlock()
+	bc.retired.Store(retired)
+}
+
 var defaultChain *Blockchain
 
 // Initialize blockchain
@@ -172,16 +359,35 @@ func GetBlockchain() *Blockchain {
 // Add GetChain helper
 func GetChain(chainID string) *Blockchain {
 	chainsLock.RLock()
-	defer chainsLock.RUnlock()
 	log.Println("All the chains are: ", chains)
-	return chains[chainID]
+	bc := chains[chainID]
+	_, isArchived := archivedChains[chainID]
+	chainsLock.RUnlock()
+
+	if bc != nil || !isArchived {
+		return bc
+	}
+
+	// Lazily restore archived chains on first access
+	chainsLock.Lock()
+	defer chainsLock.Unlock()
+	if bc := chains[chainID]; bc != nil {
+		return bc
+	}
+	bc, err := restoreChain(chainID)
+	if err != nil {
+		log.Printf("Failed to restore archived chain %s: %v", chainID, err)
+		return nil
+	}
+	return bc
 }
 
 type ChainInfo struct {
-	ChainID string `json:"chain_id"`
-	Name    string `json:"name"`
-	Agents  int    `json:"agents"`
-	Blocks  int    `json:"blocks"`
+	ChainID  string `json:"chain_id"`
+	Name     string `json:"name"`
+	Agents   int    `json:"agents"`
+	Blocks   int    `json:"blocks"`
+	Archived bool   `json:"archived,omitempty"`
 }
 
 // GetAllChains returns a list of all chain IDs
@@ -195,15 +401,101 @@ func GetAllChains() []ChainInfo {
 			ChainID: id,
 			Name:    id,                   // Using chainID as name for now
 			Agents:  len(chain.Nodes) - 1, // Subtract 1 to exclude bootstrap node
-			Blocks:  len(chain.Blocks),
+			Blocks:  chain.Height() + 1,
+		})
+	}
+	for id, stub := range archivedChains {
+		chainInfos = append(chainInfos, ChainInfo{
+			ChainID:  id,
+			Name:     id,
+			Agents:   len(stub.nodes) - 1,
+			Blocks:   stub.blocks,
+			Archived: true,
 		})
 	}
 	return chainInfos
 }
 
+// BootstrapNode returns the chain's first registered node, which other nodes join through
+func (bc *Blockchain) BootstrapNode() *p2p.Node {
+	bc.NodesMu.RLock()
+	defer bc.NodesMu.RUnlock()
+	return bc.Nodes[bc.bootstrapAddr]
+}
+
+// ErrNoHealthyNode is returned when none of a chain's nodes is accepting connections
+var ErrNoHealthyNode = errors.New("chain has no P2P node accepting connections")
+
+// GetHealthyBootstrapNode returns a node new agents can bootstrap from: the chain's bootstrap
+// node if it is listening, or else the listening node with the lowest address, so repeated
+// calls pick the same one.
+func (bc *Blockchain) GetHealthyBootstrapNode() (*p2p.Node, error) {
+	bc.NodesMu.RLock()
+	defer bc.NodesMu.RUnlock()
+	if node := bc.Nodes[bc.bootstrapAddr]; node != nil && node.Listening() {
+		return node, nil
+	}
+
+	addrs := make([]string, 0, len(bc.Nodes))
+	for addr := range bc.Nodes {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		if node := bc.Nodes[addr]; node != nil && node.Listening() {
+			return node, nil
+		}
+	}
+	return nil, ErrNoHealthyNode
+}
+
+// Broadcast sends msg to the chain's peers through its bootstrap node, so it never reaches
+// another chain's network. It reports false, dropping msg, if the chain has no node yet.
+func (bc *Blockchain) Broadcast(msg p2p.Message) bool {
+	node := bc.BootstrapNode()
+	if node == nil {
+		log.Printf("Chain %s has no P2P node, not broadcasting %s", bc.ChainID, msg.Type)
+		return false
+	}
+	node.BroadcastMessage(msg)
+	return true
+}
+
+// BroadcastToChain sends msg on the network of chainID, see Blockchain.Broadcast
+func BroadcastToChain(chainID string, msg p2p.Message) bool {
+	bc := GetChain(chainID)
+	if bc == nil {
+		log.Printf("Chain %s not found, not broadcasting %s", chainID, msg.Type)
+		return false
+	}
+	return bc.Broadcast(msg)
+}
+
+// UnregisterNode removes a node from the chain, returning the address it was registered
+// under. The bootstrap node can't be removed.
+func (bc *Blockchain) UnregisterNode(node *p2p.Node) (string, bool) {
+	bc.NodesMu.Lock()
+	defer bc.NodesMu.Unlock()
+	for addr, n := range bc.Nodes {
+		if n == node && addr != bc.bootstrapAddr {
+			delete(bc.Nodes, addr)
+			return addr, true
+		}
+	}
+	return "", false
+}
+
 // RegisterNode adds a node to the chain's network
 func (bc *Blockchain) RegisterNode(addr string, node *p2p.Node) {
 	bc.NodesMu.Lock()
-	defer bc.NodesMu.Unlock()
+	_, known := bc.Nodes[addr]
 	bc.Nodes[addr] = node
+	if bc.bootstrapAddr == "" {
+		bc.bootstrapAddr = addr
+	}
+	bc.NodesMu.Unlock()
+
+	if !known && node != nil {
+		subscribeBlockGossip(bc.ChainID, node)
+	}
 }
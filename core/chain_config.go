@@ -0,0 +1,286 @@
+package core
+
+import "time"
+
+// LLM call types whose response length can be tuned per chain
+const (
+	LLMCallDiscussion = "discussion" // A validator's contribution to a discussion round
+	LLMCallVote       = "vote"       // A validator's final vote on a block
+)
+
+// LLMCallTypes lists every call type accepted in ChainConfig.MaxTokens
+var LLMCallTypes = []string{LLMCallDiscussion, LLMCallVote}
+
+// LLMModels lists every model accepted in ChainConfig.LLMModel
+var LLMModels = []string{
+	"gpt-3.5-turbo",
+	"gpt-4",
+	"gpt-4-turbo",
+	"gpt-4o",
+	"gpt-4o-mini",
+	"gpt-4.1",
+	"gpt-4.1-mini",
+	"gpt-4.1-nano",
+}
+
+// MaxLLMTemperature is the highest ChainConfig.LLMTemperature the provider accepts
+const MaxLLMTemperature = 2.0
+
+// How strictly validator mentions in discussions are matched to known validators
+const (
+	NameResolutionStrict   = "strict"   // Only exact |@Name| mentions
+	NameResolutionTolerant = "tolerant" // Case-insensitive, pipes optional
+	NameResolutionFuzzy    = "fuzzy"    // Tolerant, plus closest known name within a small edit distance
+)
+
+// NameResolutionModes lists every accepted ChainConfig.NameResolution value
+var NameResolutionModes = []string{NameResolutionStrict, NameResolutionTolerant, NameResolutionFuzzy}
+
+// Bounds on ChainConfig.DiscussionRounds
+const (
+	DefaultDiscussionRounds = 5
+	MaxDiscussionRounds     = 20
+)
+
+// What happens when a validator already has MaxInfluences influences
+const (
+	InfluenceOverflowReject      = "reject"       // Refuse the new influence
+	InfluenceOverflowEvictOldest = "evict_oldest" // Drop the oldest influence to make room
+)
+
+// What happens to submitted content longer than MaxContentLength
+const (
+	ContentOverflowReject    = "reject"    // Refuse the submission
+	ContentOverflowSummarize = "summarize" // Replace the content with an LLM summary within the limit
+)
+
+// Bounds on ChainConfig.ResearchWeightBonus. At the maximum a research-backed vote counts twice.
+const (
+	DefaultResearchWeightBonus = 0.25
+	MaxResearchWeightBonus     = 1.0
+)
+
+// DefaultMinValidators is how many voting validators a chain needs before blocks are proposed
+const DefaultMinValidators = 2
+
+// DefaultAcceptanceThreshold is the share of weighted support a block needs to be accepted
+const DefaultAcceptanceThreshold = 0.5
+
+// DefaultRelationshipDecayRate is the share of each relationship score that fades per hour
+const DefaultRelationshipDecayRate = 0.05
+
+// How long a block proposal waits for consensus beyond the discussion and voting rounds,
+// unless the chain overrides it, in seconds
+const (
+	DefaultConsensusBufferSeconds       = 5
+	DefaultConsensusSafetyMarginSeconds = 2
+)
+
+// TxTypeTransfer is the transaction type assumed when a transaction doesn't set one
+const TxTypeTransfer = "transfer"
+
+// ChainConfig holds per-chain settings that tune how consensus runs on a chain
+type ChainConfig struct {
+	// ResearchWeighting gives validators whose stance was backed by web
+	// research a bonus on top of their base voting weight.
+	ResearchWeighting   bool    `json:"research_weighting"`
+	ResearchWeightBonus float64 `json:"research_weight_bonus"`
+
+	// ConfidenceWeighting scales each final vote by the confidence (0-1) the validator stated.
+	ConfidenceWeighting bool `json:"confidence_weighting"`
+
+	// MaxTokens caps the LLM response length per call type (see LLMCallTypes).
+	// Call types without an entry use the package default from ai.DefaultLLMConfig.
+	MaxTokens map[string]int `json:"max_tokens"`
+
+	// DiscussionRounds is how many rounds validators discuss a block before the final vote.
+	DiscussionRounds int `json:"discussion_rounds"`
+
+	// NameResolution controls how validator mentions are matched (see NameResolutionModes).
+	NameResolution string `json:"name_resolution"`
+
+	// DevilsAdvocate assigns one voting validator per discussion round, in rotation,
+	// to argue the opposing view so the final vote isn't shaped by groupthink.
+	DevilsAdvocate bool `json:"devils_advocate"`
+
+	// BlockGossip broadcasts accepted blocks as NEW_BLOCK so peers that missed consensus catch up.
+	BlockGossip bool `json:"block_gossip"`
+
+	// CompactOffchainData drops duplicate discussions and votes before they are stored in EigenDA.
+	CompactOffchainData bool `json:"compact_offchain_data"`
+
+	// MaxInfluences bounds each validator's influences, which are interpolated into its prompts.
+	// InfluenceOverflow decides whether additions past the limit are rejected or evict the oldest.
+	MaxInfluences     int    `json:"max_influences"`
+	InfluenceOverflow string `json:"influence_overflow"`
+
+	// MaxContentLength bounds submitted transaction content in characters, since it is
+	// interpolated into every validator's prompt each round; 0 means unlimited.
+	// ContentOverflow decides whether longer content is rejected or summarized.
+	MaxContentLength int    `json:"max_content_length"`
+	ContentOverflow  string `json:"content_overflow"`
+
+	// MaxBlockSeconds and MaxLLMCallsPerBlock cap the wall-clock time and LLM calls spent on one
+	// block, from proposal to verdict. Once either runs out the block is decided on the votes
+	// already cast. 0 means unlimited.
+	MaxBlockSeconds     int `json:"max_block_seconds"`
+	MaxLLMCallsPerBlock int `json:"max_llm_calls_per_block"`
+
+	// AllowUnsigned accepts transactions without a signature, signing them with a throwaway
+	// key as earlier versions did. Such transactions aren't authenticated to their sender.
+	AllowUnsigned bool `json:"allow_unsigned"`
+
+	// TransactionTypes lists the transaction types the mempool accepts. Transactions
+	// without a type count as TxTypeTransfer.
+	TransactionTypes []string `json:"transaction_types"`
+
+	// MinValidators is how many voting validators must be registered before a block can be
+	// proposed, so consensus isn't decided by a single voter. Observers don't count.
+	MinValidators int `json:"min_validators"`
+
+	// AcceptanceThreshold is the share of the weighted final votes that must support a block
+	// for it to be accepted: support / (support + oppose) >= AcceptanceThreshold.
+	AcceptanceThreshold float64 `json:"acceptance_threshold"`
+
+	// MaxTxsPerBlock and MaxBlockBytes cap how many transactions, and how many bytes of JSON
+	// encoded transactions, go into one block. The rest wait in the mempool. 0 means unlimited.
+	MaxTxsPerBlock int `json:"max_txs_per_block"`
+	MaxBlockBytes  int `json:"max_block_bytes"`
+
+	// RelationshipDecayRate is the share of each validator relationship score that fades
+	// toward 0 per hour, so agents that stop interacting drift back to neutral. 0 disables it.
+	RelationshipDecayRate float64 `json:"relationship_decay_rate"`
+
+	// ConsensusTimeoutSeconds is how long a block proposal waits for consensus before it is
+	// cancelled. 0 derives it from the rounds: (rounds+1) round durations plus
+	// ConsensusBufferSeconds and ConsensusSafetyMarginSeconds.
+	ConsensusTimeoutSeconds      int `json:"consensus_timeout"`
+	ConsensusBufferSeconds       int `json:"consensus_buffer"`
+	ConsensusSafetyMarginSeconds int `json:"consensus_safety_margin"`
+
+	// LLMModel and LLMTemperature override the model and sampling temperature of the chain's
+	// validators' LLM calls. Empty and nil keep the package defaults from ai.DefaultLLMConfig.
+	LLMModel       string   `json:"llm_model,omitempty"`
+	LLMTemperature *float64 `json:"llm_temperature,omitempty"`
+
+	// ConsensusStrategy names the strategy that decides blocks, see consensus.ConsensusStrategyNames.
+	// Empty means the default multi-round deliberation.
+	ConsensusStrategy string `json:"consensus_strategy"`
+}
+
+// DefaultChainConfig returns the settings used when a chain doesn't override them
+func DefaultChainConfig() ChainConfig {
+	return ChainConfig{
+		ResearchWeighting:   false,
+		ResearchWeightBonus: DefaultResearchWeightBonus,
+		MaxTokens: map[string]int{
+			LLMCallDiscussion: 1024,
+			LLMCallVote:       512,
+		},
+		DiscussionRounds:    DefaultDiscussionRounds,
+		NameResolution:      NameResolutionFuzzy,
+		BlockGossip:         true,
+		CompactOffchainData: true,
+		MaxInfluences:       10,
+		InfluenceOverflow:   InfluenceOverflowReject,
+		MaxContentLength:    4000,
+		ContentOverflow:     ContentOverflowReject,
+		TransactionTypes:    []string{TxTypeTransfer},
+		MinValidators:       DefaultMinValidators,
+		AcceptanceThreshold: DefaultAcceptanceThreshold,
+		MaxBlockBytes:       DefaultMaxBlockBytes,
+
+		RelationshipDecayRate: DefaultRelationshipDecayRate,
+
+		ConsensusBufferSeconds:       DefaultConsensusBufferSeconds,
+		ConsensusSafetyMarginSeconds: DefaultConsensusSafetyMarginSeconds,
+	}
+}
+
+// Quorum returns the configured minimum number of voting validators, falling back to the
+// default for configs saved before the setting existed
+func (c ChainConfig) Quorum() int {
+	if c.MinValidators <= 0 {
+		return DefaultMinValidators
+	}
+	return c.MinValidators
+}
+
+// Threshold returns the configured acceptance threshold, falling back to the default for
+// configs saved before the setting existed
+func (c ChainConfig) Threshold() float64 {
+	if c.AcceptanceThreshold <= 0 {
+		return DefaultAcceptanceThreshold
+	}
+	return c.AcceptanceThreshold
+}
+
+// ResearchBonus returns the configured research weight bonus, kept within 0 and
+// MaxResearchWeightBonus so a config saved without bounds can't invert the tally
+func (c ChainConfig) ResearchBonus() float64 {
+	return min(max(c.ResearchWeightBonus, 0), MaxResearchWeightBonus)
+}
+
+// Rounds returns the configured number of discussion rounds, falling back to the default
+// for configs saved before the setting existed
+func (c ChainConfig) Rounds() int {
+	if c.DiscussionRounds <= 0 {
+		return DefaultDiscussionRounds
+	}
+	return c.DiscussionRounds
+}
+
+// ConsensusTimeout returns how long a block proposal waits for consensus when each round
+// takes roundDuration. A configured timeout wins; otherwise the derived one is capped by the
+// block's time budget, at which the block is decided anyway. Configs saved before the
+// buffer settings existed use the defaults.
+func (c ChainConfig) ConsensusTimeout(roundDuration time.Duration) time.Duration {
+	if c.ConsensusTimeoutSeconds > 0 {
+		return time.Duration(c.ConsensusTimeoutSeconds) * time.Second
+	}
+	buffer, margin := c.ConsensusBufferSeconds, c.ConsensusSafetyMarginSeconds
+	if buffer <= 0 {
+		buffer = DefaultConsensusBufferSeconds
+	}
+	if margin <= 0 {
+		margin = DefaultConsensusSafetyMarginSeconds
+	}
+	timeout := time.Duration(c.Rounds()+1)*roundDuration + time.Duration(buffer+margin)*time.Second
+	if budget := time.Duration(c.MaxBlockSeconds+margin) * time.Second; c.MaxBlockSeconds > 0 && budget < timeout {
+		timeout = budget
+	}
+	return timeout
+}
+
+// MaxTokensFor returns the configured response length for a call type, or 0 when unset
+func (c ChainConfig) MaxTokensFor(callType string) int {
+	return c.MaxTokens[callType]
+}
+
+// IsKnownLLMModel reports whether model is one of LLMModels
+func IsKnownLLMModel(model string) bool {
+	for _, known := range LLMModels {
+		if model == known {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTransactionType reports whether the mempool accepts transactions of txType. Configs
+// saved before the setting existed allow only transfers.
+func (c ChainConfig) AllowsTransactionType(txType string) bool {
+	if txType == "" {
+		txType = TxTypeTransfer
+	}
+	types := c.TransactionTypes
+	if len(types) == 0 {
+		types = []string{TxTypeTransfer}
+	}
+	for _, allowed := range types {
+		if txType == allowed {
+			return true
+		}
+	}
+	return false
+}
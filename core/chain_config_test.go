@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsensusTimeout(t *testing.T) {
+	round := 10 * time.Second
+	cases := []struct {
+		name   string
+		config func(*ChainConfig)
+		want   time.Duration
+	}{
+		{"derived from rounds", func(c *ChainConfig) {}, 6*round + 7*time.Second},
+		{"custom buffers", func(c *ChainConfig) {
+			c.ConsensusBufferSeconds, c.ConsensusSafetyMarginSeconds = 30, 10
+		}, 6*round + 40*time.Second},
+		{"saved before buffers existed", func(c *ChainConfig) {
+			c.ConsensusBufferSeconds, c.ConsensusSafetyMarginSeconds = 0, 0
+		}, 6*round + 7*time.Second},
+		{"capped by the block budget", func(c *ChainConfig) { c.MaxBlockSeconds = 20 }, 22 * time.Second},
+		{"explicit timeout wins", func(c *ChainConfig) {
+			c.ConsensusTimeoutSeconds, c.MaxBlockSeconds = 300, 20
+		}, 300 * time.Second},
+	}
+	for _, tc := range cases {
+		config := DefaultChainConfig()
+		tc.config(&config)
+		if got := config.ConsensusTimeout(round); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
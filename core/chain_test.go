@@ -0,0 +1,273 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+// staticMempool always returns the same pending transactions
+type staticMempool struct{ txs []Transaction }
+
+func (m *staticMempool) AddTransaction(tx interface{}) error   { return nil }
+func (m *staticMempool) GetPendingTransactions() []Transaction { return m.txs }
+func (m *staticMempool) RemoveTransaction(txID string)         {}
+func (m *staticMempool) CleanupExpiredTransactions()           {}
+func (m *staticMempool) Size() int                             { return len(m.txs) }
+
+func TestConcurrentCreateBlockAssignsEachHeightOnce(t *testing.T) {
+	mp := &staticMempool{txs: []Transaction{{From: "alice", To: "bob", Amount: 1, ChainID: "race-chain"}}}
+	bc := NewBlockchain("race-chain", mp)
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, "race-chain")
+		chainsLock.Unlock()
+	}()
+
+	const producers = 8
+	const rounds = 20
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := make(map[int]int)
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				block, _, err := bc.CreateBlock()
+				if err != nil {
+					t.Errorf("producer %d: CreateBlock failed: %v", p, err)
+					return
+				}
+				block.Signature = fmt.Sprintf("producer-%d-%d", p, r)
+				if err := bc.AddBlock(*block); err == nil {
+					mu.Lock()
+					accepted[block.Height]++
+					mu.Unlock()
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	blocks := bc.BlocksSnapshot()
+	if len(blocks) < 2 {
+		t.Fatalf("expected some blocks to be accepted, chain has %d", len(blocks))
+	}
+	for i, block := range blocks {
+		if block.Height != i {
+			t.Fatalf("block at index %d has height %d", i, block.Height)
+		}
+		if i > 0 && block.PrevHash != blocks[i-1].Hash() {
+			t.Fatalf("block %d does not link to block %d", i, i-1)
+		}
+	}
+	for height, count := range accepted {
+		if count != 1 {
+			t.Errorf("height %d was accepted %d times", height, count)
+		}
+	}
+	if bc.Height() != len(blocks)-1 {
+		t.Errorf("Height() = %d, want %d", bc.Height(), len(blocks)-1)
+	}
+}
+
+func TestProcessTransactionRejectsReplayedNonces(t *testing.T) {
+	bc := NewBlockchain("nonce-chain", &staticMempool{})
+	key, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := Address(&key.PublicKey)
+	submit := func(nonce uint64) error {
+		tx := Transaction{From: alice, To: "bob", Amount: 1, Nonce: nonce, Timestamp: 1740830400, ChainID: "nonce-chain"}
+		if err := tx.SignTransaction(key); err != nil {
+			t.Fatal(err)
+		}
+		return bc.ProcessTransaction(tx, bc.Mempool)
+	}
+
+	if err := submit(0); !errors.Is(err, ErrStaleNonce) {
+		t.Fatalf("nonce 0: expected ErrStaleNonce, got %v", err)
+	}
+	if err := submit(1); err != nil {
+		t.Fatalf("nonce 1: %v", err)
+	}
+	if err := submit(1); !errors.Is(err, ErrStaleNonce) {
+		t.Fatalf("replayed nonce: expected ErrStaleNonce, got %v", err)
+	}
+	if err := submit(5); err != nil {
+		t.Fatalf("nonce 5: %v", err)
+	}
+	if err := submit(3); !errors.Is(err, ErrStaleNonce) {
+		t.Fatalf("lower nonce: expected ErrStaleNonce, got %v", err)
+	}
+	if got := bc.AccountNonce(alice); got != 5 {
+		t.Fatalf("AccountNonce = %d, want 5", got)
+	}
+	if got := bc.AccountNonce("bob"); got != 0 {
+		t.Fatalf("AccountNonce for an unknown sender = %d, want 0", got)
+	}
+}
+
+func TestUnboundTransactionsLeaveNoncesAlone(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDefault(store)
+	defer func() {
+		storage.SetDefault(nil)
+		store.Close()
+	}()
+
+	const chainID = "unbound-nonce-chain"
+	bc := NewBlockchain(chainID, &staticMempool{})
+	bc.Config.AllowUnsigned = true
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, chainID)
+		chainsLock.Unlock()
+	}()
+	victim, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stranger, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := Address(&victim.PublicKey)
+	submit := func(key *ecdsa.PrivateKey, nonce uint64) error {
+		tx := Transaction{From: from, To: "bob", Amount: 1, Nonce: nonce, Timestamp: 1740830400, ChainID: chainID}
+		if err := tx.SignTransaction(key); err != nil {
+			t.Fatal(err)
+		}
+		return bc.ProcessTransaction(tx, bc.Mempool)
+	}
+
+	// Taken as a legacy transaction, but it mustn't lock the victim out
+	if err := submit(stranger, math.MaxUint64); err != nil {
+		t.Fatalf("legacy transaction rejected: %v", err)
+	}
+	if got := bc.AccountNonce(from); got != 0 {
+		t.Fatalf("an unbound transaction moved the sender's nonce to %d", got)
+	}
+	if err := submit(victim, 1); err != nil {
+		t.Fatalf("the sender's own transaction was rejected: %v", err)
+	}
+
+	// Accepted nonces outlive the Blockchain, so a restart can't reopen replays
+	restarted := NewBlockchain(chainID, &staticMempool{})
+	if got := restarted.AccountNonce(from); got != 1 {
+		t.Fatalf("restarted chain has nonce %d, want 1", got)
+	}
+}
+
+func TestRevertNonceOnlyUndoesTheLatestNonce(t *testing.T) {
+	bc := NewBlockchain("revert-chain", &staticMempool{})
+	if bc.RevertNonce("alice", 0, 0) {
+		t.Fatal("reverted a nonce nothing was accepted for")
+	}
+	bc.nonces = map[string]uint64{"alice": 4}
+
+	if bc.RevertNonce("alice", 3, 1) {
+		t.Fatal("reverted a nonce that isn't the latest")
+	}
+	if !bc.RevertNonce("alice", 4, 2) || bc.AccountNonce("alice") != 2 {
+		t.Fatalf("expected alice back at nonce 2, got %d", bc.AccountNonce("alice"))
+	}
+}
+
+func TestFinalizeBlockAppendsPersistsAndNotifies(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDefault(store)
+	defer func() {
+		storage.SetDefault(nil)
+		store.Close()
+	}()
+
+	const chainID = "finality-chain"
+	var finalized []int
+	OnBlockFinalized(func(block Block) {
+		if block.ChainID == chainID {
+			finalized = append(finalized, block.Height)
+		}
+	})
+
+	mp := &staticMempool{txs: []Transaction{{From: "alice", To: "bob", Amount: 1, ChainID: chainID}}}
+	bc := NewBlockchain(chainID, mp)
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, chainID)
+		chainsLock.Unlock()
+	}()
+
+	block, _, err := bc.CreateBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.FinalizeBlock(*block); err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.FinalizeBlock(*block); err == nil {
+		t.Fatal("finalized the same height twice")
+	}
+
+	if bc.Height() != 1 || bc.LatestHash() != block.Hash() {
+		t.Fatalf("tip is height %d hash %s, want 1 and %s", bc.Height(), bc.LatestHash(), block.Hash())
+	}
+	if len(finalized) != 1 || finalized[0] != 1 {
+		t.Fatalf("listener saw %v, want [1]", finalized)
+	}
+
+	// A chain created again after a restart picks up where it left off
+	restored := NewBlockchain(chainID, mp)
+	if restored.Height() != 1 || restored.LatestHash() != block.Hash() {
+		t.Fatalf("restored tip is height %d hash %s, want 1 and %s", restored.Height(), restored.LatestHash(), block.Hash())
+	}
+}
+
+func TestCreateBlockDefersTransactionsPastLimits(t *testing.T) {
+	txs := []Transaction{
+		{From: "a", Fee: 1, Timestamp: 1, Content: "low"},
+		{From: "b", Fee: 5, Timestamp: 2, Content: strings.Repeat("x", 500)},
+		{From: "c", Fee: 3, Timestamp: 3, Content: "mid"},
+		{From: "d", Fee: 3, Timestamp: 1, Content: "mid, older"},
+	}
+
+	selected, deferred := selectBlockTxs(append([]Transaction(nil), txs...), ChainConfig{MaxTxsPerBlock: 2})
+	if deferred != 2 || len(selected) != 2 || selected[0].From != "b" || selected[1].From != "d" {
+		t.Fatalf("by count: selected %v, deferred %d", senders(selected), deferred)
+	}
+
+	// The large transaction doesn't fit, smaller ones behind it still do
+	limit := encodedTxSize(txs[0]) + encodedTxSize(txs[2]) + encodedTxSize(txs[3])
+	selected, deferred = selectBlockTxs(append([]Transaction(nil), txs...), ChainConfig{MaxBlockBytes: limit})
+	if deferred != 1 || len(selected) != 3 || selected[0].From != "d" || selected[2].From != "a" {
+		t.Fatalf("by bytes: selected %v, deferred %d", senders(selected), deferred)
+	}
+
+	selected, deferred = selectBlockTxs(append([]Transaction(nil), txs...), ChainConfig{})
+	if deferred != 0 || len(selected) != 4 {
+		t.Fatalf("unlimited: selected %v, deferred %d", senders(selected), deferred)
+	}
+}
+
+func senders(txs []Transaction) []string {
+	var from []string
+	for _, tx := range txs {
+		from = append(from, tx.From)
+	}
+	return from
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+// Finalized blocks are kept in the node's local storage under block:<chainID>:<height>,
+// with the height zero-padded so iteration returns them in order
+const blockKeyPrefix = "block:"
+
+func blockChainPrefix(chainID string) []byte {
+	return []byte(blockKeyPrefix + chainID + ":")
+}
+
+func blockKey(chainID string, height int) []byte {
+	return append(blockChainPrefix(chainID), fmt.Sprintf("%012d", height)...)
+}
+
+// BlockFinalizedListener is called with every block appended to a chain
+type BlockFinalizedListener func(block Block)
+
+var (
+	finalizedListeners   []BlockFinalizedListener
+	finalizedListenersMu sync.RWMutex
+)
+
+// OnBlockFinalized registers a listener called for every finalized block
+func OnBlockFinalized(listener BlockFinalizedListener) {
+	finalizedListenersMu.Lock()
+	defer finalizedListenersMu.Unlock()
+	finalizedListeners = append(finalizedListeners, listener)
+}
+
+func notifyBlockFinalized(block Block) {
+	finalizedListenersMu.RLock()
+	listeners := make([]BlockFinalizedListener, len(finalizedListeners))
+	copy(listeners, finalizedListeners)
+	finalizedListenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(block)
+	}
+}
+
+// FinalizeBlock makes a block part of the chain once consensus accepts it, or once a peer
+// gossips it: it appends the block, moving the chain's tip, persists it to local storage
+// and notifies OnBlockFinalized listeners. A block that doesn't extend the tip is
+// rejected and nothing else happens.
+func (bc *Blockchain) FinalizeBlock(block Block) error {
+	if err := bc.AddBlock(block); err != nil {
+		return err
+	}
+	if err := persistBlock(block); err != nil {
+		log.Printf("Failed to persist block %d on chain %s: %v", block.Height, bc.ChainID, err)
+	}
+	log.Printf("Finalized block %d on chain %s", block.Height, bc.ChainID)
+	notifyBlockFinalized(block)
+	return nil
+}
+
+// persistBlock writes a block to local storage. Without local storage configured it does
+// nothing.
+func persistBlock(block Block) error {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return store.Set(blockKey(block.ChainID, block.Height), encoded)
+}
+
+// persistedBlocks returns a chain's blocks from local storage, genesis first. It stops at
+// the first gap or unreadable block, so the result always forms a chain.
+func persistedBlocks(chainID string) []Block {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+
+	var blocks []Block
+	errGap := errors.New("gap in persisted blocks")
+	err := store.Iterate(blockChainPrefix(chainID), func(key, value []byte) error {
+		var block Block
+		if err := json.Unmarshal(value, &block); err != nil {
+			return fmt.Errorf("unreadable block %s: %v", key, err)
+		}
+		if block.Height != len(blocks) || (len(blocks) > 0 && block.PrevHash != blocks[len(blocks)-1].Hash()) {
+			return errGap
+		}
+		blocks = append(blocks, block)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Stopped reading persisted blocks for chain %s after %d blocks: %v", chainID, len(blocks), err)
+	}
+	return blocks
+}
+
+// Accepted nonces are kept alongside blocks under nonce:<chainID>:<address>, as decimal text
+const nonceKeyPrefix = "nonce:"
+
+func nonceChainPrefix(chainID string) []byte {
+	return []byte(nonceKeyPrefix + chainID + ":")
+}
+
+// persistNonce writes the highest accepted nonce of an address to local storage. Without
+// local storage configured it does nothing.
+func persistNonce(chainID, address string, nonce uint64) error {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+	return store.Set(append(nonceChainPrefix(chainID), address...), []byte(strconv.FormatUint(nonce, 10)))
+}
+
+// persistedNonces returns a chain's accepted nonces from local storage, skipping unreadable ones
+func persistedNonces(chainID string) map[string]uint64 {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+
+	prefix := nonceChainPrefix(chainID)
+	nonces := make(map[string]uint64)
+	err := store.Iterate(prefix, func(key, value []byte) error {
+		nonce, err := strconv.ParseUint(string(value), 10, 64)
+		if err != nil {
+			log.Printf("Skipping unreadable nonce %s: %v", key, err)
+			return nil
+		}
+		nonces[string(key[len(prefix):])] = nonce
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to read persisted nonces for chain %s: %v", chainID, err)
+	}
+	return nonces
+}
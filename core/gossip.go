@@ -0,0 +1,77 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+// NewBlockMessage is the P2P message type carrying a finalized block
+const NewBlockMessage = "NEW_BLOCK"
+
+// GossipBlock announces a finalized block to the peers of every node on the chain,
+// so nodes that missed consensus can catch up
+func (bc *Blockchain) GossipBlock(block Block) {
+	if !bc.Config.BlockGossip {
+		return
+	}
+
+	bc.NodesMu.RLock()
+	nodes := make([]*p2p.Node, 0, len(bc.Nodes))
+	for _, node := range bc.Nodes {
+		nodes = append(nodes, node)
+	}
+	bc.NodesMu.RUnlock()
+
+	msg := p2p.Message{Type: NewBlockMessage, Data: block}
+	for _, node := range nodes {
+		node.BroadcastMessage(msg)
+	}
+}
+
+// subscribeBlockGossip applies NEW_BLOCK announcements received by a node to the chain. The
+// chain is looked up on every message rather than captured: archiving and restoring a chain
+// replaces its Blockchain but keeps its nodes, and their subscriptions with them.
+func subscribeBlockGossip(chainID string, node *p2p.Node) {
+	node.Subscribe(NewBlockMessage, func(data []byte) {
+		var block Block
+		if err := json.Unmarshal(data, &block); err != nil {
+			log.Printf("Failed to decode gossiped block: %v", err)
+			return
+		}
+		bc := GetChain(chainID)
+		if bc == nil {
+			log.Printf("Ignoring gossiped block %d: chain %s no longer exists", block.Height, chainID)
+			return
+		}
+		if err := bc.ApplyGossipedBlock(block); err != nil {
+			log.Printf("Ignoring gossiped block %d on chain %s: %v", block.Height, chainID, err)
+		}
+	})
+}
+
+// ApplyGossipedBlock appends a block received from a peer if it extends the local chain.
+// Blocks already held are ignored silently; conflicting or out-of-order blocks are rejected.
+func (bc *Blockchain) ApplyGossipedBlock(block Block) error {
+	if block.ChainID != bc.ChainID {
+		return fmt.Errorf("block belongs to chain %s", block.ChainID)
+	}
+
+	if local, ok := bc.BlockAt(block.Height); ok {
+		if local.Hash() == block.Hash() {
+			return nil // Already have it
+		}
+		return fmt.Errorf("conflicts with local block at height %d", block.Height)
+	}
+	if height := bc.Height(); block.Height > height+1 {
+		return fmt.Errorf("local chain is at height %d, missing earlier blocks", height)
+	}
+
+	if err := bc.FinalizeBlock(block); err != nil {
+		return err
+	}
+	log.Printf("Applied gossiped block %d on chain %s", block.Height, bc.ChainID)
+	return nil
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+func TestGossipReachesRestoredChain(t *testing.T) {
+	t.Setenv("CHAIN_ARCHIVE_DIR", t.TempDir())
+
+	const chainID = "gossip-archive"
+	mp := &staticMempool{txs: []Transaction{{From: "alice", To: "bob", Amount: 1, ChainID: chainID}}}
+	archived := NewBlockchain(chainID, mp)
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, chainID)
+		delete(archivedChains, chainID)
+		chainsLock.Unlock()
+	}()
+	peer := startChainNetwork(t, archived)
+
+	block, _, err := archived.CreateBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp.txs = nil // The block took them, leaving the chain idle
+	if _, err := ArchiveChain(chainID); err != nil {
+		t.Fatalf("failed to archive: %v", err)
+	}
+	live := GetChain(chainID)
+	if live == nil || live == archived {
+		t.Fatalf("expected the chain to be restored into a new Blockchain, got %p", live)
+	}
+
+	// The restored chain keeps the bootstrap node, whose NEW_BLOCK subscription predates it
+	peer.BroadcastMessage(p2p.Message{Type: NewBlockMessage, Data: *block})
+	deadline := time.Now().Add(5 * time.Second)
+	for live.Height() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("gossiped block did not reach the restored chain, height is %d", live.Height())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if archived.Height() != 0 {
+		t.Fatalf("gossiped block was applied to the archived Blockchain too, height %d", archived.Height())
+	}
+}
@@ -2,7 +2,7 @@ package core
 
 // MempoolInterface defines the required functionality for a mempool
 type MempoolInterface interface {
-	AddTransaction(tx interface{}) bool
+	AddTransaction(tx interface{}) error
 	GetPendingTransactions() []Transaction
 	RemoveTransaction(txID string)
 	CleanupExpiredTransactions()
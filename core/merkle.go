@@ -0,0 +1,128 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Transactions are committed to with a binary merkle tree over their signatures, in block
+// order. Leaves and inner nodes are hashed with different prefixes so one can't pass for
+// the other:
+//
+//	leaf = SHA-256(0x00 || signature)
+//	node = SHA-256(0x01 || left || right)
+//
+// A node without a sibling moves up a level unchanged. The root of no transactions is the
+// SHA-256 of nothing.
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// MerkleProof shows a transaction is included under a block's TxRoot: the sibling hashes
+// from its leaf up to the root
+type MerkleProof struct {
+	Index int          `json:"index"` // Position of the transaction in the block
+	Steps []MerkleStep `json:"steps"` // Lowest level first; levels where the node has no sibling are skipped
+}
+
+// MerkleStep is one sibling on the path to the root
+type MerkleStep struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"` // Whether the sibling is hashed in on the left
+}
+
+// TxMerkleRoot returns the hex merkle root of txs' signatures
+func TxMerkleRoot(txs []Transaction) string {
+	root, _ := txMerkleTree(txs, -1)
+	return root
+}
+
+// TxInclusionProof returns the merkle proof for the block's transaction at index
+func TxInclusionProof(block *Block, index int) (MerkleProof, error) {
+	if index < 0 || index >= len(block.Txs) {
+		return MerkleProof{}, fmt.Errorf("block %d has no transaction %d", block.Height, index)
+	}
+	_, steps := txMerkleTree(block.Txs, index)
+	return MerkleProof{Index: index, Steps: steps}, nil
+}
+
+// VerifyTxInclusion reports whether proof shows tx is included in block, by hashing tx up to
+// the block's TxRoot. Blocks created before TxRoot existed are checked against the root of
+// their transactions.
+func VerifyTxInclusion(block *Block, tx Transaction, proof MerkleProof) bool {
+	root := block.TxRoot
+	if root == "" {
+		root = TxMerkleRoot(block.Txs)
+	}
+	expected, err := hex.DecodeString(root)
+	if err != nil {
+		return false
+	}
+
+	hash := merkleLeaf(tx)
+	for _, step := range proof.Steps {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return false
+		}
+		if step.Left {
+			hash = merkleNode(sibling, hash)
+		} else {
+			hash = merkleNode(hash, sibling)
+		}
+	}
+	return bytes.Equal(hash, expected)
+}
+
+// txMerkleTree returns the hex root of txs, and the proof steps for the leaf at index if it
+// is not negative
+func txMerkleTree(txs []Transaction, index int) (string, []MerkleStep) {
+	if len(txs) == 0 {
+		empty := sha256.Sum256(nil)
+		return hex.EncodeToString(empty[:]), nil
+	}
+	level := make([][]byte, len(txs))
+	for i, tx := range txs {
+		level[i] = merkleLeaf(tx)
+	}
+
+	var steps []MerkleStep
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			switch index {
+			case i:
+				steps = append(steps, MerkleStep{Hash: hex.EncodeToString(level[i+1])})
+			case i + 1:
+				steps = append(steps, MerkleStep{Hash: hex.EncodeToString(level[i]), Left: true})
+			}
+			next = append(next, merkleNode(level[i], level[i+1]))
+		}
+		level = next
+		if index >= 0 {
+			index /= 2
+		}
+	}
+	return hex.EncodeToString(level[0]), steps
+}
+
+func merkleLeaf(tx Transaction) []byte {
+	sum := sha256.Sum256(append([]byte{merkleLeafPrefix}, tx.Signature...))
+	return sum[:]
+}
+
+func merkleNode(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, merkleNodePrefix)
+	data = append(data, left...)
+	data = append(data, right...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+func merkleTestTxs(n int) []Transaction {
+	txs := make([]Transaction, n)
+	for i := range txs {
+		txs[i] = Transaction{From: "alice", Nonce: uint64(i + 1), Signature: fmt.Sprintf("sig-%d", i)}
+	}
+	return txs
+}
+
+func TestTxInclusionProofs(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 5, 8} {
+		block := &Block{Height: 1, PrevHash: "prev", Txs: merkleTestTxs(n)}
+		block.TxRoot = TxMerkleRoot(block.Txs)
+
+		for i, tx := range block.Txs {
+			proof, err := TxInclusionProof(block, i)
+			if err != nil {
+				t.Fatalf("%d txs: proof for %d: %v", n, i, err)
+			}
+			if !VerifyTxInclusion(block, tx, proof) {
+				t.Errorf("%d txs: proof for transaction %d does not verify", n, i)
+			}
+			if n > 1 && VerifyTxInclusion(block, block.Txs[(i+1)%n], proof) {
+				t.Errorf("%d txs: proof for transaction %d verifies another transaction", n, i)
+			}
+		}
+		if VerifyTxInclusion(block, Transaction{Signature: "forged"}, MerkleProof{}) {
+			t.Errorf("%d txs: a transaction outside the block verifies", n)
+		}
+	}
+}
+
+func TestTxMerkleRoot(t *testing.T) {
+	empty := TxMerkleRoot(nil)
+	if empty != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Fatalf("unexpected root for an empty block: %s", empty)
+	}
+	if _, err := TxInclusionProof(&Block{}, 0); err == nil {
+		t.Fatal("expected no proof for a transaction of an empty block")
+	}
+
+	single := merkleTestTxs(1)
+	proof, _ := TxInclusionProof(&Block{Txs: single}, 0)
+	if len(proof.Steps) != 0 || TxMerkleRoot(single) == empty {
+		t.Fatalf("a single transaction should be its own root, got %d steps", len(proof.Steps))
+	}
+
+	// The root depends on the transactions and their order
+	txs := merkleTestTxs(3)
+	swapped := []Transaction{txs[1], txs[0], txs[2]}
+	if TxMerkleRoot(txs) == TxMerkleRoot(swapped) || TxMerkleRoot(txs) == TxMerkleRoot(txs[:2]) {
+		t.Fatal("root does not commit to the transactions and their order")
+	}
+}
+
+func TestTxRootIsValidatedAndHashed(t *testing.T) {
+	mp := &staticMempool{txs: merkleTestTxs(3)}
+	bc := NewBlockchain("merkle-chain", mp)
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, "merkle-chain")
+		chainsLock.Unlock()
+	}()
+
+	block, _, err := bc.CreateBlock()
+	if err != nil {
+		t.Fatalf("failed to create block: %v", err)
+	}
+	if block.TxRoot != TxMerkleRoot(block.Txs) {
+		t.Fatalf("CreateBlock set TxRoot %q, expected the transactions' root", block.TxRoot)
+	}
+
+	tampered := *block
+	tampered.TxRoot = TxMerkleRoot(block.Txs[:1])
+	if tampered.Hash() == block.Hash() {
+		t.Fatal("block hash ignores the TxRoot")
+	}
+	if bc.ValidateBlock(tampered) {
+		t.Fatal("block with a TxRoot that doesn't match its transactions passed validation")
+	}
+	rootless := *block
+	rootless.TxRoot = ""
+	if bc.ValidateBlock(rootless) {
+		t.Fatal("block without a TxRoot passed validation")
+	}
+	if !bc.ValidateBlock(*block) {
+		t.Fatal("block from CreateBlock failed validation")
+	}
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Connections reconnect forever, NATSReconnectWait apart. nats.go's own reconnect buffer is
+// turned off: publishes made while disconnected fail fast, and the ones sent through
+// PublishCritical are kept in an outbox instead and re-published once the connection is back.
+const (
+	NATSReconnectWait = 2 * time.Second
+	natsOutboxLimit   = 1000 // Critical messages kept while disconnected, oldest dropped first
+)
+
+// NATSStatusListener is called whenever a NATS connection disconnects, reconnects or closes
+type NATSStatusListener func(status nats.Status)
+
+var (
+	natsListenersMu sync.RWMutex
+	natsListeners   []NATSStatusListener
+)
+
+// OnNATSStatus registers a listener for connection status changes of the node's NATS connections
+func OnNATSStatus(listener NATSStatusListener) {
+	natsListenersMu.Lock()
+	defer natsListenersMu.Unlock()
+	natsListeners = append(natsListeners, listener)
+}
+
+func notifyNATSStatus(status nats.Status) {
+	natsListenersMu.RLock()
+	listeners := append([]NATSStatusListener{}, natsListeners...)
+	natsListenersMu.RUnlock()
+	for _, listener := range listeners {
+		listener(status)
+	}
+}
+
+// NATSHealth describes the shared NATS connection, as reported by /ready
+type NATSHealth struct {
+	Status     string    `json:"status"`
+	Connected  bool      `json:"connected"`
+	Since      time.Time `json:"since,omitempty"` // Last status change
+	Reconnects uint64    `json:"reconnects"`
+	Buffered   int       `json:"buffered"` // Critical messages waiting to be re-published
+	Dropped    int       `json:"dropped"`  // Critical messages lost to a full outbox
+}
+
+var (
+	natsHealthMu     sync.RWMutex
+	natsStatusSince  time.Time
+	globalNATSOutbox = &natsOutbox{}
+)
+
+// GetNATSHealth reports the state of NatsBrokerInstance
+func GetNATSHealth() NATSHealth {
+	nc := NatsBrokerInstance
+	if nc == nil {
+		return NATSHealth{Status: "not initialized"}
+	}
+	natsHealthMu.RLock()
+	since := natsStatusSince
+	natsHealthMu.RUnlock()
+	buffered, dropped := globalNATSOutbox.counts()
+	return NATSHealth{
+		Status:     nc.Status().String(),
+		Connected:  nc.IsConnected(),
+		Since:      since,
+		Reconnects: nc.Stats().Reconnects,
+		Buffered:   buffered,
+		Dropped:    dropped,
+	}
+}
+
+// PublishCritical publishes on NatsBrokerInstance, keeping the message for re-publishing after
+// a reconnect if the connection is down. Only a message that can't be kept returns an error.
+func PublishCritical(subject string, data []byte) error {
+	return globalNATSOutbox.publish(NatsBrokerInstance, subject, data)
+}
+
+// natsOptions configures reconnecting for a connection whose critical messages go through
+// outbox. Status changes reach the OnNATSStatus listeners.
+func natsOptions(outbox *natsOutbox, shared bool) []nats.Option {
+	statusChanged := func(nc *nats.Conn) {
+		if shared {
+			natsHealthMu.Lock()
+			natsStatusSince = time.Now()
+			natsHealthMu.Unlock()
+		}
+		notifyNATSStatus(nc.Status())
+	}
+	return []nats.Option{
+		nats.ReconnectWait(NATSReconnectWait),
+		nats.MaxReconnects(-1),
+		nats.ReconnectBufSize(-1),
+		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
+			log.Printf("Disconnected from NATS: %v", err)
+			statusChanged(nc)
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			log.Printf("Reconnected to NATS at %s", nc.ConnectedUrl())
+			outbox.flush(nc)
+			statusChanged(nc)
+		}),
+		nats.ClosedHandler(func(nc *nats.Conn) {
+			statusChanged(nc)
+		}),
+	}
+}
+
+type natsMessage struct {
+	subject string
+	data    []byte
+}
+
+// natsOutbox holds critical messages that couldn't be published, in publishing order
+type natsOutbox struct {
+	mu      sync.Mutex
+	pending []natsMessage
+	dropped int
+}
+
+// publish sends a message on nc, or queues it if nc is down. Queued messages go out first,
+// so subscribers still see them in order.
+func (o *natsOutbox) publish(nc *nats.Conn, subject string, data []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if nc != nil && nc.IsConnected() {
+		o.flushLocked(nc)
+	}
+	if nc != nil && len(o.pending) == 0 {
+		err := nc.Publish(subject, data)
+		if err == nil {
+			return nil
+		}
+		if !disconnected(err) {
+			return err
+		}
+	}
+	if len(o.pending) >= natsOutboxLimit {
+		log.Printf("NATS outbox full, dropping oldest %s message", o.pending[0].subject)
+		o.pending = o.pending[1:]
+		o.dropped++
+	}
+	o.pending = append(o.pending, natsMessage{subject: subject, data: data})
+	return nil
+}
+
+// flush re-publishes queued messages on nc
+func (o *natsOutbox) flush(nc *nats.Conn) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.flushLocked(nc)
+}
+
+// flushLocked re-publishes queued messages until the connection drops again. Callers hold o.mu.
+func (o *natsOutbox) flushLocked(nc *nats.Conn) {
+	done := 0
+	for _, msg := range o.pending {
+		err := nc.Publish(msg.subject, msg.data)
+		if err != nil && disconnected(err) {
+			break
+		}
+		if err != nil {
+			log.Printf("Dropping buffered %s message: %v", msg.subject, err)
+		}
+		done++
+	}
+	if done > 0 {
+		log.Printf("Re-published %d buffered NATS messages", done)
+	}
+	o.pending = o.pending[done:]
+}
+
+// disconnected reports whether a publish failed only because the connection is down
+func disconnected(err error) bool {
+	return err == nats.ErrReconnectBufExceeded || err == nats.ErrConnectionReconnecting
+}
+
+func (o *natsOutbox) counts() (buffered, dropped int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending), o.dropped
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func startTestNATS(t *testing.T, port int) *server.Server {
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: port, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("failed to create NATS server: %v", err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(4 * time.Second) {
+		t.Fatal("NATS server failed to start")
+	}
+	return srv
+}
+
+func TestCriticalMessagesArePublishedAfterReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	srv := startTestNATS(t, port)
+	statuses := make(chan nats.Status, 10)
+	OnNATSStatus(func(status nats.Status) { statuses <- status })
+
+	outbox := &natsOutbox{}
+	nc, err := nats.Connect(fmt.Sprintf("nats://127.0.0.1:%d", port), natsOptions(outbox, false)...)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer nc.Close()
+	received := make(chan string, 10)
+	if _, err := nc.Subscribe("AGENT_VOTE", func(m *nats.Msg) { received <- string(m.Data) }); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	nc.Flush()
+
+	srv.Shutdown()
+	if status := <-statuses; status != nats.RECONNECTING {
+		t.Fatalf("expected RECONNECTING after the server stopped, got %v", status)
+	}
+	for _, vote := range []string{"first", "second"} {
+		if err := outbox.publish(nc, "AGENT_VOTE", []byte(vote)); err != nil {
+			t.Fatalf("publishing while disconnected should buffer, got %v", err)
+		}
+	}
+	if buffered, _ := outbox.counts(); buffered != 2 {
+		t.Fatalf("expected 2 buffered messages, got %d", buffered)
+	}
+
+	srv = startTestNATS(t, port)
+	defer srv.Shutdown()
+	select {
+	case status := <-statuses:
+		if status != nats.CONNECTED {
+			t.Fatalf("expected CONNECTED after the server restarted, got %v", status)
+		}
+	case <-time.After(3 * NATSReconnectWait):
+		t.Fatal("client did not reconnect")
+	}
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Fatalf("expected %q, got %q", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("buffered message %q was not re-published", want)
+		}
+	}
+	if buffered, _ := outbox.counts(); buffered != 0 {
+		t.Fatalf("expected the outbox to be empty, got %d", buffered)
+	}
+}
+
+func TestNATSOutboxDropsOldestWhenFull(t *testing.T) {
+	outbox := &natsOutbox{}
+	for i := 0; i <= natsOutboxLimit; i++ {
+		if err := outbox.publish(nil, "AGENT_DISCUSSION", []byte(fmt.Sprint(i))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	buffered, dropped := outbox.counts()
+	if buffered != natsOutboxLimit || dropped != 1 {
+		t.Fatalf("expected %d buffered and 1 dropped, got %d and %d", natsOutboxLimit, buffered, dropped)
+	}
+	if string(outbox.pending[0].data) != "1" {
+		t.Fatalf("expected the oldest message to be dropped, first is %q", outbox.pending[0].data)
+	}
+}
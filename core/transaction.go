@@ -6,7 +6,10 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 )
 
 // Transaction represents a basic transaction structure
@@ -14,12 +17,14 @@ type Transaction struct {
 	From      string  `json:"from"`
 	To        string  `json:"to"`
 	Amount    float64 `json:"amount"`
-	Fee       uint64  `json:"fee"` // 💰 New: Transaction fee
+	Fee       uint64  `json:"fee"`   // 💰 New: Transaction fee
+	Nonce     uint64  `json:"nonce"` // Must exceed the sender's last accepted nonce on the chain
 	Content   string  `json:"content"`
 	Timestamp int64   `json:"timestamp"`
 	Signature string  `json:"signature"`
 	PublicKey string  `json:"publicKey"`
 	ChainID   string  `json:"chainID"`
+	Type      string  `json:"type,omitempty"` // One of the chain's TransactionTypes; empty means a transfer
 }
 
 // GenerateKeyPair creates a new key pair for signing transactions
@@ -27,31 +32,126 @@ func GenerateKeyPair() (*ecdsa.PrivateKey, error) {
 	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 }
 
-// SignTransaction signs a transaction with the given private key
+// TransactionSigningPayload is the part of a transaction covered by its signature,
+// encoded as compact JSON in this field order
+type TransactionSigningPayload struct {
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Amount    float64 `json:"amount"`
+	Fee       uint64  `json:"fee"`
+	Nonce     uint64  `json:"nonce"`
+	Content   string  `json:"content"`
+	Timestamp int64   `json:"timestamp"`
+	ChainID   string  `json:"chainID"`
+	Type      string  `json:"type,omitempty"` // Left out when empty, so untyped transactions sign as before
+}
+
+// SigningPayload returns the bytes whose SHA-256 hash the sender signs
+func (tx *Transaction) SigningPayload() []byte {
+	payload, _ := json.Marshal(TransactionSigningPayload{
+		From:      tx.From,
+		To:        tx.To,
+		Amount:    tx.Amount,
+		Fee:       tx.Fee,
+		Nonce:     tx.Nonce,
+		Content:   tx.Content,
+		Timestamp: tx.Timestamp,
+		ChainID:   tx.ChainID,
+		Type:      tx.Type,
+	})
+	return payload
+}
+
+// SignTransaction signs a transaction with the given P-256 private key, storing the signature
+// as hex r||s (32 bytes each) and the compressed public key as hex
 func (tx *Transaction) SignTransaction(privateKey *ecdsa.PrivateKey) error {
-	// Create hash of transaction data
-	hash := sha256.Sum256([]byte(fmt.Sprintf("%s%s%.8f", tx.From, tx.To, tx.Amount)))
+	hash := sha256.Sum256(tx.SigningPayload())
 
-	// Sign the hash
 	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
 	if err != nil {
 		return err
 	}
 
-	// Store signature and public key
-	tx.Signature = hex.EncodeToString(append(r.Bytes(), s.Bytes()...))
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	tx.Signature = hex.EncodeToString(signature)
 	tx.PublicKey = hex.EncodeToString(elliptic.MarshalCompressed(privateKey.PublicKey.Curve, privateKey.PublicKey.X, privateKey.PublicKey.Y))
 
 	return nil
 }
 
-// VerifyTransaction verifies the transaction signature
-func (tx *Transaction) VerifyTransaction(from string) bool {
-	// TODO: In the final implementation, we would:
-	// 1. Decode the signature and public key
-	// 2. Recreate the transaction hash
-	// 3. Verify the signature using the public key
+// ErrSenderMismatch is returned for a transaction whose From isn't the address of the key
+// that signed it
+var ErrSenderMismatch = errors.New("sender is not the address of the signing key")
+
+// Address returns the account address of a P-256 public key: "0x" and the hex of the first
+// 20 bytes of the SHA-256 hash of its compressed encoding. A signed transaction's From must
+// be the address of its PublicKey.
+func Address(key *ecdsa.PublicKey) string {
+	hash := sha256.Sum256(elliptic.MarshalCompressed(key.Curve, key.X, key.Y))
+	return "0x" + hex.EncodeToString(hash[:20])
+}
+
+// parsePublicKey decodes a hex-encoded compressed (33 bytes) or uncompressed (65 bytes) P-256 point
+func parsePublicKey(publicKey string) (*ecdsa.PublicKey, error) {
+	keyBytes, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("public key is not valid hex")
+	}
+	curve := elliptic.P256()
+	var x, y *big.Int
+	switch len(keyBytes) {
+	case 33:
+		x, y = elliptic.UnmarshalCompressed(curve, keyBytes)
+	case 65:
+		x, y = elliptic.Unmarshal(curve, keyBytes)
+	}
+	if x == nil {
+		return nil, fmt.Errorf("public key is not a P-256 point")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
 
-	// For now, just verify the sender matches
-	return tx.From == from
+// VerifySignature checks that the transaction carries a valid signature by its public key over
+// its signing payload. It doesn't check who the key belongs to, see VerifyTransaction.
+func VerifySignature(tx Transaction) error {
+	if tx.Signature == "" || tx.PublicKey == "" {
+		return fmt.Errorf("transaction is not signed")
+	}
+	key, err := parsePublicKey(tx.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	signature, err := hex.DecodeString(tx.Signature)
+	if err != nil || len(signature) != 64 {
+		return fmt.Errorf("signature must be 64 bytes of hex (r||s)")
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+
+	hash := sha256.Sum256(tx.SigningPayload())
+	if !ecdsa.Verify(key, hash[:], r, s) {
+		return fmt.Errorf("signature does not match the transaction")
+	}
+	return nil
+}
+
+// VerifyTransaction checks that the transaction is validly signed and that its From is the
+// address of the signing key, so only the key's holder can send from that address
+func VerifyTransaction(tx Transaction) error {
+	if err := VerifySignature(tx); err != nil {
+		return err
+	}
+	key, _ := parsePublicKey(tx.PublicKey)
+	if tx.From != Address(key) {
+		return fmt.Errorf("%w: %s signed for %s", ErrSenderMismatch, Address(key), tx.From)
+	}
+	return nil
+}
+
+// VerifyTransaction reports whether the transaction is from the given sender and signed by its key
+func (tx *Transaction) VerifyTransaction(from string) bool {
+	return tx.From == from && VerifyTransaction(*tx) == nil
 }
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func signedTransaction(t *testing.T) Transaction {
+	key, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transaction{From: Address(&key.PublicKey), To: "bob", Amount: 2.5, Content: "proposal", Timestamp: 1740830400, ChainID: "sig-chain"}
+	if err := tx.SignTransaction(key); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestVerifyTransactionAcceptsSignedTransaction(t *testing.T) {
+	tx := signedTransaction(t)
+	if err := VerifyTransaction(tx); err != nil {
+		t.Fatalf("valid transaction rejected: %v", err)
+	}
+	if !tx.VerifyTransaction(tx.From) {
+		t.Fatal("method rejected a valid transaction from its sender")
+	}
+	if tx.VerifyTransaction("mallory") {
+		t.Fatal("method accepted the transaction for a different sender")
+	}
+}
+
+func TestVerifyTransactionRejectsTampering(t *testing.T) {
+	tampered := map[string]func(tx *Transaction){
+		"from":    func(tx *Transaction) { tx.From = "mallory" },
+		"content": func(tx *Transaction) { tx.Content = "another proposal" },
+		"amount":  func(tx *Transaction) { tx.Amount = 250 },
+		"chain":   func(tx *Transaction) { tx.ChainID = "other-chain" },
+		"key": func(tx *Transaction) {
+			other := signedTransaction(t)
+			tx.PublicKey = other.PublicKey
+		},
+		"unsigned": func(tx *Transaction) { tx.Signature, tx.PublicKey = "", "" },
+		"garbage":  func(tx *Transaction) { tx.Signature = "zz" },
+	}
+	for name, tamper := range tampered {
+		tx := signedTransaction(t)
+		tamper(&tx)
+		if err := VerifyTransaction(tx); err == nil {
+			t.Errorf("%s: tampered transaction verified", name)
+		}
+	}
+}
+
+func TestVerifyTransactionRejectsStrangersSigningForAnotherSender(t *testing.T) {
+	alice, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mallory, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transaction{From: Address(&alice.PublicKey), To: "mallory", Amount: 100, Timestamp: 1740830400, ChainID: "sig-chain"}
+	if err := tx.SignTransaction(mallory); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifySignature(tx); err != nil {
+		t.Fatalf("mallory's signature should itself be valid: %v", err)
+	}
+	if err := VerifyTransaction(tx); !errors.Is(err, ErrSenderMismatch) {
+		t.Fatalf("expected ErrSenderMismatch, got %v", err)
+	}
+	if tx.VerifyTransaction(tx.From) {
+		t.Fatal("method accepted a transaction signed by someone other than the sender")
+	}
+
+	bc := NewBlockchain("sig-chain", &staticMempool{})
+	defer func() {
+		chainsLock.Lock()
+		delete(chains, "sig-chain")
+		chainsLock.Unlock()
+	}()
+	if err := bc.ProcessTransaction(tx, bc.Mempool); !errors.Is(err, ErrSenderMismatch) {
+		t.Fatalf("chain accepted a forged sender: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package da
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DABackend is where the DataAvailabilityService keeps blobs
+type DABackend interface {
+	// StoreData stores data and returns the ID it can be retrieved by. It returns once the
+	// backend has accepted the blob, which may not be confirmed yet, see GetBlobStatus.
+	StoreData(ctx context.Context, data map[string]interface{}) (string, error)
+	// RetrieveData returns the data stored under dataID
+	RetrieveData(dataID string) (map[string]interface{}, error)
+	// GetBlobStatus reports whether a stored blob is BlobStatusPending, BlobStatusConfirmed,
+	// BlobStatusFinalized or BlobStatusFailed
+	GetBlobStatus(ctx context.Context, dataID string) (string, error)
+}
+
+// useMemoryBackend reports whether SetupGlobalDAService should use the in-memory backend:
+// when DA_BACKEND=memory, or when no EigenDA auth key is configured
+func useMemoryBackend() bool {
+	if strings.EqualFold(os.Getenv("DA_BACKEND"), "memory") {
+		return true
+	}
+	_, ok := os.LookupEnv("EIGENDA_AUTH_PK")
+	return !ok
+}
+
+// StoreData stores data in the backend and publishes its ID to NATS
+func (s *DataAvailabilityService) StoreData(ctx context.Context, data map[string]interface{}) (string, error) {
+	dataID, err := s.backend.StoreData(ctx, data)
+	if err != nil {
+		return dataID, err
+	}
+
+	message := fmt.Sprintf(`{"dataID":"%s","timestamp":%d}`, dataID, time.Now().Unix())
+	if err := s.messenger.PublishCritical(SUBJECT_DATA_STORED, message); err != nil {
+		return dataID, fmt.Errorf("data stored but failed to publish event: %w", err)
+	}
+	return dataID, nil
+}
+
+// RetrieveData retrieves data from the backend and publishes that it was read
+func (s *DataAvailabilityService) RetrieveData(dataID string) (map[string]interface{}, error) {
+	data, err := s.backend.RetrieveData(dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf(`{"dataID":"%s","timestamp":%d}`, dataID, time.Now().Unix())
+	s.messenger.PublishGlobal(SUBJECT_DATA_RETRIEVED, message)
+	return data, nil
+}
+
+// GetBlobStatus retrieves the current status of a blob from the backend
+func (s *DataAvailabilityService) GetBlobStatus(ctx context.Context, dataID string) (string, error) {
+	return s.backend.GetBlobStatus(ctx, dataID)
+}
+
+// MemoryBackend keeps blobs in a map for local development. Nothing survives a restart.
+type MemoryBackend struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte // dataID -> JSON encoded data
+}
+
+// NewMemoryBackend creates an empty in-memory backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{blobs: make(map[string][]byte)}
+}
+
+// StoreData stores a copy of data under a random ID
+func (m *MemoryBackend) StoreData(ctx context.Context, data map[string]interface{}) (string, error) {
+	if data == nil {
+		return "", fmt.Errorf("data is required")
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate data ID: %w", err)
+	}
+	dataID := "mem-" + hex.EncodeToString(id)
+
+	m.mu.Lock()
+	m.blobs[dataID] = encoded
+	m.mu.Unlock()
+	return dataID, nil
+}
+
+// RetrieveData returns the data stored under dataID
+func (m *MemoryBackend) RetrieveData(dataID string) (map[string]interface{}, error) {
+	if dataID == "" {
+		return nil, fmt.Errorf("dataID is required")
+	}
+
+	m.mu.RLock()
+	encoded, ok := m.blobs[dataID]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found", dataID)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retrieved data: %w", err)
+	}
+	return data, nil
+}
+
+// GetBlobStatus reports stored blobs as FINALIZED, since they are available immediately
+func (m *MemoryBackend) GetBlobStatus(ctx context.Context, dataID string) (string, error) {
+	m.mu.RLock()
+	_, ok := m.blobs[dataID]
+	m.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("blob %s not found", dataID)
+	}
+	return BlobStatusFinalized, nil
+}
@@ -0,0 +1,41 @@
+package da
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryBackendRoundTrip(t *testing.T) {
+	backend := NewMemoryBackend()
+	dataID, err := backend.StoreData(context.Background(), map[string]interface{}{"blockHash": "abc", "height": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := backend.RetrieveData(dataID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["blockHash"] != "abc" || data["height"] != float64(3) {
+		t.Fatalf("unexpected data %v", data)
+	}
+	if status, err := backend.GetBlobStatus(context.Background(), dataID); err != nil || status != BlobStatusFinalized {
+		t.Fatalf("expected FINALIZED, got %v (%v)", status, err)
+	}
+
+	if _, err := backend.RetrieveData("missing"); err == nil {
+		t.Fatal("expected an error for an unknown blob")
+	}
+}
+
+func TestUseMemoryBackend(t *testing.T) {
+	t.Setenv("EIGENDA_AUTH_PK", "00")
+	t.Setenv("DA_BACKEND", "")
+	if useMemoryBackend() {
+		t.Fatal("expected EigenDA when an auth key is set")
+	}
+	t.Setenv("DA_BACKEND", "memory")
+	if !useMemoryBackend() {
+		t.Fatal("expected the memory backend with DA_BACKEND=memory")
+	}
+}
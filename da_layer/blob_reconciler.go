@@ -0,0 +1,87 @@
+package da
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Offchain data is dispersed without waiting for EigenDA to confirm it, which can take
+// up to EIGENDA_MAX_WAIT_TIME, so a slow disperser doesn't hold up the request saving it.
+// Its blob reference is stored as pending, and this poller checks pending blobs every
+// EIGENDA_POLL_INTERVAL and stores their reference again once the status is known.
+var (
+	pendingBlobs   = make(map[string]BlobReference) // blobID -> reference waiting for confirmation
+	pendingBlobsMu sync.Mutex
+	reconcilerOnce sync.Once
+)
+
+// trackPendingBlob queues a pending reference for the poller, starting it if needed
+func trackPendingBlob(ref BlobReference) {
+	pendingBlobsMu.Lock()
+	pendingBlobs[ref.BlobID] = ref
+	pendingBlobsMu.Unlock()
+
+	reconcilerOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(EIGENDA_POLL_INTERVAL)
+			defer ticker.Stop()
+			for range ticker.C {
+				reconcilePendingBlobs()
+			}
+		}()
+	})
+}
+
+// reconcilePendingBlobs checks the status of every pending blob once
+func reconcilePendingBlobs() {
+	svc := GlobalDAService
+	if svc == nil {
+		return
+	}
+
+	pendingBlobsMu.Lock()
+	refs := make([]BlobReference, 0, len(pendingBlobs))
+	for _, ref := range pendingBlobs {
+		refs = append(refs, ref)
+	}
+	pendingBlobsMu.Unlock()
+
+	for _, ref := range refs {
+		ctx, cancel := context.WithTimeout(context.Background(), EIGENDA_REQUEST_TIMEOUT)
+		status, err := svc.GetBlobStatus(ctx, ref.BlobID)
+		cancel()
+		if err != nil {
+			log.Printf("Failed to check status of blob %s: %v", ref.BlobID, err)
+		}
+
+		updated, done := resolveBlobReference(ref, status, err, time.Now())
+		if !done {
+			continue
+		}
+		pendingBlobsMu.Lock()
+		delete(pendingBlobs, ref.BlobID)
+		pendingBlobsMu.Unlock()
+
+		log.Printf("Blob %s for block %s is %s", ref.BlobID, ref.BlockHash, updated.Status)
+		if err := StoreBlobReference(updated); err != nil {
+			log.Printf("Failed to update blob reference for block %s: %v", ref.BlockHash, err)
+		}
+	}
+}
+
+// resolveBlobReference applies a status check to a pending reference, reporting whether
+// the blob is settled. A blob still pending, or whose status can't be read, past
+// EIGENDA_MAX_WAIT_TIME after it was stored is marked failed.
+func resolveBlobReference(ref BlobReference, status string, err error, now time.Time) (BlobReference, bool) {
+	if err == nil && status != BlobStatusPending {
+		ref.Status = status
+		return ref, true
+	}
+	if now.Sub(time.Unix(ref.Timestamp, 0)) > EIGENDA_MAX_WAIT_TIME {
+		ref.Status = BlobStatusFailed
+		return ref, true
+	}
+	return ref, false
+}
@@ -0,0 +1,117 @@
+package da
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+// Blob references are also kept in the node's local storage, one entry per block, so
+// lookups keep working after a restart without loading the master index from EigenDA
+const blobRefPrefix = "blobref:"
+
+func blobRefChainPrefix(chainID string) []byte {
+	return []byte(blobRefPrefix + chainID + ":")
+}
+
+func blobRefKey(chainID, blockHash string) []byte {
+	return append(blobRefChainPrefix(chainID), blockHash...)
+}
+
+// persistBlobReference writes ref to local storage. Without local storage configured it
+// does nothing.
+func persistBlobReference(ref BlobReference) error {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(ref)
+	if err != nil {
+		return err
+	}
+	return store.Set(blobRefKey(ref.ChainID, ref.BlockHash), encoded)
+}
+
+// storedBlobReferences returns the chain's references from local storage, keyed by block hash
+func storedBlobReferences(chainID string) map[string]BlobReference {
+	refs := make(map[string]BlobReference)
+	store := storage.Default()
+	if store == nil {
+		return refs
+	}
+
+	err := store.Iterate(blobRefChainPrefix(chainID), func(key, value []byte) error {
+		var ref BlobReference
+		if err := json.Unmarshal(value, &ref); err != nil {
+			log.Printf("Skipping unreadable blob reference %s: %v", key, err)
+			return nil
+		}
+		refs[ref.BlockHash] = ref
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to read blob references for chain %s: %v", chainID, err)
+	}
+	return refs
+}
+
+// storedBlobReference returns one reference from local storage
+func storedBlobReference(chainID, blockHash string) (BlobReference, bool) {
+	store := storage.Default()
+	if store == nil {
+		return BlobReference{}, false
+	}
+
+	encoded, err := store.Get(blobRefKey(chainID, blockHash))
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Failed to read blob reference %s/%s: %v", chainID, blockHash, err)
+		}
+		return BlobReference{}, false
+	}
+	var ref BlobReference
+	if err := json.Unmarshal(encoded, &ref); err != nil {
+		log.Printf("Skipping unreadable blob reference %s/%s: %v", chainID, blockHash, err)
+		return BlobReference{}, false
+	}
+	return ref, true
+}
+
+// chainBlobReferences merges the chain's references from local storage and the master
+// index, preferring the master index where both have the block
+func chainBlobReferences(chainID string) map[string]BlobReference {
+	refs := storedBlobReferences(chainID)
+
+	masterIndexLock.RLock()
+	defer masterIndexLock.RUnlock()
+	if chainIndex, ok := masterIndex.ChainIndices[chainID]; ok {
+		for blockHash, ref := range chainIndex.BlobReferences {
+			refs[blockHash] = ref
+		}
+	}
+	return refs
+}
+
+// pendingBlobReferences returns every chain's references in local storage that are still
+// waiting for their blob to be confirmed
+func pendingBlobReferences() []BlobReference {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+
+	var refs []BlobReference
+	err := store.Iterate([]byte(blobRefPrefix), func(key, value []byte) error {
+		var ref BlobReference
+		if err := json.Unmarshal(value, &ref); err == nil && ref.Status == BlobStatusPending {
+			refs = append(refs, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to read pending blob references: %v", err)
+	}
+	return refs
+}
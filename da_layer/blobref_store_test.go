@@ -0,0 +1,109 @@
+package da
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBlobReferencesServedFromLocalStorage(t *testing.T) {
+	withCacheStorage(t)
+	ref := BlobReference{BlobID: "blob-7", ChainID: "ref-chain", BlockHash: "hash-7", BlockHeight: 7, Outcome: "accepted"}
+	if err := persistBlobReference(ref); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistBlobReference(BlobReference{BlobID: "other", ChainID: "ref-chain-2", BlockHash: "hash-7", BlockHeight: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is in the master index, as after a restart without EigenDA
+	if got, ok := GetBlobReferenceByBlockHash("ref-chain", "hash-7"); !ok || got != ref {
+		t.Fatalf("lookup by hash: got %+v, %v", got, ok)
+	}
+	if got, ok := GetBlobReferenceByHeight("ref-chain", 7); !ok || got != ref {
+		t.Fatalf("lookup by height: got %+v, %v", got, ok)
+	}
+	if refs := GetBlobReferencesForChain("ref-chain"); len(refs) != 1 || refs[0] != ref {
+		t.Fatalf("expected just the chain's reference, got %+v", refs)
+	}
+}
+
+func TestResolveBlobReference(t *testing.T) {
+	stored := time.Unix(1000, 0)
+	ref := BlobReference{BlobID: "blob-1", Timestamp: stored.Unix(), Status: BlobStatusPending}
+
+	if got, done := resolveBlobReference(ref, BlobStatusConfirmed, nil, stored.Add(time.Minute)); !done || got.Status != BlobStatusConfirmed {
+		t.Fatalf("confirmed blob: got %+v, %v", got, done)
+	}
+	if got, done := resolveBlobReference(ref, BlobStatusPending, nil, stored.Add(time.Minute)); done || got.Status != BlobStatusPending {
+		t.Fatalf("pending blob: got %+v, %v", got, done)
+	}
+	if _, done := resolveBlobReference(ref, "", errors.New("unavailable"), stored.Add(time.Minute)); done {
+		t.Fatal("a failed status check settled the blob")
+	}
+	if got, done := resolveBlobReference(ref, "", errors.New("unavailable"), stored.Add(EIGENDA_MAX_WAIT_TIME+time.Second)); !done || got.Status != BlobStatusFailed {
+		t.Fatalf("expired blob: got %+v, %v", got, done)
+	}
+}
+
+func TestPendingBlobReferences(t *testing.T) {
+	withCacheStorage(t)
+	pending := BlobReference{BlobID: "blob-p", ChainID: "pending-chain", BlockHash: "hash-p", Status: BlobStatusPending}
+	for _, ref := range []BlobReference{
+		pending,
+		{BlobID: "blob-c", ChainID: "pending-chain", BlockHash: "hash-c", Status: BlobStatusConfirmed},
+		{BlobID: "blob-old", ChainID: "other-chain", BlockHash: "hash-old"},
+	} {
+		if err := persistBlobReference(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if refs := pendingBlobReferences(); len(refs) != 1 || refs[0] != pending {
+		t.Fatalf("expected just the pending reference, got %+v", refs)
+	}
+}
+
+func TestListOffchainDataSortsByHeight(t *testing.T) {
+	withCacheStorage(t)
+	for _, height := range []int{3, 1, 2} {
+		ref := BlobReference{BlobID: fmt.Sprintf("list-%d", height), ChainID: "list-chain", BlockHash: fmt.Sprintf("hash-%d", height), BlockHeight: height}
+		if err := persistBlobReference(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blobIDs, err := ListOffchainData("list-chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(blobIDs) != "[list-1 list-2 list-3]" {
+		t.Fatalf("expected blob IDs lowest block first, got %v", blobIDs)
+	}
+	if refs := ListOffchainDataDetailed("list-chain"); len(refs) != 3 || refs[0].BlockHeight != 1 || refs[2].BlockHeight != 3 {
+		t.Fatalf("unexpected detailed listing %+v", refs)
+	}
+	if blobIDs, _ := ListOffchainData("no-such-chain"); len(blobIDs) != 0 {
+		t.Fatalf("expected nothing for an unknown chain, got %v", blobIDs)
+	}
+}
+
+func TestBlobReferenceByContentHashPrefersLatest(t *testing.T) {
+	withCacheStorage(t)
+	for _, ref := range []BlobReference{
+		{BlobID: "first", ChainID: "content-chain", BlockHash: "proposal-1", ContentHash: "same", BlockHeight: 4, Timestamp: 100},
+		{BlobID: "second", ChainID: "content-chain", BlockHash: "proposal-2", ContentHash: "same", BlockHeight: 4, Timestamp: 200},
+		{BlobID: "other", ChainID: "content-chain", BlockHash: "proposal-3", ContentHash: "different", BlockHeight: 5, Timestamp: 300},
+	} {
+		if err := persistBlobReference(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ref, ok := GetBlobReferenceByContentHash("content-chain", "same"); !ok || ref.BlobID != "second" {
+		t.Fatalf("expected the re-proposal's blob, got %+v (%v)", ref, ok)
+	}
+	if _, ok := GetBlobReferenceByContentHash("content-chain", "unknown"); ok {
+		t.Fatal("found a reference for unknown content")
+	}
+}
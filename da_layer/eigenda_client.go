@@ -7,11 +7,19 @@ import (
 	"log"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 )
 
-// StoreData stores data in EigenDA and publishes dataID to NATS
-func (s *DataAvailabilityService) StoreData(data map[string]interface{}) (string, error) {
+// eigenDABackend stores blobs with the EigenDA disperser
+type eigenDABackend struct {
+	client  clients.DisperserClient
+	limiter *opLimiter // Bounds concurrent disperse/retrieve operations
+}
+
+// StoreData disperses data to EigenDA. It returns once the disperser accepts the blob,
+// without waiting for it to be confirmed.
+func (s *eigenDABackend) StoreData(ctx context.Context, data map[string]interface{}) (string, error) {
 	if data == nil {
 		return "", fmt.Errorf("data is required")
 	}
@@ -28,8 +36,12 @@ func (s *DataAvailabilityService) StoreData(data map[string]interface{}) (string
 	// Add retry logic for dispersing the blob
 	var dataID string
 	err = retry(3, 2*time.Second, func() error {
+		// Wait for a free operation slot before hitting the disperser
+		release := s.limiter.acquire()
+		defer release()
+
 		// Context with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), EIGENDA_REQUEST_TIMEOUT)
+		ctx, cancel := context.WithTimeout(ctx, EIGENDA_REQUEST_TIMEOUT)
 		defer cancel()
 
 		// Custom quorums (none for now, means we're dispersing to the default quorums)
@@ -50,28 +62,19 @@ func (s *DataAvailabilityService) StoreData(data map[string]interface{}) (string
 		return "", err
 	}
 
-	// Wait for blob to be confirmed or finalized
-	status, err := s.waitForBlobStatus(dataID)
-	if err != nil {
-		return dataID, fmt.Errorf("blob dispersed but status tracking failed: %w", err)
-	}
-
-	// Publish event using the messenger
-	message := fmt.Sprintf(`{"dataID":"%s","status":"%s","timestamp":%d}`,
-		dataID, status, time.Now().Unix())
-	if err := s.messenger.PublishGlobal(SUBJECT_DATA_STORED, message); err != nil {
-		return dataID, fmt.Errorf("data stored but failed to publish event: %w", err)
-	}
-
 	return dataID, nil
 }
 
 // RetrieveData retrieves data from EigenDA using dataID
-func (s *DataAvailabilityService) RetrieveData(dataID string) (map[string]interface{}, error) {
+func (s *eigenDABackend) RetrieveData(dataID string) (map[string]interface{}, error) {
 	if dataID == "" {
 		return nil, fmt.Errorf("dataID is required")
 	}
 
+	// Wait for a free operation slot before hitting the disperser
+	release := s.limiter.acquire()
+	defer release()
+
 	// Create a context with timeout for retrieval
 	ctx, cancel := context.WithTimeout(context.Background(), EIGENDA_REQUEST_TIMEOUT)
 	defer cancel()
@@ -83,7 +86,7 @@ func (s *DataAvailabilityService) RetrieveData(dataID string) (map[string]interf
 	}
 
 	// Remove null bytes padding from the data
-	decodedData := s.removeNullBytesPadding(blobData)
+	decodedData := removeNullBytesPadding(blobData)
 
 	// Log the retrieved data for debugging
 	log.Printf("Retrieved data (length: %d): %s", len(decodedData), string(decodedData))
@@ -107,34 +110,38 @@ func (s *DataAvailabilityService) RetrieveData(dataID string) (map[string]interf
 		}
 	}
 
-	// Publish event that data was retrieved
-	retrieveMsg := fmt.Sprintf(`{"dataID":"%s","timestamp":%d}`, dataID, time.Now().Unix())
-	s.messenger.PublishGlobal(SUBJECT_DATA_RETRIEVED, retrieveMsg)
-
 	return result, nil
 }
 
-// GetBlobStatus retrieves the current status of a blob from EigenDA
-func (s *DataAvailabilityService) GetBlobStatus(dataID string) (interface{}, error) {
+// GetBlobStatus asks the disperser for a blob's status
+func (s *eigenDABackend) GetBlobStatus(ctx context.Context, dataID string) (string, error) {
 	if dataID == "" {
-		return nil, fmt.Errorf("dataID is required")
+		return "", fmt.Errorf("dataID is required")
 	}
 
 	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), EIGENDA_REQUEST_TIMEOUT)
+	ctx, cancel := context.WithTimeout(ctx, EIGENDA_REQUEST_TIMEOUT)
 	defer cancel()
 
 	// Get the blob status using the client
 	statusReply, err := s.client.GetBlobStatus(ctx, []byte(dataID))
 	if err != nil {
-		return nil, fmt.Errorf("error getting blob status: %w", err)
+		return "", fmt.Errorf("error getting blob status: %w", err)
 	}
 
-	return statusReply, nil
+	switch status := statusReply.Status.String(); status {
+	case BlobStatusConfirmed, BlobStatusFinalized, BlobStatusFailed:
+		return status, nil
+	case "INSUFFICIENT_SIGNATURES":
+		return BlobStatusFailed, nil
+	default:
+		// PROCESSING, DISPERSING and UNKNOWN may still resolve
+		return BlobStatusPending, nil
+	}
 }
 
 // retrieveBlobFromDisperser retrieves a blob from EigenDA using the disperser client
-func (s *DataAvailabilityService) retrieveBlobFromDisperser(ctx context.Context, dataID string) ([]byte, error) {
+func (s *eigenDABackend) retrieveBlobFromDisperser(ctx context.Context, dataID string) ([]byte, error) {
 	// First, get the blob status to get the batch information needed for retrieval
 	statusReply, err := s.client.GetBlobStatus(ctx, []byte(dataID))
 	if err != nil {
@@ -162,48 +169,3 @@ func (s *DataAvailabilityService) retrieveBlobFromDisperser(ctx context.Context,
 
 	return data, nil
 }
-
-// waitForBlobStatus polls the blob status until it's finalized or failed
-func (s *DataAvailabilityService) waitForBlobStatus(requestID string) (string, error) {
-	// Create a context for the overall status checking
-	statusOverallCtx, statusOverallCancel := context.WithTimeout(context.Background(), EIGENDA_MAX_WAIT_TIME)
-	defer statusOverallCancel()
-
-	ticker := time.NewTicker(EIGENDA_POLL_INTERVAL)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			// Create a new context for each status request
-			statusCtx, statusCancel := context.WithTimeout(statusOverallCtx, EIGENDA_REQUEST_TIMEOUT)
-
-			// Get the blob status
-			statusReply, err := s.client.GetBlobStatus(statusCtx, []byte(requestID))
-			statusCancel()
-
-			if err != nil {
-				return "ERROR", fmt.Errorf("error getting blob status: %w", err)
-			}
-
-			// Check if the status is done
-			status := statusReply.Status.String()
-			if status == "FINALIZED" {
-				// fmt.Printf("Blob Status is finalized: %s\n", statusReply)
-				return "FINALIZED", nil
-			} else if status == "CONFIRMED" {
-				// fmt.Printf("Blob Status is confirmed: %s\n", statusReply)
-				return "CONFIRMED", nil
-			} else if status == "FAILED" {
-				fmt.Printf("Blob Status is failed: %s\n", statusReply)
-				return "FAILED", fmt.Errorf("blob dispersal failed with status: %v", status)
-			}
-
-			// Continue polling for other statuses
-			fmt.Printf("Current Blob Status: %s\n", status)
-
-		case <-statusOverallCtx.Done():
-			return "TIMEOUT", fmt.Errorf("timed out waiting for blob to finalize")
-		}
-	}
-}
@@ -0,0 +1,44 @@
+package da
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+)
+
+// disperserConfigFromEnv builds the disperser client configuration, reading EIGENDA_HOST,
+// EIGENDA_PORT and EIGENDA_DISABLE_TLS and falling back to the Holesky disperser over TLS.
+// Disabling TLS lets the client talk to a local insecure emulator.
+func disperserConfigFromEnv() *clients.Config {
+	host := os.Getenv("EIGENDA_HOST")
+	if host == "" {
+		host = EIGENDA_HOST
+	}
+
+	port := os.Getenv("EIGENDA_PORT")
+	if port == "" {
+		port = EIGENDA_PORT
+	} else if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		log.Printf("Invalid EIGENDA_PORT %q, using default of %s", port, EIGENDA_PORT)
+		port = EIGENDA_PORT
+	}
+
+	secure := true
+	if value := os.Getenv("EIGENDA_DISABLE_TLS"); value != "" {
+		disable, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Invalid EIGENDA_DISABLE_TLS %q, keeping TLS enabled", value)
+		} else {
+			secure = !disable
+		}
+	}
+
+	return &clients.Config{
+		Hostname:          host,
+		Port:              port,
+		Timeout:           EIGENDA_REQUEST_TIMEOUT,
+		UseSecureGrpcFlag: secure, // should be true for production
+	}
+}
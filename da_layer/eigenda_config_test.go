@@ -0,0 +1,28 @@
+package da
+
+import "testing"
+
+func TestDisperserConfigFromEnv(t *testing.T) {
+	t.Setenv("EIGENDA_HOST", "")
+	t.Setenv("EIGENDA_PORT", "")
+	t.Setenv("EIGENDA_DISABLE_TLS", "")
+	config := disperserConfigFromEnv()
+	if config.Hostname != EIGENDA_HOST || config.Port != EIGENDA_PORT || !config.UseSecureGrpcFlag {
+		t.Fatalf("expected Holesky defaults over TLS, got %+v", config)
+	}
+
+	t.Setenv("EIGENDA_HOST", "localhost")
+	t.Setenv("EIGENDA_PORT", "32001")
+	t.Setenv("EIGENDA_DISABLE_TLS", "true")
+	config = disperserConfigFromEnv()
+	if config.Hostname != "localhost" || config.Port != "32001" || config.UseSecureGrpcFlag {
+		t.Fatalf("expected insecure localhost:32001, got %+v", config)
+	}
+
+	t.Setenv("EIGENDA_PORT", "not-a-port")
+	t.Setenv("EIGENDA_DISABLE_TLS", "maybe")
+	config = disperserConfigFromEnv()
+	if config.Port != EIGENDA_PORT || !config.UseSecureGrpcFlag {
+		t.Fatalf("expected invalid values to fall back to defaults, got %+v", config)
+	}
+}
@@ -0,0 +1,102 @@
+package da
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// OperationStats reports how EigenDA operations are being queued by the service
+type OperationStats struct {
+	MaxConcurrent   int           `json:"maxConcurrent"`
+	InFlight        int64         `json:"inFlight"`
+	Queued          int64         `json:"queued"`
+	TotalOperations int64         `json:"totalOperations"`
+	AverageWait     time.Duration `json:"averageWait"`
+	MaxWait         time.Duration `json:"maxWait"`
+}
+
+// opLimiter bounds the number of concurrent disperse/retrieve calls made to EigenDA.
+// Callers beyond the limit queue until a slot frees up.
+type opLimiter struct {
+	slots     chan struct{}
+	queued    int64
+	inFlight  int64
+	totalOps  int64
+	totalWait int64 // nanoseconds
+	maxWait   int64 // nanoseconds
+}
+
+func newOpLimiter(max int) *opLimiter {
+	if max <= 0 {
+		max = EIGENDA_MAX_CONCURRENT_OPS
+	}
+	return &opLimiter{slots: make(chan struct{}, max)}
+}
+
+// maxConcurrentOpsFromEnv reads EIGENDA_MAX_CONCURRENT_OPS, falling back to the default
+func maxConcurrentOpsFromEnv() int {
+	value := os.Getenv("EIGENDA_MAX_CONCURRENT_OPS")
+	if value == "" {
+		return EIGENDA_MAX_CONCURRENT_OPS
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		log.Printf("Invalid EIGENDA_MAX_CONCURRENT_OPS %q, using default of %d", value, EIGENDA_MAX_CONCURRENT_OPS)
+		return EIGENDA_MAX_CONCURRENT_OPS
+	}
+	return max
+}
+
+// acquire blocks until an operation slot is free and returns the function that releases it
+func (l *opLimiter) acquire() func() {
+	if l == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	atomic.AddInt64(&l.queued, 1)
+	l.slots <- struct{}{}
+	atomic.AddInt64(&l.queued, -1)
+	atomic.AddInt64(&l.inFlight, 1)
+
+	wait := int64(time.Since(start))
+	atomic.AddInt64(&l.totalOps, 1)
+	atomic.AddInt64(&l.totalWait, wait)
+	for {
+		current := atomic.LoadInt64(&l.maxWait)
+		if wait <= current || atomic.CompareAndSwapInt64(&l.maxWait, current, wait) {
+			break
+		}
+	}
+
+	return func() {
+		atomic.AddInt64(&l.inFlight, -1)
+		<-l.slots
+	}
+}
+
+func (l *opLimiter) stats() OperationStats {
+	if l == nil {
+		return OperationStats{}
+	}
+
+	stats := OperationStats{
+		MaxConcurrent:   cap(l.slots),
+		InFlight:        atomic.LoadInt64(&l.inFlight),
+		Queued:          atomic.LoadInt64(&l.queued),
+		TotalOperations: atomic.LoadInt64(&l.totalOps),
+		MaxWait:         time.Duration(atomic.LoadInt64(&l.maxWait)),
+	}
+	if stats.TotalOperations > 0 {
+		stats.AverageWait = time.Duration(atomic.LoadInt64(&l.totalWait) / stats.TotalOperations)
+	}
+	return stats
+}
+
+// OperationStats returns the queue depth and wait times of EigenDA operations
+func (s *DataAvailabilityService) OperationStats() OperationStats {
+	return s.limiter.stats()
+}
@@ -18,7 +18,12 @@ import (
 func SetupGlobalDAService(natsURL string) error {
 	globalDAServiceOnce.Do(func() {
 		var service *DataAvailabilityService
-		service, globalDAServiceErr = NewDataAvailabilityService(natsURL)
+		if useMemoryBackend() {
+			log.Println("WARNING: using the in-memory DA backend; offchain data is NOT durable and is lost when the process exits")
+			service, globalDAServiceErr = NewDataAvailabilityServiceWithBackend(natsURL, NewMemoryBackend())
+		} else {
+			service, globalDAServiceErr = NewDataAvailabilityService(natsURL)
+		}
 		if globalDAServiceErr != nil {
 			log.Printf("Failed to initialize global DA service: %v", globalDAServiceErr)
 			return
@@ -44,6 +49,11 @@ func SetupGlobalDAService(natsURL string) error {
 			return
 		}
 		log.Println("Master index initialized successfully")
+
+		// Pick up blobs still waiting for confirmation before a restart
+		for _, ref := range pendingBlobReferences() {
+			trackPendingBlob(ref)
+		}
 	})
 
 	return globalDAServiceErr
@@ -65,13 +75,8 @@ func CloseGlobalDAService() {
 	}
 }
 
-// NewDataAvailabilityService creates a new DA service
+// NewDataAvailabilityService creates a new DA service backed by EigenDA
 func NewDataAvailabilityService(natsURL string) (*DataAvailabilityService, error) {
-	messenger, err := communication.NewMessenger(natsURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create messenger: %w", err)
-	}
-
 	// Get authentication key from environment
 	eigendaAuthKey, ok := os.LookupEnv("EIGENDA_AUTH_PK")
 	if !ok {
@@ -97,12 +102,8 @@ func NewDataAvailabilityService(natsURL string) (*DataAvailabilityService, error
 	signer := auth.NewLocalBlobRequestSigner("0x" + eigendaAuthKey)
 
 	// Configuration for the disperser client
-	config := &clients.Config{
-		Hostname:          EIGENDA_HOST,
-		Port:              EIGENDA_PORT,
-		Timeout:           EIGENDA_REQUEST_TIMEOUT,
-		UseSecureGrpcFlag: true, // should be true for production
-	}
+	config := disperserConfigFromEnv()
+	log.Printf("Using EigenDA disperser %s:%s (TLS %t)", config.Hostname, config.Port, config.UseSecureGrpcFlag)
 
 	// Create the disperser client
 	client, err := clients.NewDisperserClient(config, signer)
@@ -110,14 +111,29 @@ func NewDataAvailabilityService(natsURL string) (*DataAvailabilityService, error
 		return nil, fmt.Errorf("failed to create disperser client: %w", err)
 	}
 
-	service := &DataAvailabilityService{
-		messenger: messenger,
-		client:    client,
+	maxOps := maxConcurrentOpsFromEnv()
+	log.Printf("EigenDA operations limited to %d concurrent calls", maxOps)
+
+	limiter := newOpLimiter(maxOps)
+	service, err := NewDataAvailabilityServiceWithBackend(natsURL, &eigenDABackend{client: client, limiter: limiter})
+	if err != nil {
+		return nil, err
 	}
+	service.limiter = limiter
 
 	return service, nil
 }
 
+// NewDataAvailabilityServiceWithBackend creates a DA service that stores data in backend
+func NewDataAvailabilityServiceWithBackend(natsURL string, backend DABackend) (*DataAvailabilityService, error) {
+	messenger, err := communication.NewMessenger(natsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create messenger: %w", err)
+	}
+
+	return &DataAvailabilityService{messenger: messenger, backend: backend}, nil
+}
+
 // SetupSubscriptions sets up NATS subscriptions for DA events
 func (s *DataAvailabilityService) SetupSubscriptions(dataStoredHandler, dataRetrievedHandler func(dataID string)) error {
 	// Subscribe to data stored events
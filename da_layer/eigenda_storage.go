@@ -1,9 +1,11 @@
 package da
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -27,33 +29,51 @@ type MasterIndexConfig struct {
 
 // OffchainData represents the off-chain data stored in EigenDA for a specific chain.
 type OffchainData struct {
-	ChainID         string                 `json:"chainId"`
-	BlockHash       string                 `json:"blockHash"`   // Block hash (used as thread ID)
-	BlockHeight     int                    `json:"blockHeight"` // Block height
-	Discussions     []consensus.Discussion `json:"discussions"`
-	Votes           []Vote                 `json:"votes"`
-	Outcome         string                 `json:"outcome"`
-	AgentIdentities map[string]string      `json:"agentIdentities"`
-	Timestamp       int64                  `json:"timestamp"` // When the data was created
+	ChainID         string                    `json:"chainId"`
+	BlockHash       string                    `json:"blockHash"`             // Block hash (used as thread ID)
+	ContentHash     string                    `json:"contentHash,omitempty"` // See core.Block.ContentHash
+	BlockHeight     int                       `json:"blockHeight"`           // Block height
+	Discussions     []consensus.Discussion    `json:"discussions"`
+	Votes           []Vote                    `json:"votes"`
+	Outcome         string                    `json:"outcome"`
+	AgentIdentities map[string]string         `json:"agentIdentities"`
+	Timestamp       int64                     `json:"timestamp"`                // When the data was created
+	Provenance      *consensus.Provenance     `json:"provenance,omitempty"`     // How the decision was produced
+	ValidatorVotes  []consensus.ValidatorVote `json:"validatorVotes,omitempty"` // Final votes with their reasons
 }
 
 // Vote represents an agent's vote off-chain.
 type Vote struct {
-	AgentID      string `json:"agentId"`
-	VoteDecision string `json:"voteDecision"`
-	Timestamp    int64  `json:"timestamp"`
+	AgentID      string   `json:"agentId"`
+	VoteDecision string   `json:"voteDecision"`
+	Timestamp    int64    `json:"timestamp"`
+	Round        int      `json:"round,omitempty"`       // Discussion round, one past the chain's discussion rounds for the final vote
+	Researched   bool     `json:"researched"`            // Whether web research informed the vote
+	Confidence   *float64 `json:"confidence,omitempty"`  // Final votes only, the validator's stated confidence (0-1)
+	VotingPower  float64  `json:"votingPower,omitempty"` // Final votes only, the validator's voting power
 }
 
 // BlobReference stores the mapping between EigenDA blob ID, chain ID, and block information
 type BlobReference struct {
-	BlobID      string `json:"blobId"`      // EigenDA blob ID
-	ChainID     string `json:"chainId"`     // Chain ID
-	BlockHash   string `json:"blockHash"`   // Block hash (used as thread ID)
-	BlockHeight int    `json:"blockHeight"` // Block height
-	Timestamp   int64  `json:"timestamp"`   // When the blob was stored
-	Outcome     string `json:"outcome"`     // Outcome of the consensus (accepted/rejected)
+	BlobID      string `json:"blobId"`                // EigenDA blob ID
+	ChainID     string `json:"chainId"`               // Chain ID
+	BlockHash   string `json:"blockHash"`             // Block hash (used as thread ID)
+	ContentHash string `json:"contentHash,omitempty"` // Stable across re-proposals, see core.Block.ContentHash
+	BlockHeight int    `json:"blockHeight"`           // Block height
+	Timestamp   int64  `json:"timestamp"`             // When the blob was stored
+	Outcome     string `json:"outcome"`               // Outcome of the consensus (accepted/rejected)
+	// Status of the blob in the DA backend; empty for references stored before it was tracked
+	Status string `json:"status,omitempty"`
 }
 
+// Blob statuses reported by DA backends and recorded on blob references
+const (
+	BlobStatusPending   = "PENDING"   // Dispersed, waiting for the backend to confirm it
+	BlobStatusConfirmed = "CONFIRMED" // Confirmed and retrievable
+	BlobStatusFinalized = "FINALIZED" // Confirmed and final
+	BlobStatusFailed    = "FAILED"    // Dispersal failed or was never confirmed
+)
+
 // MasterIndex represents the master index of all blob references
 type MasterIndex struct {
 	ChainIndices map[string]ChainIndex `json:"chainIndices"` // chainID -> ChainIndex
@@ -94,10 +114,19 @@ func StoreBlobReference(ref BlobReference) error {
 	blobReferences[ref.ChainID][ref.BlockHash] = ref
 	blobReferencesLock.Unlock()
 
+	// Persist locally so the reference survives a restart
+	if err := persistBlobReference(ref); err != nil {
+		log.Printf("Failed to persist blob reference for block %s: %v", ref.BlockHash, err)
+	}
+
 	// Update master index
 	masterIndexLock.Lock()
 	defer masterIndexLock.Unlock()
 
+	if masterIndex.ChainIndices == nil {
+		masterIndex.ChainIndices = make(map[string]ChainIndex)
+	}
+
 	// Initialize chain index if it doesn't exist
 	if _, ok := masterIndex.ChainIndices[ref.ChainID]; !ok {
 		masterIndex.ChainIndices[ref.ChainID] = ChainIndex{
@@ -118,14 +147,9 @@ func StoreBlobReference(ref BlobReference) error {
 
 // GetBlobReferencesForChain returns all blob references for a specific chain
 func GetBlobReferencesForChain(chainID string) []BlobReference {
-	masterIndexLock.RLock()
-	defer masterIndexLock.RUnlock()
-
 	var refs []BlobReference
-	if chainIndex, ok := masterIndex.ChainIndices[chainID]; ok {
-		for _, ref := range chainIndex.BlobReferences {
-			refs = append(refs, ref)
-		}
+	for _, ref := range chainBlobReferences(chainID) {
+		refs = append(refs, ref)
 	}
 
 	// Sort by block height (descending)
@@ -139,31 +163,41 @@ func GetBlobReferencesForChain(chainID string) []BlobReference {
 // GetBlobReferenceByBlockHash returns the blob reference for a specific block hash
 func GetBlobReferenceByBlockHash(chainID, blockHash string) (BlobReference, bool) {
 	masterIndexLock.RLock()
-	defer masterIndexLock.RUnlock()
-
 	if chainIndex, ok := masterIndex.ChainIndices[chainID]; ok {
 		if ref, ok := chainIndex.BlobReferences[blockHash]; ok {
+			masterIndexLock.RUnlock()
 			return ref, true
 		}
 	}
-	return BlobReference{}, false
+	masterIndexLock.RUnlock()
+
+	return storedBlobReference(chainID, blockHash)
 }
 
 // GetBlobReferenceByHeight returns the blob reference for a specific block height
 func GetBlobReferenceByHeight(chainID string, height int) (BlobReference, bool) {
-	masterIndexLock.RLock()
-	defer masterIndexLock.RUnlock()
-
-	if chainIndex, ok := masterIndex.ChainIndices[chainID]; ok {
-		for _, ref := range chainIndex.BlobReferences {
-			if ref.BlockHeight == height {
-				return ref, true
-			}
+	for _, ref := range chainBlobReferences(chainID) {
+		if ref.BlockHeight == height {
+			return ref, true
 		}
 	}
 	return BlobReference{}, false
 }
 
+// GetBlobReferenceByContentHash returns the blob reference for a block's content hash, so a
+// block's data can be found whichever proposal of its transactions reached consensus. When
+// the same content was stored more than once, the most recently stored reference wins.
+func GetBlobReferenceByContentHash(chainID, contentHash string) (BlobReference, bool) {
+	var found BlobReference
+	ok := false
+	for _, ref := range chainBlobReferences(chainID) {
+		if ref.ContentHash == contentHash && (!ok || ref.Timestamp > found.Timestamp) {
+			found, ok = ref, true
+		}
+	}
+	return found, ok
+}
+
 // GetBlobReferenceByBlobID returns the blob reference for a specific blob ID
 func GetBlobReferenceByBlobID(blobID string) (BlobReference, bool) {
 	masterIndexLock.RLock()
@@ -180,8 +214,11 @@ func GetBlobReferenceByBlobID(blobID string) (BlobReference, bool) {
 }
 
 // SaveOffchainData stores off-chain data into EigenDA using the global DataAvailabilityService.
-// It marshals the off-chain data into a map and then stores it via StoreData.
-func SaveOffchainData(data OffchainData) (string, error) {
+// It marshals the off-chain data into a map and then stores it via StoreData. It returns as
+// soon as the blob is dispersed: the blob reference is stored as pending and a background
+// poller records its status once EigenDA confirms it. The data is readable from the local
+// cache in the meantime.
+func SaveOffchainData(ctx context.Context, data OffchainData) (string, error) {
 	// Get the global DA service
 	svc := GetGlobalDAService()
 	if svc == nil {
@@ -202,32 +239,39 @@ func SaveOffchainData(data OffchainData) (string, error) {
 	dataMap := map[string]interface{}{
 		"chainId":         data.ChainID,
 		"blockHash":       data.BlockHash,
+		"contentHash":     data.ContentHash,
 		"blockHeight":     data.BlockHeight,
 		"discussions":     data.Discussions,
 		"votes":           data.Votes,
 		"outcome":         data.Outcome,
 		"agentIdentities": data.AgentIdentities,
 		"timestamp":       data.Timestamp,
+		"provenance":      data.Provenance,
 		"type":            "offchainData", // Add a type field to identify this as offchain data
 	}
 
 	// Store the data in EigenDA
-	blobID, err := svc.StoreData(dataMap)
+	blobID, err := svc.StoreData(ctx, dataMap)
 	if err != nil {
 		return "", err
 	}
+	cacheOffchainData(blobID, data)
 
 	// Store the blob reference
 	ref := BlobReference{
 		BlobID:      blobID,
 		ChainID:     data.ChainID,
 		BlockHash:   data.BlockHash,
+		ContentHash: data.ContentHash,
 		BlockHeight: data.BlockHeight,
 		Timestamp:   data.Timestamp,
 		Outcome:     data.Outcome,
+		Status:      BlobStatusPending,
 	}
 
-	if err := StoreBlobReference(ref); err != nil {
+	err = StoreBlobReference(ref)
+	trackPendingBlob(ref)
+	if err != nil {
 		return blobID, fmt.Errorf("data stored but failed to update master index: %w", err)
 	}
 
@@ -235,8 +279,13 @@ func SaveOffchainData(data OffchainData) (string, error) {
 }
 
 // GetOffchainData retrieves off-chain data from EigenDA using the global DataAvailabilityService.
-// It takes a dataID and returns the corresponding OffchainData.
+// It takes a dataID and returns the corresponding OffchainData, checking the local cache first.
 func GetOffchainData(dataID string) (*OffchainData, error) {
+	// Recently saved or read data is served from the local cache
+	if cached, ok := cachedOffchainData(dataID); ok {
+		return cached, nil
+	}
+
 	// Get the global DA service
 	svc := GetGlobalDAService()
 	if svc == nil {
@@ -259,23 +308,34 @@ func GetOffchainData(dataID string) (*OffchainData, error) {
 	if err := json.Unmarshal(jsonData, &offchainData); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal offchain data: %w", err)
 	}
+	cacheOffchainData(dataID, offchainData)
 
 	return &offchainData, nil
 }
 
-// ListOffchainData lists all off-chain data for a specific chain.
-// This is a placeholder function that would need to be implemented with a proper
-// indexing mechanism, as EigenDA doesn't provide a native way to list or query data.
+// ListOffchainData returns the blob IDs of a chain's off-chain data, lowest block first.
+// EigenDA can't list blobs itself, so this reads the master index and the node's local
+// blob references; it never returns an error.
 func ListOffchainData(chainID string) ([]string, error) {
-	// Get the global DA service
-	svc := GetGlobalDAService()
-	if svc == nil {
-		return nil, fmt.Errorf("global DA service not initialized")
+	refs := ListOffchainDataDetailed(chainID)
+	blobIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		blobIDs = append(blobIDs, ref.BlobID)
 	}
+	return blobIDs, nil
+}
 
-	// In a real implementation, you would need to maintain an index of dataIDs
-	// for each chain, possibly in a database or another storage mechanism.
-	return nil, fmt.Errorf("listing offchain data is not implemented")
+// ListOffchainDataDetailed returns the blob references of a chain's off-chain data, sorted
+// by block height from lowest to highest
+func ListOffchainDataDetailed(chainID string) []BlobReference {
+	refs := make([]BlobReference, 0)
+	for _, ref := range chainBlobReferences(chainID) {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].BlockHeight < refs[j].BlockHeight
+	})
+	return refs
 }
 
 // InitializeMasterIndex loads the master index from EigenDA or creates a new one
@@ -365,7 +425,7 @@ func saveMasterIndex() error {
 	}
 
 	// Store the data in EigenDA
-	blobID, err := svc.StoreData(dataMap)
+	blobID, err := svc.StoreData(context.Background(), dataMap)
 	if err != nil {
 		return fmt.Errorf("failed to store master index: %w", err)
 	}
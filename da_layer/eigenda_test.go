@@ -1,6 +1,7 @@
 package da
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -56,7 +57,7 @@ func TestEigenDAIntegration(t *testing.T) {
 
 	// Store data
 	fmt.Println("Storing data in EigenDA...")
-	dataID, err := service.StoreData(testData)
+	dataID, err := service.StoreData(context.Background(), testData)
 	if err != nil {
 		t.Fatalf("Error storing data: %v", err)
 	}
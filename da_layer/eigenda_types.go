@@ -4,7 +4,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/NethermindEth/chaoschain-launchpad/communication"
 )
 
@@ -16,12 +15,17 @@ const (
 	SUBJECT_DATA_STORED    = "data.stored"
 	SUBJECT_DATA_RETRIEVED = "data.retrieved"
 
-	// EigenDA configuration
+	// EigenDA configuration; the disperser host and port are defaults for
+	// EIGENDA_HOST and EIGENDA_PORT
 	EIGENDA_HOST            = "disperser-holesky.eigenda.xyz"
 	EIGENDA_PORT            = "443"
 	EIGENDA_REQUEST_TIMEOUT = 30 * time.Second
 	EIGENDA_POLL_INTERVAL   = 5 * time.Second
-	EIGENDA_MAX_WAIT_TIME   = 30 * time.Minute
+	EIGENDA_MAX_WAIT_TIME   = 30 * time.Minute // Pending blobs not confirmed by then are marked failed
+
+	// Default number of disperse/retrieve calls allowed in flight at once,
+	// override with EIGENDA_MAX_CONCURRENT_OPS
+	EIGENDA_MAX_CONCURRENT_OPS = 4
 
 	// EigenDA API endpoints
 	EIGENDA_DISPERSE_URL = "https://disperser-holesky.eigenda.xyz:443/v1/blob"
@@ -36,8 +40,10 @@ var (
 	globalDAServiceErr  error
 )
 
-// DataAvailabilityService handles interactions with EigenDA
+// DataAvailabilityService stores offchain data in a DA backend, EigenDA by default, and
+// announces stored and retrieved data over NATS
 type DataAvailabilityService struct {
 	messenger *communication.Messenger
-	client    clients.DisperserClient
+	backend   DABackend
+	limiter   *opLimiter // Bounds concurrent EigenDA disperse/retrieve operations; nil for other backends
 }
@@ -25,7 +25,7 @@ func retry(attempts int, sleep time.Duration, f func() error) error {
 }
 
 // removeNullBytesPadding removes null bytes padding from the end of the data
-func (s *DataAvailabilityService) removeNullBytesPadding(data []byte) []byte {
+func removeNullBytesPadding(data []byte) []byte {
 	// Find the first non-null byte from the beginning
 	var startPos int
 	for startPos = 0; startPos < len(data); startPos++ {
@@ -0,0 +1,45 @@
+package da
+
+import (
+	"time"
+)
+
+// ChainArchive is every block's offchain data for a chain, as written by ExportChainArchive
+type ChainArchive struct {
+	ChainID    string          `json:"chainId"`
+	ExportedAt int64           `json:"exportedAt"`
+	Blocks     []ArchivedBlock `json:"blocks"`           // Oldest block first
+	Failed     []FailedBlob    `json:"failed,omitempty"` // Blobs that couldn't be fetched
+}
+
+// ArchivedBlock is one block's discussions, votes and outcome, with the blob they came from
+type ArchivedBlock struct {
+	BlobID string `json:"blobId"`
+	OffchainData
+}
+
+// FailedBlob records a blob left out of an archive and why
+type FailedBlob struct {
+	BlobReference
+	Error string `json:"error"`
+}
+
+// ExportChainArchive fetches the offchain data behind every blob reference of a chain.
+// Blobs that can't be fetched don't stop the export; they are listed in Failed instead.
+func ExportChainArchive(chainID string) ChainArchive {
+	archive := ChainArchive{
+		ChainID:    chainID,
+		ExportedAt: time.Now().Unix(),
+		Blocks:     []ArchivedBlock{},
+	}
+
+	for _, ref := range ListOffchainDataDetailed(chainID) {
+		data, err := GetOffchainData(ref.BlobID)
+		if err != nil {
+			archive.Failed = append(archive.Failed, FailedBlob{BlobReference: ref, Error: err.Error()})
+			continue
+		}
+		archive.Blocks = append(archive.Blocks, ArchivedBlock{BlobID: ref.BlobID, OffchainData: *data})
+	}
+	return archive
+}
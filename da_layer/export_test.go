@@ -0,0 +1,30 @@
+package da
+
+import "testing"
+
+func TestExportChainArchiveRecordsUnfetchableBlobs(t *testing.T) {
+	withCacheStorage(t)
+	for _, ref := range []BlobReference{
+		{BlobID: "blob-2", ChainID: "export-chain", BlockHash: "hash-2", BlockHeight: 2, Outcome: "rejected"},
+		{BlobID: "blob-1", ChainID: "export-chain", BlockHash: "hash-1", BlockHeight: 1, Outcome: "accepted"},
+		{BlobID: "blob-3", ChainID: "export-chain", BlockHash: "hash-3", BlockHeight: 3, Outcome: "accepted"},
+	} {
+		if err := persistBlobReference(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// No DA service is running, so only the cached blobs can be fetched
+	cacheOffchainData("blob-1", OffchainData{ChainID: "export-chain", BlockHash: "hash-1", BlockHeight: 1, Outcome: "accepted"})
+	cacheOffchainData("blob-3", OffchainData{ChainID: "export-chain", BlockHash: "hash-3", BlockHeight: 3, Outcome: "accepted"})
+
+	archive := ExportChainArchive("export-chain")
+	if len(archive.Blocks) != 2 || archive.Blocks[0].BlobID != "blob-1" || archive.Blocks[1].BlobID != "blob-3" {
+		t.Fatalf("expected blobs 1 and 3 oldest first, got %+v", archive.Blocks)
+	}
+	if archive.Blocks[0].Outcome != "accepted" {
+		t.Fatalf("archived block lost its data: %+v", archive.Blocks[0])
+	}
+	if len(archive.Failed) != 1 || archive.Failed[0].BlobID != "blob-2" || archive.Failed[0].Error == "" {
+		t.Fatalf("expected blob 2 recorded as failed, got %+v", archive.Failed)
+	}
+}
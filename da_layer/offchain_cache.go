@@ -0,0 +1,160 @@
+package da
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+// Defaults for the local offchain data cache
+const (
+	DefaultOffchainCacheTTL        = 7 * 24 * time.Hour
+	DefaultOffchainCacheMaxEntries = 1000
+)
+
+// Offchain data saved to or read from EigenDA is kept in the node's local storage, so
+// recent discussions read back instantly and stay readable while EigenDA is slow or down.
+// Blobs never change once stored, so entries only leave the cache when they outlive the
+// TTL or the cache grows past its maximum size.
+var (
+	offchainCacheTTL        = offchainCacheTTLFromEnv()
+	offchainCacheMaxEntries = offchainCacheMaxEntriesFromEnv()
+	offchainCacheMu         sync.Mutex // Serializes writes and evictions
+)
+
+const offchainCachePrefix = "offchain:"
+
+type offchainCacheEntry struct {
+	CachedAt int64        `json:"cachedAt"` // Unix nanoseconds
+	Data     OffchainData `json:"data"`
+}
+
+func offchainCacheKey(blobID string) []byte {
+	return []byte(offchainCachePrefix + blobID)
+}
+
+// offchainCacheTTLFromEnv reads OFFCHAIN_CACHE_TTL, falling back to the default
+func offchainCacheTTLFromEnv() time.Duration {
+	value := os.Getenv("OFFCHAIN_CACHE_TTL")
+	if value == "" {
+		return DefaultOffchainCacheTTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("Invalid OFFCHAIN_CACHE_TTL %q, using default of %s", value, DefaultOffchainCacheTTL)
+		return DefaultOffchainCacheTTL
+	}
+	return ttl
+}
+
+// offchainCacheMaxEntriesFromEnv reads OFFCHAIN_CACHE_MAX_ENTRIES, falling back to the default
+func offchainCacheMaxEntriesFromEnv() int {
+	value := os.Getenv("OFFCHAIN_CACHE_MAX_ENTRIES")
+	if value == "" {
+		return DefaultOffchainCacheMaxEntries
+	}
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		log.Printf("Invalid OFFCHAIN_CACHE_MAX_ENTRIES %q, using default of %d", value, DefaultOffchainCacheMaxEntries)
+		return DefaultOffchainCacheMaxEntries
+	}
+	return max
+}
+
+// cacheOffchainData stores data under its blob ID, evicting expired and then the oldest
+// entries past the size cap. Without local storage configured it does nothing.
+func cacheOffchainData(blobID string, data OffchainData) {
+	store := storage.Default()
+	if store == nil {
+		return
+	}
+
+	encoded, err := json.Marshal(offchainCacheEntry{CachedAt: time.Now().UnixNano(), Data: data})
+	if err != nil {
+		log.Printf("Failed to encode offchain data %s for the local cache: %v", blobID, err)
+		return
+	}
+
+	offchainCacheMu.Lock()
+	defer offchainCacheMu.Unlock()
+	if err := store.Set(offchainCacheKey(blobID), encoded); err != nil {
+		log.Printf("Failed to cache offchain data %s: %v", blobID, err)
+		return
+	}
+	evictOffchainCache(store)
+}
+
+// cachedOffchainData returns the cached data for a blob ID, if present and not expired
+func cachedOffchainData(blobID string) (*OffchainData, bool) {
+	store := storage.Default()
+	if store == nil {
+		return nil, false
+	}
+
+	encoded, err := store.Get(offchainCacheKey(blobID))
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Failed to read offchain data %s from the local cache: %v", blobID, err)
+		}
+		return nil, false
+	}
+
+	var entry offchainCacheEntry
+	if err := json.Unmarshal(encoded, &entry); err != nil {
+		log.Printf("Dropping unreadable cached offchain data %s: %v", blobID, err)
+		store.Delete(offchainCacheKey(blobID))
+		return nil, false
+	}
+	if time.Since(time.Unix(0, entry.CachedAt)) > offchainCacheTTL {
+		store.Delete(offchainCacheKey(blobID))
+		return nil, false
+	}
+	return &entry.Data, true
+}
+
+// evictOffchainCache drops expired entries, then the oldest ones until the cache fits its
+// size cap. Callers hold offchainCacheMu.
+func evictOffchainCache(store storage.Storage) {
+	type cached struct {
+		key      []byte
+		cachedAt int64
+	}
+	var live []cached
+	var expired [][]byte
+	cutoff := time.Now().Add(-offchainCacheTTL).UnixNano()
+
+	err := store.Iterate([]byte(offchainCachePrefix), func(key, value []byte) error {
+		var entry struct {
+			CachedAt int64 `json:"cachedAt"`
+		}
+		if err := json.Unmarshal(value, &entry); err != nil || entry.CachedAt < cutoff {
+			expired = append(expired, key)
+			return nil
+		}
+		live = append(live, cached{key: key, cachedAt: entry.CachedAt})
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to scan the offchain data cache: %v", err)
+		return
+	}
+
+	if len(live) > offchainCacheMaxEntries {
+		sort.Slice(live, func(i, j int) bool { return live[i].cachedAt < live[j].cachedAt })
+		for _, entry := range live[:len(live)-offchainCacheMaxEntries] {
+			expired = append(expired, entry.key)
+		}
+	}
+	for _, key := range expired {
+		if err := store.Delete(key); err != nil {
+			log.Printf("Failed to evict %s from the offchain data cache: %v", key, err)
+		}
+	}
+}
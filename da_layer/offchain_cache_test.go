@@ -0,0 +1,70 @@
+package da
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+func withCacheStorage(t *testing.T) storage.Storage {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDefault(store)
+	t.Cleanup(func() {
+		storage.SetDefault(nil)
+		store.Close()
+	})
+	return store
+}
+
+func TestGetOffchainDataServesCachedDataWithoutEigenDA(t *testing.T) {
+	withCacheStorage(t)
+	cacheOffchainData("blob-1", OffchainData{ChainID: "cache-chain", BlockHash: "abc", Outcome: "accepted"})
+
+	// No DA service is running, so this can only be answered from the cache
+	data, err := GetOffchainData("blob-1")
+	if err != nil {
+		t.Fatalf("cached data not served: %v", err)
+	}
+	if data.BlockHash != "abc" || data.Outcome != "accepted" {
+		t.Fatalf("unexpected cached data: %+v", data)
+	}
+	if _, err := GetOffchainData("blob-2"); err == nil {
+		t.Fatal("uncached blob served without EigenDA")
+	}
+}
+
+func TestOffchainCacheExpiresEntries(t *testing.T) {
+	withCacheStorage(t)
+	defer func(ttl time.Duration) { offchainCacheTTL = ttl }(offchainCacheTTL)
+	offchainCacheTTL = 20 * time.Millisecond
+
+	cacheOffchainData("blob-1", OffchainData{BlockHash: "abc"})
+	if _, ok := cachedOffchainData("blob-1"); !ok {
+		t.Fatal("fresh entry missing")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := cachedOffchainData("blob-1"); ok {
+		t.Fatal("expired entry still served")
+	}
+}
+
+func TestOffchainCacheEvictsOldestPastMaxEntries(t *testing.T) {
+	withCacheStorage(t)
+	defer func(max int) { offchainCacheMaxEntries = max }(offchainCacheMaxEntries)
+	offchainCacheMaxEntries = 3
+
+	for i := 1; i <= 5; i++ {
+		cacheOffchainData(fmt.Sprintf("blob-%d", i), OffchainData{BlockHeight: i})
+	}
+	for i := 1; i <= 5; i++ {
+		_, ok := cachedOffchainData(fmt.Sprintf("blob-%d", i))
+		if want := i > 2; ok != want {
+			t.Errorf("blob-%d cached = %t, want %t", i, ok, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package mempool
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Defaults for the mempool size limits
+const (
+	DefaultMaxTransactions   = 10000
+	DefaultMaxBytes          = 32 << 20
+	DefaultMaxEphemeralVotes = 10000 // Discussion stances and final votes kept per block
+)
+
+// ErrMempoolFull is returned when a transaction doesn't fit and no pending transaction has a
+// lower fee to make room
+var ErrMempoolFull = errors.New("mempool is full and no pending transaction has a lower fee")
+
+// Option customizes a new mempool
+type Option func(*Mempool)
+
+// WithMaxTransactions caps how many transactions the mempool holds; 0 means unlimited
+func WithMaxTransactions(max int) Option {
+	return func(mp *Mempool) {
+		mp.maxTransactions = max
+	}
+}
+
+// WithMaxBytes caps the JSON encoded size of the pending transactions; 0 means unlimited
+func WithMaxBytes(max int) Option {
+	return func(mp *Mempool) {
+		mp.maxBytes = max
+	}
+}
+
+// WithMaxEphemeralVotes caps how many stances and votes are kept for the block under
+// consensus; further ones are dropped. 0 means unlimited.
+func WithMaxEphemeralVotes(max int) Option {
+	return func(mp *Mempool) {
+		mp.maxEphemeralVotes = max
+	}
+}
+
+// Stats reports how full the mempool is
+type Stats struct {
+	Transactions    int   `json:"transactions"`
+	Bytes           int   `json:"bytes"`
+	MaxTransactions int   `json:"maxTransactions"` // 0 means unlimited
+	MaxBytes        int   `json:"maxBytes"`        // 0 means unlimited
+	Evicted         int64 `json:"evicted"`         // Transactions displaced by higher-fee ones
+	DroppedVotes    int64 `json:"droppedVotes"`    // Stances and votes past the per-block limit
+}
+
+// Stats returns the mempool's current size against its limits
+func (mp *Mempool) Stats() Stats {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return Stats{
+		Transactions:    len(mp.transactions),
+		Bytes:           mp.bytes,
+		MaxTransactions: mp.maxTransactions,
+		MaxBytes:        mp.maxBytes,
+		Evicted:         mp.evicted,
+		DroppedVotes:    mp.droppedVotes,
+	}
+}
+
+// maxTransactionsFromEnv reads MEMPOOL_MAX_TXS, falling back to the default
+func maxTransactionsFromEnv() int {
+	return limitFromEnv("MEMPOOL_MAX_TXS", DefaultMaxTransactions)
+}
+
+// maxBytesFromEnv reads MEMPOOL_MAX_BYTES, falling back to the default
+func maxBytesFromEnv() int {
+	return limitFromEnv("MEMPOOL_MAX_BYTES", DefaultMaxBytes)
+}
+
+func limitFromEnv(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		log.Printf("Invalid %s %q, using default of %d", name, value, def)
+		return def
+	}
+	return limit
+}
+
+// txSize is the JSON encoded size a transaction counts against MaxBytes
+func txSize(tx interface{}) int {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// makeRoom evicts the lowest-fee pending transactions, oldest first among equal fees, until
+// a transaction of the given fee and size fits. Only transactions with a strictly lower fee
+// are evicted; if that isn't enough nothing is evicted and ErrMempoolFull is returned.
+// Callers hold mp.mu.
+func (mp *Mempool) makeRoom(fee uint64, size int) error {
+	fits := func(count, bytes int) bool {
+		return (mp.maxTransactions <= 0 || count < mp.maxTransactions) &&
+			(mp.maxBytes <= 0 || bytes+size <= mp.maxBytes)
+	}
+	count, bytes := len(mp.transactions), mp.bytes
+	if fits(count, bytes) {
+		return nil
+	}
+	if mp.maxBytes > 0 && size > mp.maxBytes {
+		return ErrMempoolFull
+	}
+
+	candidates := make([]string, 0, len(mp.transactions))
+	for id, tx := range mp.transactions {
+		if tx.Fee < fee {
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := mp.transactions[candidates[i]], mp.transactions[candidates[j]]
+		if a.Fee != b.Fee {
+			return a.Fee < b.Fee
+		}
+		return a.Timestamp < b.Timestamp
+	})
+
+	var evict []string
+	for _, id := range candidates {
+		if fits(count, bytes) {
+			break
+		}
+		evict = append(evict, id)
+		count--
+		bytes -= mp.sizes[id]
+	}
+	if !fits(count, bytes) {
+		return ErrMempoolFull
+	}
+
+	for _, id := range evict {
+		mp.drop(id)
+	}
+	mp.evicted += int64(len(evict))
+	mp.logRemovals(evict...)
+	log.Printf("Evicted %d low-fee transactions from the %s mempool", len(evict), mp.chainID)
+	return nil
+}
@@ -0,0 +1,74 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func signedTransaction(t *testing.T, chainID string, fee uint64, timestamp int64) core.Transaction {
+	t.Helper()
+	key, err := core.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := core.Transaction{From: "alice", To: "bob", Amount: 1, Fee: fee, Timestamp: timestamp, ChainID: chainID}
+	if err := tx.SignTransaction(key); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestFullMempoolEvictsLowestFee(t *testing.T) {
+	mp := NewMempool("limit-chain", WithMaxTransactions(3), WithMaxBytes(0))
+	var low core.Transaction
+	for i, fee := range []uint64{5, 1, 1} {
+		tx := signedTransaction(t, "limit-chain", fee, int64(100+i))
+		if fee == 1 && low.Signature == "" {
+			low = tx // The older of the two lowest-fee transactions
+		}
+		if err := mp.AddTransaction(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mp.AddTransaction(signedTransaction(t, "limit-chain", 1, 200)); !errors.Is(err, ErrMempoolFull) {
+		t.Fatalf("equal fee should not displace anything, got %v", err)
+	}
+
+	if err := mp.AddTransaction(signedTransaction(t, "limit-chain", 3, 200)); err != nil {
+		t.Fatalf("higher fee transaction rejected: %v", err)
+	}
+	for _, tx := range mp.GetPendingTransactions() {
+		if tx.Signature == low.Signature {
+			t.Fatal("oldest lowest-fee transaction was not evicted")
+		}
+	}
+	if stats := mp.Stats(); stats.Transactions != 3 || stats.Evicted != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestMaxBytesBoundsMempool(t *testing.T) {
+	first := signedTransaction(t, "bytes-chain", 1, 100)
+	mp := NewMempool("bytes-chain", WithMaxTransactions(0), WithMaxBytes(txSize(first)+10))
+	if err := mp.AddTransaction(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.AddTransaction(signedTransaction(t, "bytes-chain", 0, 101)); !errors.Is(err, ErrMempoolFull) {
+		t.Fatalf("expected the byte limit to reject a lower-fee transaction, got %v", err)
+	}
+	if err := mp.AddTransaction(signedTransaction(t, "bytes-chain", 2, 102)); err != nil {
+		t.Fatalf("higher fee transaction rejected: %v", err)
+	}
+
+	stats := mp.Stats()
+	if stats.Transactions != 1 || stats.Bytes > stats.MaxBytes {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	mp.RemoveTransaction(mp.GetPendingTransactions()[0].Signature)
+	if stats := mp.Stats(); stats.Bytes != 0 {
+		t.Fatalf("bytes not released on removal: %+v", stats)
+	}
+}
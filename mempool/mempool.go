@@ -1,6 +1,9 @@
 package mempool
 
 import (
+	"fmt"
+	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,36 +18,48 @@ var (
 
 // Mempool stores pending transactions before they are added to a block
 type Mempool struct {
-	mu                       sync.Mutex
-	transactions             map[string]core.Transaction
-	expirationSec            int64  // Transactions expire after X seconds
-	chainID                  string // Add chainID to mempool
-	EphemeralBlockHashes     []string
-	EphemeralVotes           []EphemeralVote
-	EphemeralAgentIdentities map[string]string
+	mu                   sync.Mutex
+	transactions         map[string]core.Transaction
+	expirationSec        int64  // Transactions expire after X seconds
+	chainID              string // Add chainID to mempool
+	EphemeralBlockHashes []string
+	ephemeralVotes       []EphemeralVote           // Of the block under consensus, see RecordEphemeralVote
+	agentIdentities      map[string]string         // Agent ID -> name, see SetAgentIdentity
+	ephemeralVoteKeys    map[ephemeralVoteKey]bool // Agent and round of every ephemeralVotes entry
+	wal                  *wal                      // Optional write-ahead log, see EnableWAL
+	sizes                map[string]int            // Encoded size of each pending transaction
+	bytes                int                       // Sum of sizes
+	maxTransactions      int                       // 0 means unlimited
+	maxBytes             int                       // 0 means unlimited
+	evicted              int64
+	maxEphemeralVotes    int   // 0 means unlimited
+	droppedVotes         int64 // Stances and votes dropped for exceeding maxEphemeralVotes
+}
+
+type ephemeralVoteKey struct {
+	agentID string
+	round   int
 }
 
 // EphemeralVote represents a temporary vote stored in the mempool
 type EphemeralVote struct {
-	ID           string `json:"id"` // Unique identifier for the vote
-	AgentID      string `json:"agentId"`
-	VoteDecision string `json:"voteDecision"`
-	Timestamp    int64  `json:"timestamp"`
+	ID           string   `json:"id"` // Unique identifier for the vote
+	AgentID      string   `json:"agentId"`
+	VoteDecision string   `json:"voteDecision"`
+	Timestamp    int64    `json:"timestamp"`
+	Round        int      `json:"round"`                 // Discussion round, one past the chain's discussion rounds for the final vote
+	Researched   bool     `json:"researched"`            // Whether web research informed the vote
+	Confidence   *float64 `json:"confidence,omitempty"`  // Final votes only, the validator's stated confidence
+	VotingPower  float64  `json:"votingPower,omitempty"` // Final votes only, the validator's voting power
 }
 
 // Initialize mempool separately
-func InitMempool(chainID string, timeout int64) *Mempool {
+func InitMempool(chainID string, timeout int64, opts ...Option) *Mempool {
 	mempoolMu.Lock()
 	defer mempoolMu.Unlock()
 
-	mp := &Mempool{
-		transactions:             make(map[string]core.Transaction),
-		expirationSec:            timeout,
-		chainID:                  chainID,
-		EphemeralBlockHashes:     []string{},
-		EphemeralVotes:           []EphemeralVote{},
-		EphemeralAgentIdentities: make(map[string]string),
-	}
+	mp := NewMempool(chainID, opts...)
+	mp.expirationSec = timeout
 	mempools[chainID] = mp
 	return mp
 }
@@ -56,27 +71,64 @@ func GetMempool(chainID string) *Mempool {
 	return mempools[chainID]
 }
 
-// AddTransaction adds a new transaction to the mempool if valid
-func (mp *Mempool) AddTransaction(tx interface{}) bool {
+// AddTransaction adds a new transaction to the mempool if valid. When the mempool is full,
+// lower-fee transactions are evicted to make room; ErrMempoolFull means none could be.
+func (mp *Mempool) AddTransaction(tx interface{}) error {
 	transaction, ok := tx.(core.Transaction)
 	if !ok {
-		return false
+		return fmt.Errorf("unsupported transaction type %T", tx)
 	}
 
 	// Verify transaction belongs to this chain
 	if transaction.ChainID != mp.chainID {
-		return false
+		return fmt.Errorf("transaction chain ID %q does not match mempool chain %q", transaction.ChainID, mp.chainID)
 	}
 
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
 
-	// Ensure transaction is valid before adding
-	if !transaction.VerifyTransaction(transaction.From) {
-		return false
+	// Ensure transaction is validly signed before adding. Legacy transactions signed with a
+	// throwaway key aren't bound to their sender; the chain decides whether to take those.
+	if err := core.VerifySignature(transaction); err != nil {
+		return fmt.Errorf("invalid transaction signature: %v", err)
+	}
+	if err := mp.validateLocked(transaction); err != nil {
+		return err
 	}
 
-	mp.transactions[transaction.Signature] = transaction
+	size := txSize(transaction)
+	if err := mp.makeRoom(transaction.Fee, size); err != nil {
+		return err
+	}
+
+	// Log the transaction before accepting it so it survives a crash
+	if mp.wal != nil {
+		if err := mp.wal.append(walEntry{Op: "add", ID: transaction.Signature, Tx: &transaction}); err != nil {
+			log.Printf("Rejecting transaction, failed to write mempool WAL: %v", err)
+			return fmt.Errorf("failed to write mempool WAL: %v", err)
+		}
+	}
+
+	mp.put(transaction.Signature, transaction, size)
+	return nil
+}
+
+// put stores a transaction and accounts for its size. Callers hold mp.mu.
+func (mp *Mempool) put(id string, tx core.Transaction, size int) {
+	mp.drop(id)
+	mp.transactions[id] = tx
+	mp.sizes[id] = size
+	mp.bytes += size
+}
+
+// drop removes a transaction and its size, reporting whether it was pending. Callers hold mp.mu.
+func (mp *Mempool) drop(id string) bool {
+	if _, exists := mp.transactions[id]; !exists {
+		return false
+	}
+	mp.bytes -= mp.sizes[id]
+	delete(mp.transactions, id)
+	delete(mp.sizes, id)
 	return true
 }
 
@@ -96,7 +148,10 @@ func (mp *Mempool) GetPendingTransactions() []core.Transaction {
 func (mp *Mempool) RemoveTransaction(txID string) {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
-	delete(mp.transactions, txID)
+	if !mp.drop(txID) {
+		return
+	}
+	mp.logRemovals(txID)
 }
 
 // CleanupExpiredTransactions removes old transactions
@@ -105,9 +160,34 @@ func (mp *Mempool) CleanupExpiredTransactions() {
 	defer mp.mu.Unlock()
 
 	now := time.Now().Unix()
+	var removed []string
 	for id, tx := range mp.transactions {
 		if now-tx.Timestamp > mp.expirationSec {
-			delete(mp.transactions, id)
+			mp.drop(id)
+			removed = append(removed, id)
+		}
+	}
+	mp.logRemovals(removed...)
+}
+
+// logRemovals prunes transactions from the WAL, compacting it once removals pile up.
+// Callers hold mp.mu.
+func (mp *Mempool) logRemovals(ids ...string) {
+	if mp.wal == nil || len(ids) == 0 {
+		return
+	}
+
+	entries := make([]walEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = walEntry{Op: "remove", ID: id}
+	}
+	if err := mp.wal.append(entries...); err != nil {
+		log.Printf("Failed to write mempool WAL: %v", err)
+	}
+
+	if mp.wal.needsCompaction() {
+		if err := mp.wal.rewrite(mp.transactions); err != nil {
+			log.Printf("Failed to compact mempool WAL: %v", err)
 		}
 	}
 }
@@ -119,15 +199,25 @@ func (mp *Mempool) Size() int {
 	return len(mp.transactions)
 }
 
-// NewMempool creates a new mempool instance
-func NewMempool(chainID string) *Mempool {
-	return &Mempool{
-		transactions:             make(map[string]core.Transaction),
-		chainID:                  chainID,
-		EphemeralBlockHashes:     []string{},
-		EphemeralVotes:           []EphemeralVote{},
-		EphemeralAgentIdentities: make(map[string]string),
+// NewMempool creates a new mempool instance. Its size limits default to MEMPOOL_MAX_TXS and
+// MEMPOOL_MAX_BYTES, or DefaultMaxTransactions and DefaultMaxBytes, unless opts override them.
+func NewMempool(chainID string, opts ...Option) *Mempool {
+	mp := &Mempool{
+		transactions:         make(map[string]core.Transaction),
+		sizes:                make(map[string]int),
+		chainID:              chainID,
+		EphemeralBlockHashes: []string{},
+		ephemeralVotes:       []EphemeralVote{},
+		agentIdentities:      make(map[string]string),
+		maxTransactions:      maxTransactionsFromEnv(),
+		maxBytes:             maxBytesFromEnv(),
+		maxEphemeralVotes:    DefaultMaxEphemeralVotes,
 	}
+	for _, opt := range opts {
+		opt(mp)
+	}
+	mp.enableWALFromEnv()
+	return mp
 }
 
 // ClearTemporaryData resets temporary data after block finalization
@@ -135,6 +225,79 @@ func (mp *Mempool) ClearTemporaryData() {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
 	mp.EphemeralBlockHashes = []string{}
-	mp.EphemeralVotes = []EphemeralVote{}
-	mp.EphemeralAgentIdentities = make(map[string]string)
+	mp.ephemeralVotes = []EphemeralVote{}
+	mp.ephemeralVoteKeys = nil
+	mp.agentIdentities = make(map[string]string)
+}
+
+// RecordEphemeralVote stores a vote unless the agent already has one for that round, and
+// reports whether it was stored. Stances arrive more than once (redeliveries, and over both
+// the discussion and vote subjects), so keying by agent and round counts each one once.
+// Once the block holds maxEphemeralVotes, further votes are dropped.
+func (mp *Mempool) RecordEphemeralVote(vote EphemeralVote) bool {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	key := ephemeralVoteKey{vote.AgentID, vote.Round}
+	if mp.ephemeralVoteKeys == nil {
+		mp.ephemeralVoteKeys = make(map[ephemeralVoteKey]bool)
+	}
+	if mp.ephemeralVoteKeys[key] {
+		return false
+	}
+	if mp.maxEphemeralVotes > 0 && len(mp.ephemeralVotes) >= mp.maxEphemeralVotes {
+		if mp.droppedVotes++; mp.droppedVotes%100 == 1 {
+			log.Printf("Mempool for chain %s holds %d votes for the current block, dropping vote of %s in round %d (%d dropped so far)",
+				mp.chainID, len(mp.ephemeralVotes), vote.AgentID, vote.Round, mp.droppedVotes)
+		}
+		return false
+	}
+	mp.ephemeralVoteKeys[key] = true
+	mp.ephemeralVotes = append(mp.ephemeralVotes, vote)
+	return true
+}
+
+// GetEphemeralVotes returns a copy of the votes recorded for the current block
+func (mp *Mempool) GetEphemeralVotes() []EphemeralVote {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	votes := make([]EphemeralVote, len(mp.ephemeralVotes))
+	copy(votes, mp.ephemeralVotes)
+	return votes
+}
+
+// SetAgentIdentity sets the name shown for an agent in the current block's offchain data
+func (mp *Mempool) SetAgentIdentity(agentID, name string) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.agentIdentities[agentID] = name
+}
+
+// GetEphemeralAgentIdentities returns a copy of the agent names stored for the current block
+func (mp *Mempool) GetEphemeralAgentIdentities() map[string]string {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	identities := make(map[string]string, len(mp.agentIdentities))
+	for id, name := range mp.agentIdentities {
+		identities[id] = name
+	}
+	return identities
+}
+
+// EphemeralVoteTally counts the support and oppose decisions recorded for a round
+func (mp *Mempool) EphemeralVoteTally(round int) (support, oppose int) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	for _, v := range mp.ephemeralVotes {
+		if v.Round != round {
+			continue
+		}
+		switch strings.ToLower(v.VoteDecision) {
+		case "support":
+			support++
+		case "oppose":
+			oppose++
+		}
+	}
+	return support, oppose
 }
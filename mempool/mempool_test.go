@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestEphemeralVotesCountEachValidatorOnce(t *testing.T) {
+	mp := NewMempool("tally-chain")
+
+	const validators = 5
+	const rounds = 5
+	finalRound := rounds + 1
+
+	// Every stance is delivered twice, as happens when a vote arrives over both NATS subjects
+	for delivery := 0; delivery < 2; delivery++ {
+		for round := 1; round <= finalRound; round++ {
+			for i := 0; i < validators; i++ {
+				decision := "support"
+				if i == 0 {
+					decision = "oppose"
+				}
+				mp.RecordEphemeralVote(EphemeralVote{
+					ID:           fmt.Sprintf("d-%d-%d-%d", delivery, round, i),
+					AgentID:      fmt.Sprintf("validator-%d", i),
+					VoteDecision: decision,
+					Round:        round,
+				})
+			}
+		}
+	}
+
+	support, oppose := mp.EphemeralVoteTally(finalRound)
+	if support+oppose != validators {
+		t.Errorf("final tally = %d support + %d oppose, want %d votes", support, oppose, validators)
+	}
+	if support != 4 || oppose != 1 {
+		t.Errorf("final tally = %d support, %d oppose, want 4 and 1", support, oppose)
+	}
+	if got := len(mp.GetEphemeralVotes()); got != validators*finalRound {
+		t.Errorf("stored %d votes, want one per validator per round (%d)", got, validators*finalRound)
+	}
+
+	mp.ClearTemporaryData()
+	if !mp.RecordEphemeralVote(EphemeralVote{AgentID: "validator-0", Round: finalRound}) {
+		t.Error("votes from a cleared block should not block the next block's votes")
+	}
+}
+
+func TestEphemeralVotesAreCapped(t *testing.T) {
+	const limit = 50
+	mp := NewMempool("capped-chain", WithMaxEphemeralVotes(limit))
+
+	// Votes and identities arrive concurrently from the NATS subscription callbacks
+	var wg sync.WaitGroup
+	for i := 0; i < 2*limit; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			agentID := fmt.Sprintf("validator-%d", i)
+			mp.RecordEphemeralVote(EphemeralVote{AgentID: agentID, VoteDecision: "support", Round: 1})
+			mp.SetAgentIdentity(agentID, agentID)
+			mp.EphemeralVoteTally(1)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(mp.GetEphemeralVotes()); got != limit {
+		t.Fatalf("stored %d votes, want the limit of %d", got, limit)
+	}
+	if dropped := mp.Stats().DroppedVotes; dropped != limit {
+		t.Fatalf("dropped %d votes, want %d", dropped, limit)
+	}
+	if got := len(mp.GetEphemeralAgentIdentities()); got != 2*limit {
+		t.Fatalf("stored %d identities, want %d", got, 2*limit)
+	}
+
+	mp.ClearTemporaryData()
+	if !mp.RecordEphemeralVote(EphemeralVote{AgentID: "validator-0", Round: 1}) {
+		t.Error("a cleared block should have room for the next block's votes")
+	}
+}
@@ -0,0 +1,54 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Reasons Validate rejects a transaction
+var (
+	ErrMissingField       = errors.New("missing required field")
+	ErrNegativeAmount     = errors.New("amount must not be negative")
+	ErrDuplicateSignature = errors.New("a transaction with this signature is already pending")
+	ErrUnknownType        = errors.New("transaction type not allowed on this chain")
+)
+
+// Validate checks a transaction against the mempool's rules: from, to and signature must be
+// set, the amount must not be negative, no pending transaction may share its signature, and
+// its type must be one of the chain's TransactionTypes.
+func (mp *Mempool) Validate(tx core.Transaction) error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return mp.validateLocked(tx)
+}
+
+// validateLocked is Validate for callers that hold mp.mu
+func (mp *Mempool) validateLocked(tx core.Transaction) error {
+	switch {
+	case tx.From == "":
+		return fmt.Errorf("%w: from", ErrMissingField)
+	case tx.To == "":
+		return fmt.Errorf("%w: to", ErrMissingField)
+	case tx.Signature == "":
+		return fmt.Errorf("%w: signature", ErrMissingField)
+	}
+
+	if tx.Amount < 0 {
+		return ErrNegativeAmount
+	}
+
+	if _, exists := mp.transactions[tx.Signature]; exists {
+		return ErrDuplicateSignature
+	}
+
+	config := core.DefaultChainConfig()
+	if chain := core.GetChain(mp.chainID); chain != nil {
+		config = chain.Config
+	}
+	if !config.AllowsTransactionType(tx.Type) {
+		return fmt.Errorf("%w: %q", ErrUnknownType, tx.Type)
+	}
+	return nil
+}
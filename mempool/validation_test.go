@@ -0,0 +1,59 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func validTransaction(signature string) core.Transaction {
+	return core.Transaction{From: "alice", To: "bob", Amount: 1, Signature: signature, ChainID: "validate-chain"}
+}
+
+func TestValidateRejections(t *testing.T) {
+	mp := NewMempool("validate-chain")
+	mp.transactions["pending-sig"] = validTransaction("pending-sig")
+
+	tests := []struct {
+		name string
+		edit func(tx *core.Transaction)
+		want error
+	}{
+		{"missing from", func(tx *core.Transaction) { tx.From = "" }, ErrMissingField},
+		{"missing to", func(tx *core.Transaction) { tx.To = "" }, ErrMissingField},
+		{"missing signature", func(tx *core.Transaction) { tx.Signature = "" }, ErrMissingField},
+		{"negative amount", func(tx *core.Transaction) { tx.Amount = -1 }, ErrNegativeAmount},
+		{"duplicate signature", func(tx *core.Transaction) { tx.Signature = "pending-sig" }, ErrDuplicateSignature},
+		{"unknown type", func(tx *core.Transaction) { tx.Type = "mint" }, ErrUnknownType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := validTransaction("new-sig")
+			tt.edit(&tx)
+			if err := mp.Validate(tx); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := mp.Validate(validTransaction("new-sig")); err != nil {
+		t.Fatalf("valid transaction rejected: %v", err)
+	}
+	typed := validTransaction("new-sig")
+	typed.Type = core.TxTypeTransfer
+	if err := mp.Validate(typed); err != nil {
+		t.Fatalf("transfer rejected: %v", err)
+	}
+}
+
+func TestAllowedTypesComeFromChainConfig(t *testing.T) {
+	config := core.DefaultChainConfig()
+	config.TransactionTypes = []string{"transfer", "work_review"}
+	if !config.AllowsTransactionType("work_review") || config.AllowsTransactionType("reward") {
+		t.Fatalf("unexpected allowed types for %v", config.TransactionTypes)
+	}
+	if !(core.ChainConfig{}).AllowsTransactionType("") {
+		t.Fatal("configs without types should still accept transfers")
+	}
+}
@@ -0,0 +1,181 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// walCompactThreshold is how many removals accumulate before the log is rewritten
+const walCompactThreshold = 256
+
+// walEntry is one line of the write-ahead log
+type walEntry struct {
+	Op string            `json:"op"` // "add" or "remove"
+	ID string            `json:"id"`
+	Tx *core.Transaction `json:"tx,omitempty"`
+}
+
+// wal appends mempool changes to a file so pending transactions survive a restart
+type wal struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	removals int
+}
+
+// EnableWAL makes the mempool durable: pending transactions recorded in dir are replayed
+// into the mempool, and every later addition is logged before it is accepted
+func (mp *Mempool) EnableWAL(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create WAL directory: %v", err)
+	}
+	path := filepath.Join(dir, mp.chainID+".wal")
+
+	pending, err := replayWAL(path)
+	if err != nil {
+		return err
+	}
+
+	w := &wal{path: path}
+	if err := w.rewrite(pending); err != nil {
+		return err
+	}
+
+	mp.mu.Lock()
+	for id, tx := range pending {
+		mp.put(id, tx, txSize(tx))
+	}
+	mp.wal = w
+	mp.mu.Unlock()
+
+	if len(pending) > 0 {
+		log.Printf("Replayed %d pending transactions for chain %s from %s", len(pending), mp.chainID, path)
+	}
+	return nil
+}
+
+// enableWALFromEnv turns on the WAL when MEMPOOL_WAL_DIR is set
+func (mp *Mempool) enableWALFromEnv() {
+	dir := os.Getenv("MEMPOOL_WAL_DIR")
+	if dir == "" {
+		return
+	}
+	if err := mp.EnableWAL(dir); err != nil {
+		log.Printf("Warning: mempool WAL disabled for chain %s: %v", mp.chainID, err)
+	}
+}
+
+// replayWAL rebuilds the pending transaction set recorded in a log file
+func replayWAL(path string) (map[string]core.Transaction, error) {
+	pending := make(map[string]core.Transaction)
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return pending, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open WAL: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry walEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A torn final write from a crash; everything before it is intact
+			log.Printf("Skipping unreadable WAL entry in %s: %v", path, err)
+			continue
+		}
+		switch entry.Op {
+		case "add":
+			if entry.Tx != nil {
+				pending[entry.ID] = *entry.Tx
+			}
+		case "remove":
+			delete(pending, entry.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read WAL: %v", err)
+	}
+	return pending, nil
+}
+
+// append writes entries and syncs them to disk
+func (w *wal) append(entries ...walEntry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := w.file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		if entry.Op == "remove" {
+			w.removals++
+		}
+	}
+	return w.file.Sync()
+}
+
+// needsCompaction reports whether enough removals have piled up to rewrite the log
+func (w *wal) needsCompaction() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.removals >= walCompactThreshold
+}
+
+// rewrite replaces the log with one add entry per pending transaction
+func (w *wal) rewrite(pending map[string]core.Transaction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	tmpPath := w.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create WAL: %v", err)
+	}
+	writer := bufio.NewWriter(tmp)
+	for id, tx := range pending {
+		tx := tx
+		line, err := json.Marshal(walEntry{Op: "add", ID: id, Tx: &tx})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		writer.Write(append(line, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write WAL: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync WAL: %v", err)
+	}
+	tmp.Close()
+
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		return fmt.Errorf("failed to replace WAL: %v", err)
+	}
+	if w.file != nil {
+		w.file.Close()
+	}
+	w.file, err = os.OpenFile(w.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open WAL: %v", err)
+	}
+	w.removals = 0
+	return nil
+}
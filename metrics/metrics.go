@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/NethermindEth/chaoschain-launchpad/ai"
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "chaoschain"
+
+var registry = prometheus.NewRegistry()
+
+// LLM requests are counted as they finish; everything else is read from its source on scrape
+var (
+	llmCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "calls_total",
+		Help:      "LLM requests sent to the provider, by call type and result.",
+	}, []string{"call_type", "result"})
+
+	llmLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "call_duration_seconds",
+		Help:      "Time taken by LLM requests, by call type.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"call_type"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		llmCalls,
+		llmLatency,
+		nodeCollector{},
+	)
+	ai.OnLLMCall(observeLLMCall)
+}
+
+// Handler serves the node's metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func observeLLMCall(call ai.LLMCall) {
+	callType := call.CallType
+	if callType == "" {
+		callType = "other"
+	}
+	result := "ok"
+	if call.Err != nil {
+		result = "error"
+	}
+	llmCalls.WithLabelValues(callType, result).Inc()
+	llmLatency.WithLabelValues(callType).Observe(call.Duration.Seconds())
+}
+
+var (
+	storageOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "storage", "operations_total"),
+		"Operations served by the node's local store, by operation.",
+		[]string{"operation"}, nil)
+	storageErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "storage", "errors_total"),
+		"Operations on the node's local store that failed.",
+		nil, nil)
+	mempoolSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "mempool", "transactions"),
+		"Pending transactions in each chain's mempool.",
+		[]string{"chain_id"}, nil)
+	consensusActiveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "consensus", "active_rounds"),
+		"Blocks currently in consensus across all chains.",
+		nil, nil)
+	consensusQueuedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "consensus", "queued_rounds"),
+		"Blocks waiting for a consensus slot across all chains.",
+		nil, nil)
+	peersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "p2p", "peers"),
+		"Connected peers of each P2P node.",
+		[]string{"chain_id", "node"}, nil)
+)
+
+// nodeCollector reads storage, mempool, consensus and peer state each time it is scraped
+type nodeCollector struct{}
+
+func (nodeCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{storageOpsDesc, storageErrorsDesc, mempoolSizeDesc,
+		consensusActiveDesc, consensusQueuedDesc, peersDesc} {
+		ch <- desc
+	}
+}
+
+func (nodeCollector) Collect(ch chan<- prometheus.Metric) {
+	// Only stores that count their operations are reported; the BadgerDB store does
+	if store, ok := storage.Default().(interface{ Metrics() storage.Metrics }); ok {
+		m := store.Metrics()
+		ch <- prometheus.MustNewConstMetric(storageOpsDesc, prometheus.CounterValue, float64(m.Puts), "put")
+		ch <- prometheus.MustNewConstMetric(storageOpsDesc, prometheus.CounterValue, float64(m.Gets), "get")
+		ch <- prometheus.MustNewConstMetric(storageOpsDesc, prometheus.CounterValue, float64(m.Deletes), "delete")
+		ch <- prometheus.MustNewConstMetric(storageErrorsDesc, prometheus.CounterValue, float64(m.Errors))
+	}
+
+	for _, info := range core.GetAllChains() {
+		if info.Archived {
+			continue
+		}
+		if chain := core.GetChain(info.ChainID); chain != nil && chain.Mempool != nil {
+			ch <- prometheus.MustNewConstMetric(mempoolSizeDesc, prometheus.GaugeValue, float64(chain.Mempool.Size()), info.ChainID)
+		}
+	}
+
+	load := consensus.GetLoadStats()
+	ch <- prometheus.MustNewConstMetric(consensusActiveDesc, prometheus.GaugeValue, float64(load.Active))
+	ch <- prometheus.MustNewConstMetric(consensusQueuedDesc, prometheus.GaugeValue, float64(load.Queued))
+
+	for _, node := range p2p.NetworkNodeStats() {
+		ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(node.Peers), node.ChainID, node.Address)
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/ai"
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestHandlerReportsStorageOperations(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage.SetDefault(store)
+	t.Cleanup(func() {
+		storage.SetDefault(nil)
+		store.Close()
+	})
+
+	store.Set([]byte("k"), []byte("v"))
+	store.Get([]byte("k"))
+	store.Get([]byte("missing"))
+	store.Delete([]byte("k"))
+
+	body := scrape(t)
+	for _, want := range []string{
+		`chaoschain_storage_operations_total{operation="put"} 1`,
+		`chaoschain_storage_operations_total{operation="get"} 2`,
+		`chaoschain_storage_operations_total{operation="delete"} 1`,
+		`chaoschain_storage_errors_total 0`,
+		`chaoschain_consensus_active_rounds`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %q", want)
+		}
+	}
+}
+
+func TestHandlerReportsLLMCalls(t *testing.T) {
+	observeLLMCall(ai.LLMCall{CallType: "vote", Duration: 2 * time.Second})
+	observeLLMCall(ai.LLMCall{Duration: time.Second, Err: errors.New("timeout")})
+
+	body := scrape(t)
+	for _, want := range []string{
+		`chaoschain_llm_calls_total{call_type="vote",result="ok"} 1`,
+		`chaoschain_llm_calls_total{call_type="other",result="error"} 1`,
+		`chaoschain_llm_call_duration_seconds_count{call_type="vote"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Wire formats for P2P messages. The handshake itself is always JSON.
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+)
+
+// ProtocolVersion is advertised in the handshake. Peers before version 2 only speak JSON;
+// version 3 length-prefixes every message, see writeFrame.
+const ProtocolVersion = 3
+
+// Codec encodes and decodes P2P messages on the wire
+type Codec interface {
+	Name() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                               { return CodecJSON }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type msgpackCodec struct{ handle *codec.MsgpackHandle }
+
+func newMsgpackCodec() msgpackCodec {
+	h := &codec.MsgpackHandle{}
+	h.WriteExt = true
+	h.RawToString = true
+	// Decode maps with string keys so payloads can be re-encoded as JSON for subscribers
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return msgpackCodec{handle: h}
+}
+
+func (msgpackCodec) Name() string { return CodecMsgpack }
+
+func (c msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var out []byte
+	err := codec.NewEncoderBytes(&out, c.handle).Encode(v)
+	return out, err
+}
+
+func (c msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+var codecs = map[string]Codec{
+	CodecJSON:    jsonCodec{},
+	CodecMsgpack: newMsgpackCodec(),
+}
+
+// CodecByName returns the codec registered under name
+func CodecByName(name string) (Codec, bool) {
+	c, ok := codecs[name]
+	return c, ok
+}
+
+// codecFromEnv reads P2P_CODEC, falling back to JSON
+func codecFromEnv() Codec {
+	name := os.Getenv("P2P_CODEC")
+	if name == "" {
+		return codecs[CodecJSON]
+	}
+	c, ok := CodecByName(name)
+	if !ok {
+		log.Printf("Unknown P2P_CODEC %q, using %s", name, CodecJSON)
+		return codecs[CodecJSON]
+	}
+	return c
+}
+
+// supportedCodecs lists the codecs a node offers in its handshake, preferred first
+func supportedCodecs(preferred Codec) []string {
+	names := []string{preferred.Name()}
+	if preferred.Name() != CodecJSON {
+		names = append(names, CodecJSON)
+	}
+	return names
+}
+
+// negotiateCodec picks the codec for a connection from what the dialing peer offered:
+// our preferred codec if offered, otherwise the first offered codec we know, otherwise JSON
+func negotiateCodec(preferred Codec, offered []string) Codec {
+	for _, name := range offered {
+		if name == preferred.Name() {
+			return preferred
+		}
+	}
+	for _, name := range offered {
+		if c, ok := CodecByName(name); ok {
+			return c
+		}
+	}
+	return codecs[CodecJSON]
+}
@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMsgpackMessagesReencodeAsJSON(t *testing.T) {
+	type vote struct {
+		ValidatorID string `json:"validatorId"`
+		Round       int    `json:"round"`
+	}
+	c, _ := CodecByName(CodecMsgpack)
+
+	data, err := c.Marshal(Message{Type: "AGENT_VOTE", Data: vote{ValidatorID: "v1", Round: 6}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var msg Message
+	if err := c.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	// Subscribers receive payloads as JSON, whatever the wire format
+	payload, err := json.Marshal(msg.Data)
+	if err != nil {
+		t.Fatalf("re-encode as JSON: %v", err)
+	}
+	var got vote
+	if err := json.Unmarshal(payload, &got); err != nil || got != (vote{ValidatorID: "v1", Round: 6}) {
+		t.Errorf("got %+v (%v), want v1 round 6", got, err)
+	}
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	msgpack, _ := CodecByName(CodecMsgpack)
+	plain, _ := CodecByName(CodecJSON)
+
+	tests := []struct {
+		preferred Codec
+		offered   []string
+		want      string
+	}{
+		{msgpack, []string{CodecMsgpack, CodecJSON}, CodecMsgpack},
+		{plain, []string{CodecMsgpack, CodecJSON}, CodecJSON},
+		{msgpack, []string{CodecJSON}, CodecJSON},
+		{msgpack, nil, CodecJSON}, // Peer predates negotiation
+		{plain, []string{"cbor", CodecMsgpack}, CodecMsgpack},
+	}
+	for _, tt := range tests {
+		if got := negotiateCodec(tt.preferred, tt.offered).Name(); got != tt.want {
+			t.Errorf("negotiateCodec(%s, %v) = %s, want %s", tt.preferred.Name(), tt.offered, got, tt.want)
+		}
+	}
+}
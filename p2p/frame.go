@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Every message on a peer connection, including the handshake, is preceded by its
+// length as a 4-byte big-endian integer so reads don't depend on TCP segment boundaries
+const frameHeaderSize = 4
+
+// maxFrameSize bounds a single message so a bad length can't trigger a huge allocation
+const maxFrameSize = 16 << 20
+
+// writeFrame writes data as one length-prefixed frame. Header and payload go out in a
+// single Write so frames from concurrent writers never interleave.
+func writeFrame(w io.Writer, data []byte) error {
+	if len(data) > maxFrameSize {
+		return fmt.Errorf("message of %d bytes exceeds the %d byte limit", len(data), maxFrameSize)
+	}
+	frame := make([]byte, frameHeaderSize+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[frameHeaderSize:], data)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readFrame reads the next length-prefixed frame
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the %d byte limit", size, maxFrameSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
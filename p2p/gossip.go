@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Broadcasts are relayed peer to peer until their TTL runs out. Every node remembers the IDs
+// it has seen for a while, so a message reaching it again over another path is dropped
+// rather than processed and relayed twice.
+const (
+	DefaultMessageTTL    = 6
+	DefaultSeenRetention = 5 * time.Minute
+)
+
+// stampBroadcast gives a message about to be broadcast its ID and TTL, and marks it seen so
+// echoes from peers are ignored
+func (n *Node) stampBroadcast(msg Message) Message {
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	if msg.TTL <= 0 {
+		msg.TTL = DefaultMessageTTL
+	}
+	n.markSeen(msg.ID)
+	return msg
+}
+
+// markSeen records a message ID, reporting false if it was already seen. Expired IDs are
+// pruned at most once per retention period.
+func (n *Node) markSeen(id string) bool {
+	n.seenMu.Lock()
+	defer n.seenMu.Unlock()
+
+	now := time.Now()
+	if now.Sub(n.seenPruned) > DefaultSeenRetention {
+		for seenID, at := range n.seenMessages {
+			if now.Sub(at) > DefaultSeenRetention {
+				delete(n.seenMessages, seenID)
+			}
+		}
+		n.seenPruned = now
+	}
+
+	if _, seen := n.seenMessages[id]; seen {
+		return false
+	}
+	n.seenMessages[id] = now
+	return true
+}
+
+// relay forwards a received broadcast to every peer except the one it came from, keeping the
+// originator's signature. Messages on their last hop and messages from peers that predate
+// relaying, which carry no ID, go no further.
+func (n *Node) relay(msg Message, from *Peer) {
+	if msg.ID == "" || msg.TTL <= 1 {
+		return
+	}
+	msg.TTL--
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sendToPeers(msg, n.peersLocked(from))
+}
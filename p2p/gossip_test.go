@@ -0,0 +1,68 @@
+package p2p
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBroadcastInMeshIsProcessedOncePerNode(t *testing.T) {
+	const size = 5
+	nodes := make([]*Node, size)
+	for i := range nodes {
+		port := freePort(t)
+		nodes[i] = NewNode(ChainConfig{ChainID: "gossip-chain", P2PPort: port})
+		nodes[i].StartServer(port)
+		defer nodes[i].Stop()
+	}
+	for i := range nodes {
+		for j := i + 1; j < size; j++ {
+			nodes[i].ConnectToPeer(fmt.Sprintf("localhost:%d", nodes[j].GetPort()))
+		}
+	}
+	for _, node := range nodes {
+		waitFor(t, "the mesh to connect", func() bool { return node.GetPeerCount() == size-1 })
+	}
+
+	counts := make([]atomic.Int32, size)
+	for i, node := range nodes {
+		i := i
+		node.Subscribe("GOSSIP", func([]byte) { counts[i].Add(1) })
+	}
+
+	nodes[0].BroadcastMessage(Message{Type: "GOSSIP", Data: "hello"})
+	waitFor(t, "every node to receive the broadcast", func() bool {
+		for i := 1; i < size; i++ {
+			if counts[i].Load() == 0 {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Give relayed copies time to arrive; each should be recognized and dropped
+	time.Sleep(200 * time.Millisecond)
+	if got := counts[0].Load(); got != 0 {
+		t.Errorf("sender processed its own broadcast %d times", got)
+	}
+	for i := 1; i < size; i++ {
+		if got := counts[i].Load(); got != 1 {
+			t.Errorf("node %d processed the broadcast %d times, want 1", i, got)
+		}
+	}
+}
+
+func TestStampedBroadcastIsMarkedSeen(t *testing.T) {
+	node := NewNode(ChainConfig{ChainID: "gossip-chain", P2PPort: freePort(t)})
+	msg := node.stampBroadcast(Message{Type: "GOSSIP"})
+	if msg.ID == "" || msg.TTL != DefaultMessageTTL {
+		t.Fatalf("broadcast not stamped: %+v", msg)
+	}
+	if node.markSeen(msg.ID) {
+		t.Fatal("own broadcast not marked seen")
+	}
+	if !node.markSeen("other") || node.markSeen("other") {
+		t.Fatal("markSeen should accept an ID once")
+	}
+}
@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// DefaultPingInterval is how often connected peers are pinged to refresh their latency
+const DefaultPingInterval = 30 * time.Second
+
+// pingLoop pings every peer on each interval until the node stops
+func (n *Node) pingLoop() {
+	ticker := time.NewTicker(n.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			n.mu.Lock()
+			peers := make([]*Peer, 0, len(n.Peers))
+			for _, peer := range n.Peers {
+				peers = append(peers, peer)
+			}
+			n.mu.Unlock()
+
+			for _, peer := range peers {
+				n.ping(peer)
+			}
+		case <-n.done:
+			return
+		}
+	}
+}
+
+// ping sends a PING carrying the send time, which the peer echoes back in a PONG
+func (n *Node) ping(peer *Peer) {
+	n.sendToPeer(peer, Message{Type: "PING", Data: time.Now().Format(time.RFC3339Nano)})
+}
+
+// handlePong records the round trip of a PING we sent
+func (n *Node) handlePong(msg Message, peer *Peer) {
+	sentAt, ok := msg.Data.(string)
+	if !ok {
+		return
+	}
+	sent, err := time.Parse(time.RFC3339Nano, sentAt)
+	if err != nil {
+		log.Printf("Invalid PONG from %s: %v", peer.Address, err)
+		return
+	}
+	n.mu.Lock()
+	peer.Latency = time.Since(sent)
+	n.mu.Unlock()
+}
+
+// sendToPeer writes a single message to one peer
+func (n *Node) sendToPeer(peer *Peer, msg Message) {
+	msg = n.sign(msg)
+	data, err := peer.codec.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to encode %s message as %s: %v", msg.Type, peer.codec.Name(), err)
+		return
+	}
+	if err := writeFrame(peer.Conn, data); err != nil {
+		log.Printf("Failed to send %s to %s: %v", msg.Type, peer.Address, err)
+	}
+}
+
+// BroadcastMessagePrioritized sends a message to all peers, lowest measured latency first,
+// so consensus-critical messages reach the nearest validators soonest. Peers whose latency
+// hasn't been measured yet go last.
+func (n *Node) BroadcastMessagePrioritized(msg Message) {
+	msg = n.sign(n.stampBroadcast(msg))
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	peers := n.peersLocked(nil)
+	sort.SliceStable(peers, func(i, j int) bool {
+		a, b := peers[i].Latency, peers[j].Latency
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+	n.sendToPeers(msg, peers)
+}
@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderConn records which peer each write went to
+type orderConn struct {
+	net.Conn
+	name  string
+	mu    *sync.Mutex
+	order *[]string
+}
+
+func (c orderConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.order = append(*c.order, c.name)
+	return len(b), nil
+}
+
+func TestBroadcastMessagePrioritizedSendsLowestLatencyFirst(t *testing.T) {
+	node := NewNode(ChainConfig{ChainID: "latency-chain"})
+	var mu sync.Mutex
+	var order []string
+	latencies := map[string]time.Duration{
+		"slow":       80 * time.Millisecond,
+		"unmeasured": 0,
+		"fast":       5 * time.Millisecond,
+		"medium":     20 * time.Millisecond,
+	}
+	for name, latency := range latencies {
+		node.Peers[name] = &Peer{
+			Address: name,
+			Conn:    orderConn{name: name, mu: &mu, order: &order},
+			codec:   codecs[CodecJSON],
+			Latency: latency,
+		}
+	}
+
+	node.BroadcastMessagePrioritized(Message{Type: "BLOCK_PROPOSAL", Data: "block"})
+
+	want := []string{"fast", "medium", "slow", "unmeasured"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("sent in order %v, want %v", order, want)
+	}
+}
+
+func TestPingMeasuresPeerLatency(t *testing.T) {
+	nodePort, peerPort := freePort(t), freePort(t)
+	node := NewNode(ChainConfig{ChainID: "latency-chain", P2PPort: nodePort})
+	peer := NewNode(ChainConfig{ChainID: "latency-chain", P2PPort: peerPort})
+	node.StartServer(nodePort)
+	peer.StartServer(peerPort)
+	defer node.Stop()
+	defer peer.Stop()
+
+	peerAddr := fmt.Sprintf("localhost:%d", peerPort)
+	node.ConnectToPeer(peerAddr)
+	waitFor(t, "latency to be measured", func() bool {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		p, ok := node.Peers[peerAddr]
+		return ok && p.Latency > 0
+	})
+}
@@ -4,4 +4,9 @@ package p2p
 type Message struct {
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
+
+	ID        string `json:"id,omitempty"`        // Set on broadcasts, see stampBroadcast
+	TTL       int    `json:"ttl,omitempty"`       // Hops a broadcast may still be relayed
+	From      string `json:"from,omitempty"`      // Signer ID, set when the sending node has a signing key
+	Signature string `json:"signature,omitempty"` // Hex ed25519 signature over signingPayload
 }
@@ -17,6 +17,43 @@ func RegisterNode(addr string, node *Node) {
 	networkNodes[addr] = node
 }
 
+// UnregisterNode removes a node from the network registry. If it was the default node,
+// another registered node takes its place.
+func UnregisterNode(addr string) {
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	node, ok := networkNodes[addr]
+	if !ok {
+		return
+	}
+	delete(networkNodes, addr)
+	if defaultNode == node {
+		for _, other := range networkNodes {
+			defaultNode = other
+			break
+		}
+	}
+}
+
+// NodeStats describes one registered node's connections
+type NodeStats struct {
+	Address string
+	ChainID string
+	Peers   int
+}
+
+// NetworkNodeStats returns the peer count of every registered node
+func NetworkNodeStats() []NodeStats {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+
+	stats := make([]NodeStats, 0, len(networkNodes))
+	for addr, node := range networkNodes {
+		stats = append(stats, NodeStats{Address: addr, ChainID: node.ChainID, Peers: node.GetPeerCount()})
+	}
+	return stats
+}
+
 // GetNetworkPeerCount returns total unique peers in the network
 func GetNetworkPeerCount() int {
 	networkMu.RLock()
@@ -2,16 +2,22 @@ package p2p
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"sync"
+	"time"
 )
 
 // Peer represents a node in the P2P network
 type Peer struct {
 	Address string
 	Conn    net.Conn
+	codec   Codec         // Negotiated in the handshake
+	Latency time.Duration // Round trip of the last PING, 0 until measured
+
+	lastSeen time.Time // When the peer connected or last sent a message
 }
 
 // ChainConfig represents the configuration for a specific chain
@@ -20,6 +26,7 @@ type ChainConfig struct {
 	P2PPort    int
 	APIPort    int
 	NetworkKey string // Optional: Could be used to further isolate networks
+	Codec      string // Preferred wire format (see CodecJSON, CodecMsgpack); defaults to P2P_CODEC or JSON
 }
 
 // Node manages peer connections and message handling
@@ -30,6 +37,27 @@ type Node struct {
 	listener    net.Listener
 	subscribers map[string][]func([]byte)
 	port        int
+	codec       Codec // Preferred wire format offered in handshakes
+
+	seeds               map[string]bool // Peers redialed until they come back, see ConnectToSeed
+	reconnecting        map[string]bool // Peers with a reconnect loop running
+	reconnectBackoff    time.Duration
+	maxReconnectBackoff time.Duration
+	reconnectAttempts   int
+	pingInterval        time.Duration
+
+	keysMu            sync.RWMutex // Guards the signing fields below, see SetSigningKey
+	signerID          string
+	signingKey        string            // Hex ed25519 private key, empty to send unsigned
+	peerKeys          map[string]string // Hex public keys by signer ID
+	requireSignatures bool
+
+	seenMu       sync.Mutex
+	seenMessages map[string]time.Time // Broadcast IDs by when they were first seen
+	seenPruned   time.Time
+
+	done     chan struct{} // Closed by Stop
+	stopOnce sync.Once
 }
 
 var defaultNode = NewNode(ChainConfig{ChainID: "main", P2PPort: 8080})
@@ -41,11 +69,33 @@ func GetP2PNode() *Node {
 
 // NewNode initializes a new P2P network node
 func NewNode(config ChainConfig) *Node {
+	preferred := codecFromEnv()
+	if config.Codec != "" {
+		if c, ok := CodecByName(config.Codec); ok {
+			preferred = c
+		} else {
+			log.Printf("Unknown P2P codec %q for chain %s, using %s", config.Codec, config.ChainID, preferred.Name())
+		}
+	}
+
 	return &Node{
 		ChainID:     config.ChainID,
 		Peers:       make(map[string]*Peer),
 		subscribers: make(map[string][]func([]byte)),
 		port:        config.P2PPort,
+		codec:       preferred,
+
+		seeds:               make(map[string]bool),
+		reconnecting:        make(map[string]bool),
+		reconnectBackoff:    DefaultReconnectBackoff,
+		maxReconnectBackoff: DefaultMaxReconnectBackoff,
+		reconnectAttempts:   DefaultReconnectAttempts,
+		pingInterval:        DefaultPingInterval,
+
+		peerKeys:     make(map[string]string),
+		seenMessages: make(map[string]time.Time),
+
+		done: make(chan struct{}),
 	}
 }
 
@@ -56,17 +106,24 @@ func (n *Node) StartServer(port int) {
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+	n.mu.Lock()
 	n.listener = listener
+	n.mu.Unlock()
 	log.Printf("P2P server started on port %d\n", port)
 
 	// Server is ready to accept connections
-	go n.acceptConnections()
+	go n.acceptConnections(listener)
+	go n.pingLoop()
 }
 
-func (n *Node) acceptConnections() {
+func (n *Node) acceptConnections(listener net.Listener) {
 	for {
-		conn, err := n.listener.Accept()
+		conn, err := listener.Accept()
 		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				log.Printf("P2P server on port %d stopped", n.port)
+				return
+			}
 			log.Printf("Connection failed: %v", err)
 			continue
 		}
@@ -74,6 +131,49 @@ func (n *Node) acceptConnections() {
 	}
 }
 
+// Stop closes the listener and every peer connection and ends any reconnect loops.
+// A stopped node can't be started again.
+func (n *Node) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.done)
+
+		n.mu.Lock()
+		listener := n.listener
+		peers := make([]*Peer, 0, len(n.Peers))
+		for addr, peer := range n.Peers {
+			peers = append(peers, peer)
+			delete(n.Peers, addr)
+		}
+		n.mu.Unlock()
+
+		if listener != nil {
+			listener.Close()
+		}
+		for _, peer := range peers {
+			peer.Conn.Close()
+		}
+	})
+}
+
+// stopped reports whether Stop has been called
+func (n *Node) stopped() bool {
+	select {
+	case <-n.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Listening reports whether the node is accepting connections: its server has started on a
+// port and it hasn't been stopped
+func (n *Node) Listening() bool {
+	n.mu.Lock()
+	started := n.listener != nil && n.port != 0
+	n.mu.Unlock()
+	return started && !n.stopped()
+}
+
 // Add these constants
 const (
 	MAX_PEERS = 10 // Maximum number of peer connections
@@ -82,87 +182,119 @@ const (
 
 // Add handshake struct at package level
 type handshakeMsg struct {
-	ChainID string `json:"chain_id"`
-	Address string `json:"address"`
+	ChainID string   `json:"chain_id"`
+	Address string   `json:"address"`
+	Version int      `json:"version,omitempty"`
+	Codecs  []string `json:"codecs,omitempty"` // Offered by the dialer, preferred first
+	Codec   string   `json:"codec,omitempty"`  // Chosen by the listener
 }
 
 // ConnectToPeer connects to a peer at a given address
 func (n *Node) ConnectToPeer(address string) {
+	if err := n.dialPeer(address); err != nil {
+		log.Printf("Failed to connect to peer %s: %v", address, err)
+	}
+}
+
+// dialPeer connects and handshakes with a peer. Connecting to ourselves or to a peer
+// we already hold is a no-op.
+func (n *Node) dialPeer(address string) error {
 	myAddr := fmt.Sprintf("localhost:%d", n.port)
 
 	// Don't connect to self
 	if address == myAddr {
-		return
+		return nil
 	}
 
 	// Don't connect if we already have this peer
-	n.mu.Lock()
-	if _, exists := n.Peers[address]; exists {
-		n.mu.Unlock()
-		return
+	if n.hasPeer(address) {
+		return nil
+	}
+	if n.stopped() {
+		return fmt.Errorf("node is stopped")
+	}
+	if n.full(address) {
+		return ErrTooManyPeers
 	}
-	n.mu.Unlock()
 
 	log.Printf("Node %s attempting to connect to peer at %s", myAddr, address)
 	conn, err := net.Dial("tcp", address)
 	if err != nil {
-		log.Printf("Failed to connect to peer %s: %v", address, err)
-		return
+		return err
 	}
 
 	// Send handshake
 	handshake := handshakeMsg{
 		ChainID: n.ChainID,
 		Address: myAddr,
+		Version: ProtocolVersion,
+		Codecs:  supportedCodecs(n.codec),
 	}
 
 	handshakeData, _ := json.Marshal(handshake)
-	if _, err := conn.Write(handshakeData); err != nil {
+	if err := writeFrame(conn, handshakeData); err != nil {
 		conn.Close()
-		return
+		return fmt.Errorf("failed to send handshake: %v", err)
 	}
 
 	// Wait for handshake response
-	buffer := make([]byte, 1024)
-	bytesRead, err := conn.Read(buffer)
+	responseData, err := readFrame(conn)
 	if err != nil {
 		conn.Close()
-		return
+		return fmt.Errorf("no handshake response: %v", err)
 	}
 
 	var response handshakeMsg
-	if err := json.Unmarshal(buffer[:bytesRead], &response); err != nil {
+	if err := json.Unmarshal(responseData, &response); err != nil {
 		conn.Close()
-		return
+		return fmt.Errorf("invalid handshake response: %v", err)
 	}
 
 	// Verify chain ID
 	if response.ChainID != n.ChainID {
 		conn.Close()
-		return
+		return fmt.Errorf("peer is on chain %s", response.ChainID)
+	}
+
+	// Listeners that predate codec negotiation don't pick one and only speak JSON
+	peerCodec, ok := CodecByName(response.Codec)
+	if !ok {
+		peerCodec = codecs[CodecJSON]
 	}
 
-	peer := &Peer{Address: address, Conn: conn}
+	peer := &Peer{Address: address, Conn: conn, codec: peerCodec, lastSeen: time.Now()}
 	n.mu.Lock()
+	if n.stopped() {
+		n.mu.Unlock()
+		conn.Close()
+		return fmt.Errorf("node is stopped")
+	}
+	// Other connections may have filled the node while we were handshaking
+	if err := n.makeRoomLocked(address); err != nil {
+		n.mu.Unlock()
+		conn.Close()
+		return err
+	}
 	n.Peers[address] = peer
 	n.mu.Unlock()
 
 	go n.listenToPeer(peer)
-	log.Printf("Node %s connected to peer: %s\n", myAddr, address)
+	go n.ping(peer)
+	log.Printf("Node %s connected to peer: %s (%s)\n", myAddr, address, peerCodec.Name())
+	return nil
 }
 
 // handleConnection handles incoming peer connections
 func (n *Node) handleConnection(conn net.Conn) {
 	// Read initial handshake
-	buffer := make([]byte, 1024)
-	bytesRead, err := conn.Read(buffer)
+	handshakeData, err := readFrame(conn)
 	if err != nil {
 		conn.Close()
 		return
 	}
 
 	var handshake handshakeMsg
-	if err := json.Unmarshal(buffer[:bytesRead], &handshake); err != nil {
+	if err := json.Unmarshal(handshakeData, &handshake); err != nil {
 		conn.Close()
 		return
 	}
@@ -181,13 +313,20 @@ func (n *Node) handleConnection(conn net.Conn) {
 
 	// Only accept connection if we don't have this peer and it's not ourselves
 	n.mu.Lock()
-	if _, exists := n.Peers[peerAddr]; exists || peerAddr == myAddr {
+	if _, exists := n.Peers[peerAddr]; exists || peerAddr == myAddr || n.stopped() {
+		n.mu.Unlock()
+		conn.Close()
+		return
+	}
+	if err := n.makeRoomLocked(peerAddr); err != nil {
 		n.mu.Unlock()
+		log.Printf("Rejecting peer %s: %v", peerAddr, err)
 		conn.Close()
 		return
 	}
 
-	peer := &Peer{Address: peerAddr, Conn: conn}
+	peerCodec := negotiateCodec(n.codec, handshake.Codecs)
+	peer := &Peer{Address: peerAddr, Conn: conn, codec: peerCodec, lastSeen: time.Now()}
 	n.Peers[peerAddr] = peer
 	n.mu.Unlock()
 
@@ -195,12 +334,15 @@ func (n *Node) handleConnection(conn net.Conn) {
 	response := handshakeMsg{
 		ChainID: n.ChainID,
 		Address: myAddr,
+		Version: ProtocolVersion,
+		Codec:   peerCodec.Name(),
 	}
-	handshakeData, _ := json.Marshal(response)
-	conn.Write(handshakeData)
+	responseData, _ := json.Marshal(response)
+	writeFrame(conn, responseData)
 
 	go n.listenToPeer(peer)
-	log.Printf("Node %s accepted connection from: %s\n", myAddr, peerAddr)
+	go n.ping(peer)
+	log.Printf("Node %s accepted connection from: %s (%s)\n", myAddr, peerAddr, peerCodec.Name())
 }
 
 // listenToPeer listens for messages from a peer
@@ -208,19 +350,30 @@ func (p *Node) listenToPeer(peer *Peer) {
 	defer peer.Conn.Close()
 
 	for {
-		buffer := make([]byte, 4096)
-		n, err := peer.Conn.Read(buffer)
+		data, err := readFrame(peer.Conn)
 		if err != nil {
-			log.Printf("Connection lost with %s", peer.Address)
+			if p.stopped() {
+				return
+			}
 			p.mu.Lock()
-			delete(p.Peers, peer.Address)
+			current := p.Peers[peer.Address] == peer
+			if current {
+				delete(p.Peers, peer.Address)
+			}
 			p.mu.Unlock()
+			// A peer dropped to make room for a seed isn't redialed
+			if !current {
+				return
+			}
+			log.Printf("Connection lost with %s", peer.Address)
+			p.scheduleReconnect(peer.Address)
 			return
 		}
+		p.touch(peer)
 
 		var msg Message
-		err = json.Unmarshal(buffer[:n], &msg)
-		log.Printf("Received message: %s", msg)
+		err = peer.codec.Unmarshal(data, &msg)
+		log.Printf("Received message: %v", msg)
 		if err != nil {
 			log.Printf("Failed to parse message: %v", err)
 			continue
@@ -233,8 +386,21 @@ func (p *Node) listenToPeer(peer *Peer) {
 // handleMessage processes incoming messages
 func (n *Node) handleMessage(msg Message, peer *Peer) {
 	log.Printf("Received message from %s: %s", peer.Address, msg.Type)
+	if !n.accepts(msg, peer) {
+		return
+	}
+	// A broadcast reaching us again over another path was already handled
+	if msg.ID != "" && !n.markSeen(msg.ID) {
+		return
+	}
 
 	switch msg.Type {
+	case "PING":
+		n.sendToPeer(peer, Message{Type: "PONG", Data: msg.Data})
+
+	case "PONG":
+		n.handlePong(msg, peer)
+
 	case "GET_PEERS":
 		// Send our peer list
 		n.mu.Lock()
@@ -260,7 +426,7 @@ func (n *Node) handleMessage(msg Message, peer *Peer) {
 			if currentPeerCount < MIN_PEERS {
 				for _, addr := range peerList {
 					n.ConnectToPeer(addr)
-					if len(n.Peers) >= MAX_PEERS {
+					if n.GetPeerCount() >= MAX_PEERS {
 						break
 					}
 				}
@@ -275,19 +441,56 @@ func (n *Node) handleMessage(msg Message, peer *Peer) {
 		// case "VALIDATION":
 		// 	// Process validation result
 		// 	log.Println("Validation received:", msg.Data)
+
+	default:
+		// Hand everything else to subscribers of that message type
+		data, err := json.Marshal(msg.Data)
+		if err != nil {
+			log.Printf("Failed to encode %s payload: %v", msg.Type, err)
+			return
+		}
+		n.Publish(msg.Type, data)
+		n.relay(msg, peer)
 	}
 }
 
-// BroadcastMessage sends a message to all peers
+// BroadcastMessage sends a message to all peers, which relay it on to theirs
 func (p *Node) BroadcastMessage(msg Message) {
+	msg = p.sign(p.stampBroadcast(msg))
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	p.sendToPeers(msg, p.peersLocked(nil))
+}
 
-	msgBytes, _ := json.Marshal(msg)
-	for _, peer := range p.Peers {
-		_, err := peer.Conn.Write(msgBytes)
-		if err != nil {
-			log.Printf("Failed to send message to %s: %v", peer.Address, err)
+// peersLocked lists the node's peers other than except, which may be nil. Callers hold n.mu.
+func (n *Node) peersLocked(except *Peer) []*Peer {
+	peers := make([]*Peer, 0, len(n.Peers))
+	for _, peer := range n.Peers {
+		if except != nil && peer.Address == except.Address {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// sendToPeers writes msg to peers in order, encoding it once per codec in use rather than
+// once per peer. Failed writes are logged; listenToPeer drops peers whose connection is
+// gone. Callers hold n.mu.
+func (n *Node) sendToPeers(msg Message, peers []*Peer) {
+	encoded := make(map[string][]byte)
+	for _, peer := range peers {
+		msgBytes, ok := encoded[peer.codec.Name()]
+		if !ok {
+			var err error
+			if msgBytes, err = peer.codec.Marshal(msg); err != nil {
+				log.Printf("Failed to encode %s message as %s: %v", msg.Type, peer.codec.Name(), err)
+				continue
+			}
+			encoded[peer.codec.Name()] = msgBytes
+		}
+		if err := writeFrame(peer.Conn, msgBytes); err != nil {
+			log.Printf("Failed to send %s to %s: %v", msg.Type, peer.Address, err)
 		}
 	}
 }
@@ -0,0 +1,152 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestStopClosesListenerPeersAndReconnects(t *testing.T) {
+	nodePort, peerPort := freePort(t), freePort(t)
+	nodeAddr := fmt.Sprintf("localhost:%d", nodePort)
+
+	node := NewNode(ChainConfig{ChainID: "stop-chain", P2PPort: nodePort})
+	peer := NewNode(ChainConfig{ChainID: "stop-chain", P2PPort: peerPort})
+	node.reconnectBackoff = 10 * time.Millisecond
+	node.StartServer(nodePort)
+	peer.StartServer(peerPort)
+	defer peer.Stop()
+
+	// A seed that never comes up keeps a reconnect loop running until Stop
+	node.ConnectToSeed(fmt.Sprintf("localhost:%d", freePort(t)))
+	peer.ConnectToPeer(nodeAddr)
+	waitFor(t, "node to accept the peer", func() bool { return node.GetPeerCount() == 1 })
+
+	node.Stop()
+	node.Stop() // Stopping twice is harmless
+
+	if node.GetPeerCount() != 0 {
+		t.Fatalf("expected no peers after Stop, got %d", node.GetPeerCount())
+	}
+	if conn, err := net.DialTimeout("tcp", nodeAddr, time.Second); err == nil {
+		conn.Close()
+		t.Fatal("listener still accepting connections after Stop")
+	}
+	waitFor(t, "peer to notice the closed connection", func() bool { return !peer.hasPeer(nodeAddr) })
+	waitFor(t, "reconnect loop to end", func() bool {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		return len(node.reconnecting) == 0
+	})
+	if err := node.dialPeer(fmt.Sprintf("localhost:%d", peerPort)); err == nil {
+		t.Fatal("stopped node dialed a peer")
+	}
+}
+
+func TestRapidMessagesAreDecodedIntact(t *testing.T) {
+	senderPort, receiverPort := freePort(t), freePort(t)
+	sender := NewNode(ChainConfig{ChainID: "frame-chain", P2PPort: senderPort})
+	receiver := NewNode(ChainConfig{ChainID: "frame-chain", P2PPort: receiverPort})
+	sender.StartServer(senderPort)
+	receiver.StartServer(receiverPort)
+	defer sender.Stop()
+	defer receiver.Stop()
+
+	const count = 100
+	var mu sync.Mutex
+	received := make(map[string]bool)
+	receiver.Subscribe("FRAME_TEST", func(data []byte) {
+		var payload string
+		if err := json.Unmarshal(data, &payload); err != nil {
+			t.Errorf("corrupted payload %q: %v", data, err)
+			return
+		}
+		mu.Lock()
+		received[payload] = true
+		mu.Unlock()
+	})
+
+	sender.ConnectToPeer(fmt.Sprintf("localhost:%d", receiverPort))
+	waitFor(t, "connection", func() bool { return sender.GetPeerCount() == 1 })
+
+	// Long payloads make TCP coalesce some writes and split others
+	padding := strings.Repeat("x", 3000)
+	for i := 0; i < count; i++ {
+		sender.BroadcastMessage(Message{Type: "FRAME_TEST", Data: fmt.Sprintf("%d-%s", i, padding)})
+	}
+
+	waitFor(t, "all messages", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == count
+	})
+	for i := 0; i < count; i++ {
+		if !received[fmt.Sprintf("%d-%s", i, padding)] {
+			t.Fatalf("message %d missing or altered", i)
+		}
+	}
+}
+
+func TestReadFrameAcrossPartialReads(t *testing.T) {
+	var buf bytes.Buffer
+	for _, msg := range []string{"first", "", "third"} {
+		if err := writeFrame(&buf, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := iotest.OneByteReader(&buf)
+	for _, want := range []string{"first", "", "third"} {
+		got, err := readFrame(r)
+		if err != nil {
+			t.Fatalf("reading %q: %v", want, err)
+		}
+		if string(got) != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNodeCapsPeersAndAdmitsSeedsAtTheLimit(t *testing.T) {
+	nodePort := freePort(t)
+	nodeAddr := fmt.Sprintf("localhost:%d", nodePort)
+	node := NewNode(ChainConfig{ChainID: "limit-chain", P2PPort: nodePort})
+	node.StartServer(nodePort)
+	defer node.Stop()
+
+	// Dialers don't need a listener of their own; the node only records their address
+	for i := 0; i < 15; i++ {
+		dialer := NewNode(ChainConfig{ChainID: "limit-chain", P2PPort: freePort(t)})
+		defer dialer.Stop()
+		dialer.ConnectToPeer(nodeAddr)
+	}
+	if got := node.GetPeerCount(); got != MAX_PEERS {
+		t.Fatalf("expected the node to cap at %d peers, got %d", MAX_PEERS, got)
+	}
+
+	// Outbound dials are refused too, before touching the network
+	if err := node.dialPeer(fmt.Sprintf("localhost:%d", freePort(t))); err != ErrTooManyPeers {
+		t.Fatalf("expected ErrTooManyPeers dialing at the limit, got %v", err)
+	}
+
+	// A seed displaces the least recently seen peer instead of being turned away
+	seedPort := freePort(t)
+	seedAddr := fmt.Sprintf("localhost:%d", seedPort)
+	seed := NewNode(ChainConfig{ChainID: "limit-chain", P2PPort: seedPort})
+	seed.StartServer(seedPort)
+	defer seed.Stop()
+	node.ConnectToSeed(seedAddr)
+
+	if !node.hasPeer(seedAddr) {
+		t.Fatal("seed was not admitted at the peer limit")
+	}
+	if got := node.GetPeerCount(); got != MAX_PEERS {
+		t.Fatalf("expected %d peers after admitting the seed, got %d", MAX_PEERS, got)
+	}
+}
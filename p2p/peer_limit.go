@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrTooManyPeers is returned when a node already holds MAX_PEERS connections
+var ErrTooManyPeers = errors.New("peer limit reached")
+
+// makeRoomLocked checks whether address may join the node's peers. Below MAX_PEERS anyone
+// can; at the limit only a seed gets in, by displacing the least recently seen peer that
+// isn't a seed. Seeds are never displaced. Callers hold n.mu.
+func (n *Node) makeRoomLocked(address string) error {
+	if len(n.Peers) < MAX_PEERS {
+		return nil
+	}
+	victim := n.evictableLocked(address)
+	if victim == nil {
+		return ErrTooManyPeers
+	}
+
+	// listenToPeer sees the peer is gone and doesn't redial it
+	delete(n.Peers, victim.Address)
+	victim.Conn.Close()
+	log.Printf("Dropped peer %s to make room for seed %s", victim.Address, address)
+	return nil
+}
+
+// evictableLocked returns the peer a full node would drop to admit address, or nil if
+// address can't get in. Callers hold n.mu.
+func (n *Node) evictableLocked(address string) *Peer {
+	if !n.seeds[address] {
+		return nil
+	}
+	var victim *Peer
+	for addr, peer := range n.Peers {
+		if n.seeds[addr] {
+			continue
+		}
+		if victim == nil || peer.lastSeen.Before(victim.lastSeen) {
+			victim = peer
+		}
+	}
+	return victim
+}
+
+// full reports whether a connection to address would be turned away, so dialPeer can skip
+// dialing it
+func (n *Node) full(address string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.Peers) >= MAX_PEERS && n.evictableLocked(address) == nil
+}
+
+// touch records that a peer was just heard from
+func (n *Node) touch(peer *Peer) {
+	n.mu.Lock()
+	peer.lastSeen = time.Now()
+	n.mu.Unlock()
+}
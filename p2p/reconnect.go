@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"log"
+	"time"
+)
+
+// Reconnection schedule for dropped peers. The wait doubles after each failed attempt up
+// to the maximum; seeds are retried until they return, other peers only a few times.
+const (
+	DefaultReconnectBackoff    = time.Second
+	DefaultMaxReconnectBackoff = 30 * time.Second
+	DefaultReconnectAttempts   = 6
+)
+
+// AddSeed marks an address as a seed, a peer the node always reconnects to when it drops
+func (n *Node) AddSeed(address string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.seeds[address] = true
+}
+
+// ConnectToSeed marks address as a seed and connects to it, retrying in the background
+// with backoff if it isn't reachable yet
+func (n *Node) ConnectToSeed(address string) {
+	n.AddSeed(address)
+	if err := n.dialPeer(address); err != nil {
+		log.Printf("Failed to connect to seed %s, retrying: %v", address, err)
+		n.scheduleReconnect(address)
+	}
+}
+
+func (n *Node) hasPeer(address string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, exists := n.Peers[address]
+	return exists
+}
+
+// scheduleReconnect starts redialing a dropped peer unless a reconnect loop is already running
+func (n *Node) scheduleReconnect(address string) {
+	n.mu.Lock()
+	if n.reconnecting[address] {
+		n.mu.Unlock()
+		return
+	}
+	n.reconnecting[address] = true
+	seed := n.seeds[address]
+	n.mu.Unlock()
+
+	go n.reconnectLoop(address, seed)
+}
+
+func (n *Node) reconnectLoop(address string, seed bool) {
+	defer func() {
+		n.mu.Lock()
+		delete(n.reconnecting, address)
+		n.mu.Unlock()
+	}()
+
+	backoff := n.reconnectBackoff
+	for attempt := 1; seed || attempt <= n.reconnectAttempts; attempt++ {
+		select {
+		case <-time.After(backoff):
+		case <-n.done:
+			return
+		}
+
+		// The peer may have dialed us in the meantime
+		if n.hasPeer(address) {
+			return
+		}
+		err := n.dialPeer(address)
+		if err == nil {
+			log.Printf("Reconnected to %s after %d attempts", address, attempt)
+			return
+		}
+		log.Printf("Reconnect attempt %d to %s failed: %v", attempt, address, err)
+
+		backoff *= 2
+		if backoff > n.maxReconnectBackoff {
+			backoff = n.maxReconnectBackoff
+		}
+	}
+	log.Printf("Giving up reconnecting to %s after %d attempts", address, n.reconnectAttempts)
+}
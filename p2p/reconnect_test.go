@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSeedIsRedialedUntilReachableAndAfterDrop(t *testing.T) {
+	seedPort, nodePort := freePort(t), freePort(t)
+	seedAddr := fmt.Sprintf("localhost:%d", seedPort)
+
+	seed := NewNode(ChainConfig{ChainID: "reconnect-chain", P2PPort: seedPort})
+	node := NewNode(ChainConfig{ChainID: "reconnect-chain", P2PPort: nodePort})
+	node.reconnectBackoff = 10 * time.Millisecond
+	node.maxReconnectBackoff = 40 * time.Millisecond
+	node.StartServer(nodePort)
+
+	// The seed isn't up yet, so the first dial fails and is retried in the background
+	node.ConnectToSeed(seedAddr)
+	if node.hasPeer(seedAddr) {
+		t.Fatal("connected to a seed that isn't listening")
+	}
+	seed.StartServer(seedPort)
+	waitFor(t, "initial connection to the seed", func() bool { return node.hasPeer(seedAddr) })
+
+	node.mu.Lock()
+	original := node.Peers[seedAddr].Conn
+	node.mu.Unlock()
+
+	// Drop the connection from the seed's side
+	waitFor(t, "seed to register the node", func() bool { return seed.GetPeerCount() == 1 })
+	seed.mu.Lock()
+	for _, peer := range seed.Peers {
+		peer.Conn.Close()
+	}
+	seed.mu.Unlock()
+
+	waitFor(t, "reconnection to the seed", func() bool {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		peer, ok := node.Peers[seedAddr]
+		return ok && peer.Conn != original
+	})
+}
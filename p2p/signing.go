@@ -0,0 +1,166 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/crypto"
+)
+
+// Reasons a message fails verification, see verifyMessage
+var (
+	ErrUnsignedMessage = errors.New("message is not signed")
+	ErrUnknownSigner   = errors.New("no public key known for the sender")
+	ErrBadSignature    = errors.New("signature does not match the message")
+	ErrNoSigningKey    = errors.New("node has no signing key")
+)
+
+// NodeSignature is a signature made with a node's signing key, see SignPayload
+type NodeSignature struct {
+	Signer    string // ID the node signs messages as
+	PublicKey string // Hex-encoded ed25519 public key
+	Signature string // Hex-encoded ed25519 signature
+}
+
+// SetSigningKey makes the node sign every message it sends as id, using a hex-encoded
+// ed25519 private key (see crypto.SignMessage)
+func (n *Node) SetSigningKey(id, privateKeyHex string) error {
+	key, err := hex.DecodeString(privateKeyHex)
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("signing key must be %d bytes of hex", ed25519.PrivateKeySize)
+	}
+	n.keysMu.Lock()
+	defer n.keysMu.Unlock()
+	n.signerID = id
+	n.signingKey = privateKeyHex
+	return nil
+}
+
+// AddPeerKey registers the hex-encoded ed25519 public key messages from id are verified against
+func (n *Node) AddPeerKey(id, publicKeyHex string) error {
+	key, err := hex.DecodeString(publicKeyHex)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key must be %d bytes of hex", ed25519.PublicKeySize)
+	}
+	n.keysMu.Lock()
+	defer n.keysMu.Unlock()
+	n.peerKeys[id] = publicKeyHex
+	return nil
+}
+
+// SetRequireSignatures switches strict mode on or off. In strict mode messages that are
+// unsigned, come from a sender without a registered key or fail verification are dropped.
+// Otherwise, the default for local development, they are processed and only a bad
+// signature from a known sender is logged.
+func (n *Node) SetRequireSignatures(require bool) {
+	n.keysMu.Lock()
+	defer n.keysMu.Unlock()
+	n.requireSignatures = require
+}
+
+// SignPayload signs arbitrary data with the node's signing key, for data leaving the P2P
+// network such as block headers
+func (n *Node) SignPayload(data []byte) (NodeSignature, error) {
+	n.keysMu.RLock()
+	id, key := n.signerID, n.signingKey
+	n.keysMu.RUnlock()
+	if key == "" {
+		return NodeSignature{}, ErrNoSigningKey
+	}
+
+	signature, err := crypto.SignMessage(key, data)
+	if err != nil {
+		return NodeSignature{}, err
+	}
+	private, _ := hex.DecodeString(key)
+	public := ed25519.PrivateKey(private).Public().(ed25519.PublicKey)
+	return NodeSignature{Signer: id, PublicKey: hex.EncodeToString(public), Signature: signature}, nil
+}
+
+// sign stamps msg with the node's identity and signature, if it has a signing key
+func (n *Node) sign(msg Message) Message {
+	n.keysMu.RLock()
+	id, key := n.signerID, n.signingKey
+	n.keysMu.RUnlock()
+	if key == "" {
+		return msg
+	}
+
+	msg.From, msg.Signature = id, ""
+	payload, err := signingPayload(msg)
+	if err != nil {
+		log.Printf("Failed to sign %s message: %v", msg.Type, err)
+		return msg
+	}
+	if msg.Signature, err = crypto.SignMessage(key, payload); err != nil {
+		log.Printf("Failed to sign %s message: %v", msg.Type, err)
+	}
+	return msg
+}
+
+// verifyMessage checks msg's signature against its sender's registered key
+func (n *Node) verifyMessage(msg Message) error {
+	if msg.From == "" || msg.Signature == "" {
+		return ErrUnsignedMessage
+	}
+	n.keysMu.RLock()
+	key, ok := n.peerKeys[msg.From]
+	n.keysMu.RUnlock()
+	if !ok {
+		return ErrUnknownSigner
+	}
+
+	payload, err := signingPayload(msg)
+	if err != nil {
+		return err
+	}
+	if !crypto.VerifySignature(key, string(payload), msg.Signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// accepts reports whether a received message should be processed, see SetRequireSignatures
+func (n *Node) accepts(msg Message, peer *Peer) bool {
+	err := n.verifyMessage(msg)
+	if err == nil {
+		return true
+	}
+
+	n.keysMu.RLock()
+	strict := n.requireSignatures
+	n.keysMu.RUnlock()
+	if strict {
+		log.Printf("Dropping %s message from %s: %v", msg.Type, peer.Address, err)
+		return false
+	}
+	if errors.Is(err, ErrBadSignature) {
+		log.Printf("Warning: %s message from %s claiming to be %s: %v", msg.Type, peer.Address, msg.From, err)
+	}
+	return true
+}
+
+// signingPayload is the part of a message covered by its signature. The TTL is left out
+// because relaying nodes lower it, and Data is normalized through JSON so the payload
+// comes out the same after a round trip through either codec.
+func signingPayload(msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var normalized interface{}
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(normalized); err != nil {
+		return nil, err
+	}
+	return []byte(msg.Type + "\n" + msg.ID + "\n" + msg.From + "\n" + string(data)), nil
+}
@@ -0,0 +1,143 @@
+package producer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/communication"
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+	"github.com/NethermindEth/chaoschain-launchpad/validator"
+)
+
+// MinAutoProduceInterval is the shortest interval an auto-producer accepts
+const MinAutoProduceInterval = 100 * time.Millisecond
+
+// AutoProducerConfig says how often an auto-producer proposes blocks and how many pending
+// transactions it waits for. With MinTxs 0 it proposes a block every interval, even an
+// empty one.
+type AutoProducerConfig struct {
+	Interval time.Duration
+	MinTxs   int
+}
+
+// autoProducer proposes a block on its chain every interval while it runs
+type autoProducer struct {
+	chainID string
+	config  AutoProducerConfig
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var (
+	// Map of chainID -> running auto-producer
+	autoProducers   = make(map[string]*autoProducer)
+	autoProducersMu sync.Mutex
+)
+
+// StartAutoProducer starts proposing blocks on a chain on a schedule, replacing any
+// auto-producer already running there
+func StartAutoProducer(chainID string, config AutoProducerConfig) error {
+	if config.Interval < MinAutoProduceInterval {
+		return fmt.Errorf("interval must be at least %s", MinAutoProduceInterval)
+	}
+	if config.MinTxs < 0 {
+		return errors.New("min_txs cannot be negative")
+	}
+
+	StopAutoProducer(chainID)
+
+	p := &autoProducer{
+		chainID: chainID,
+		config:  config,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	autoProducersMu.Lock()
+	autoProducers[chainID] = p
+	autoProducersMu.Unlock()
+
+	go p.run()
+	log.Printf("Auto-producer started on chain %s: every %s with at least %d transactions", chainID, config.Interval, config.MinTxs)
+	return nil
+}
+
+// StopAutoProducer stops a chain's auto-producer, reporting whether one was running
+func StopAutoProducer(chainID string) bool {
+	autoProducersMu.Lock()
+	p, ok := autoProducers[chainID]
+	delete(autoProducers, chainID)
+	autoProducersMu.Unlock()
+
+	if !ok {
+		return false
+	}
+	close(p.stop)
+	<-p.done
+	log.Printf("Auto-producer stopped on chain %s", chainID)
+	return true
+}
+
+// AutoProducerStatus returns the config of a chain's running auto-producer
+func AutoProducerStatus(chainID string) (AutoProducerConfig, bool) {
+	autoProducersMu.Lock()
+	defer autoProducersMu.Unlock()
+	p, ok := autoProducers[chainID]
+	if !ok {
+		return AutoProducerConfig{}, false
+	}
+	return p.config, true
+}
+
+func (p *autoProducer) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.produce()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// produce proposes a block when the chain is free and the mempool holds enough transactions
+func (p *autoProducer) produce() {
+	bc := core.GetChain(p.chainID)
+	if bc == nil || bc.Mempool == nil {
+		return
+	}
+	if validator.VotingValidatorCount(p.chainID) < bc.Config.Quorum() {
+		return
+	}
+	cm := consensus.GetConsensusManager(p.chainID)
+	if cm.Busy() {
+		return
+	}
+
+	pending := bc.Mempool.Size()
+	if pending < p.config.MinTxs {
+		return
+	}
+	block, deferred, err := bc.CreateBlockAllowEmpty()
+	if err != nil {
+		log.Printf("Auto-producer failed to create a block on chain %s: %v", p.chainID, err)
+		return
+	}
+
+	threadID := block.Hash()
+	communication.CreateThread(threadID, fmt.Sprintf("Block Proposal %s", threadID), "AutoProducer")
+
+	var queued *consensus.QueuedError
+	if err := cm.ProposeBlock(block); err != nil && !errors.As(err, &queued) {
+		log.Printf("Auto-producer failed to propose block %d on chain %s: %v", block.Height, p.chainID, err)
+		return
+	}
+	log.Printf("Auto-producer proposed block %d on chain %s with %d transactions, %d deferred to later blocks",
+		block.Height, p.chainID, len(block.Txs), deferred)
+}
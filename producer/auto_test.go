@@ -0,0 +1,33 @@
+package producer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartAndStopAutoProducer(t *testing.T) {
+	const chainID = "auto-producer-chain"
+	if err := StartAutoProducer(chainID, AutoProducerConfig{Interval: time.Millisecond}); err == nil {
+		t.Fatal("expected a too-short interval to be rejected")
+	}
+	if err := StartAutoProducer(chainID, AutoProducerConfig{Interval: MinAutoProduceInterval, MinTxs: -1}); err == nil {
+		t.Fatal("expected negative min_txs to be rejected")
+	}
+
+	// The chain doesn't exist, so ticks are no-ops
+	config := AutoProducerConfig{Interval: MinAutoProduceInterval, MinTxs: 2}
+	if err := StartAutoProducer(chainID, config); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := AutoProducerStatus(chainID); !ok || got != config {
+		t.Fatalf("status = %+v, %v", got, ok)
+	}
+	time.Sleep(2 * MinAutoProduceInterval)
+
+	if !StopAutoProducer(chainID) {
+		t.Fatal("running auto-producer not stopped")
+	}
+	if StopAutoProducer(chainID) {
+		t.Fatal("stopped auto-producer reported running")
+	}
+}
@@ -28,6 +28,11 @@ func NewProducer(mp core.MempoolInterface, personality ai.Personality, p2pNode *
 	}
 }
 
+// P2PNode returns the node the producer broadcasts blocks on
+func (p *Producer) P2PNode() *p2p.Node {
+	return p.p2pNode
+}
+
 // ProduceBlock creates a new block, signs it, and publishes its proposal both via NATS and TCP-based P2P.
 func (p *Producer) ProduceBlock() core.Block {
 	prevHash := "genesis"
@@ -46,6 +51,7 @@ func (p *Producer) ProduceBlock() core.Block {
 		Height:    height,
 		PrevHash:  prevHash,
 		Txs:       selectedTxs,
+		TxRoot:    core.TxMerkleRoot(selectedTxs),
 		Timestamp: time.Now().Unix(),
 		Signature: "", // TODO: Implement AI-based cryptographic signing
 	}
@@ -75,13 +81,17 @@ func (p *Producer) ProduceBlock() core.Block {
 		}
 	}
 
-	// Also broadcast block proposal over the TCP-based P2P layer.
-	// Note: p2p.GetP2PNode().BroadcastMessage sends a p2p.Message that includes the block.
-	p2p.GetP2PNode().BroadcastMessage(p2p.Message{
-		Type: "BLOCK_PROPOSAL",
-		Data: block,
-	})
-	log.Println("Broadcasted BLOCK_PROPOSAL event via P2P layer")
+	// Also broadcast block proposal over the TCP-based P2P layer, on the producer's own node
+	// so it stays on the producer's chain
+	if p.p2pNode == nil {
+		log.Println("Producer has no P2P node, not broadcasting BLOCK_PROPOSAL")
+	} else {
+		p.p2pNode.BroadcastMessage(p2p.Message{
+			Type: "BLOCK_PROPOSAL",
+			Data: block,
+		})
+		log.Println("Broadcasted BLOCK_PROPOSAL event via P2P layer")
+	}
 
 	p.LastBlock = &block
 	return block
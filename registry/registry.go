@@ -25,3 +25,16 @@ func RegisterProducer(chainID string, id string, p *producer.Producer) {
 func RegisterValidator(chainID string, id string, v *validator.Validator) {
 	validator.RegisterValidator(chainID, id, v)
 }
+
+// UnregisterProducer removes a producer, returning it or nil if none was registered
+func UnregisterProducer(chainID string, id string) *producer.Producer {
+	agentLock.Lock()
+	defer agentLock.Unlock()
+	p := producers[chainID][id]
+	delete(producers[chainID], id)
+	return p
+}
+
+func UnregisterValidator(chainID string, id string) *validator.Validator {
+	return validator.UnregisterValidator(chainID, id)
+}
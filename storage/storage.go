@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	badger "github.com/dgraph-io/badger/v4"
+)
+
+// ErrNotFound is returned by Get when a key doesn't exist
+var ErrNotFound = errors.New("key not found")
+
+// Storage is the key-value store used to persist node state across restarts
+type Storage interface {
+	Get(key []byte) ([]byte, error)
+	Set(key, value []byte) error
+	Delete(key []byte) error
+	// Iterate calls fn for every key with the given prefix, in key order
+	Iterate(prefix []byte, fn func(key, value []byte) error) error
+	Close() error
+}
+
+// BadgerStorage implements Storage on top of BadgerDB
+type BadgerStorage struct {
+	db *badger.DB
+
+	puts, gets, deletes, errors atomic.Uint64
+}
+
+// Metrics counts the operations a store has served since it was opened
+type Metrics struct {
+	Puts    uint64
+	Gets    uint64
+	Deletes uint64
+	Errors  uint64 // Failed operations of any kind; a Get of a missing key isn't a failure
+}
+
+// Metrics returns the store's operation counts
+func (s *BadgerStorage) Metrics() Metrics {
+	return Metrics{
+		Puts:    s.puts.Load(),
+		Gets:    s.gets.Load(),
+		Deletes: s.deletes.Load(),
+		Errors:  s.errors.Load(),
+	}
+}
+
+// count records an operation and whether it failed, returning err
+func (s *BadgerStorage) count(op *atomic.Uint64, err error) error {
+	op.Add(1)
+	if err != nil {
+		s.errors.Add(1)
+	}
+	return err
+}
+
+// NewBadgerStorage opens (or creates) a BadgerDB database in dir
+func NewBadgerStorage(dir string) (*BadgerStorage, error) {
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open badger database at %s: %v", dir, err)
+	}
+	return &BadgerStorage{db: db}, nil
+}
+
+// NewMemoryStorage returns a BadgerDB-backed Storage that keeps everything in memory
+func NewMemoryStorage() (*BadgerStorage, error) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open in-memory badger database: %v", err)
+	}
+	return &BadgerStorage{db: db}, nil
+}
+
+func (s *BadgerStorage) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		s.gets.Add(1)
+		return nil, ErrNotFound
+	}
+	return value, s.count(&s.gets, err)
+}
+
+func (s *BadgerStorage) Set(key, value []byte) error {
+	return s.count(&s.puts, s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	}))
+}
+
+func (s *BadgerStorage) Delete(key []byte) error {
+	return s.count(&s.deletes, s.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	}))
+}
+
+func (s *BadgerStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if err := fn(item.KeyCopy(nil), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.errors.Add(1)
+	}
+	return err
+}
+
+func (s *BadgerStorage) Close() error {
+	return s.db.Close()
+}
+
+var (
+	defaultStorage   Storage
+	defaultStorageMu sync.RWMutex
+)
+
+// SetDefault installs the node-wide storage; nil disables persistence
+func SetDefault(s Storage) {
+	defaultStorageMu.Lock()
+	defer defaultStorageMu.Unlock()
+	defaultStorage = s
+}
+
+// Default returns the node-wide storage, or nil if persistence isn't configured
+func Default() Storage {
+	defaultStorageMu.RLock()
+	defer defaultStorageMu.RUnlock()
+	return defaultStorage
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"math"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// DefaultRelationshipDecayInterval is how often StartRelationshipDecay fades relationships
+const DefaultRelationshipDecayInterval = time.Minute
+
+// Scores closer to 0 than this are rounded to neutral rather than fading forever
+const relationshipNeutralBand = 0.01
+
+// DecayRelationships fades every relationship score toward 0 by rate, the share lost per
+// hour, compounded over elapsed. It reports whether any score changed.
+func (v *Validator) DecayRelationships(elapsed time.Duration, rate float64) bool {
+	if elapsed <= 0 || rate <= 0 {
+		return false
+	}
+	factor := math.Pow(1-math.Min(rate, 1), elapsed.Hours())
+
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+	changed := false
+	for name, score := range v.Relationships {
+		if score == 0 {
+			continue
+		}
+		score *= factor
+		if math.Abs(score) < relationshipNeutralBand {
+			score = 0
+		}
+		v.Relationships[name] = score
+		changed = true
+	}
+	return changed
+}
+
+// StartRelationshipDecay fades the relationships of every active chain's validators on each
+// interval, at the rate set in the chain's config
+func StartRelationshipDecay(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		last := time.Now()
+		for now := range ticker.C {
+			decayAllRelationships(now.Sub(last))
+			last = now
+		}
+	}()
+}
+
+func decayAllRelationships(elapsed time.Duration) {
+	for _, info := range core.GetAllChains() {
+		// Archived chains stay frozen, and looking them up would restore them
+		if info.Archived {
+			continue
+		}
+		chain := core.GetChain(info.ChainID)
+		if chain == nil || chain.Config.RelationshipDecayRate <= 0 {
+			continue
+		}
+		for _, v := range GetAllValidators(info.ChainID) {
+			if v.DecayRelationships(elapsed, chain.Config.RelationshipDecayRate) {
+				v.persist(info.ChainID)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestRelationshipsDecayTowardNeutral(t *testing.T) {
+	v := &Validator{ID: "d", Name: "Dana", Relationships: map[string]float64{"Ally": 0.8, "Rival": -0.8, "Stranger": 0}}
+	rate := core.DefaultRelationshipDecayRate
+
+	// 0.8 * 0.95^9 is still just above 0.5; the tenth hour takes it below
+	for i := 0; i < 9; i++ {
+		v.DecayRelationships(time.Hour, rate)
+	}
+	if v.Relationships["Ally"] < 0.5 {
+		t.Fatalf("decayed too fast: %v after 9 intervals", v.Relationships["Ally"])
+	}
+	v.DecayRelationships(time.Hour, rate)
+	if got := v.Relationships["Ally"]; got >= 0.5 {
+		t.Fatalf("expected 0.8 to decay below 0.5 after 10 intervals, got %v", got)
+	}
+	if got := v.Relationships["Rival"]; math.Abs(got+v.Relationships["Ally"]) > 1e-9 {
+		t.Fatalf("negative scores should decay symmetrically, got %v", got)
+	}
+
+	// Splitting the elapsed time doesn't change the result
+	a := &Validator{Relationships: map[string]float64{"x": 0.6}}
+	b := &Validator{Relationships: map[string]float64{"x": 0.6}}
+	a.DecayRelationships(2*time.Hour, rate)
+	b.DecayRelationships(time.Hour, rate)
+	b.DecayRelationships(time.Hour, rate)
+	if math.Abs(a.Relationships["x"]-b.Relationships["x"]) > 1e-9 {
+		t.Fatalf("decay depends on interval length: %v vs %v", a.Relationships["x"], b.Relationships["x"])
+	}
+
+	if v.DecayRelationships(time.Hour, 0) {
+		t.Fatal("a zero rate should leave relationships alone")
+	}
+	v.DecayRelationships(1000*time.Hour, rate)
+	if v.Relationships["Ally"] != 0 || v.Relationships["Stranger"] != 0 {
+		t.Fatalf("expected faded scores to settle at 0, got %v", v.Relationships)
+	}
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+)
+
+func init() {
+	consensus.SetDiscussionSigner(func(chainID, validatorID string, payload []byte) string {
+		v := GetValidatorByID(chainID, validatorID)
+		if v == nil {
+			return ""
+		}
+		return v.Sign(payload)
+	})
+}
+
+// generateSigningKey gives the validator a fresh ed25519 key pair
+func (v *Validator) generateSigningKey() {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Printf("Failed to generate signing key for validator %s: %v", v.ID, err)
+		return
+	}
+	v.PublicKey = publicKey
+	v.privateKey = privateKey
+}
+
+// Sign signs a payload with the validator's key and returns the base64 signature
+func (v *Validator) Sign(payload []byte) string {
+	if v.privateKey == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(v.privateKey, payload))
+}
+
+// PublicKeyBase64 returns the validator's base64 encoded ed25519 public key
+func (v *Validator) PublicKeyBase64() string {
+	return base64.StdEncoding.EncodeToString(v.PublicKey)
+}
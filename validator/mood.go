@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+)
+
+// Moods a validator moves through as blocks are decided, from most to least content. A vote
+// on the winning side moves it one step toward MoodSatisfied; losing outvoteLimit votes in a
+// row moves it one step toward MoodFrustrated.
+const (
+	MoodSatisfied  = "Satisfied"
+	MoodContent    = "Content"
+	MoodNeutral    = "Neutral"
+	MoodUneasy     = "Uneasy"
+	MoodFrustrated = "Frustrated"
+)
+
+var moodLadder = []string{MoodSatisfied, MoodContent, MoodNeutral, MoodUneasy, MoodFrustrated}
+
+const (
+	outvoteLimit     = 2  // Consecutive losing votes before the mood sours
+	moodHistoryLimit = 10 // Mood changes kept per validator
+)
+
+// MoodChange records one change of a validator's mood
+type MoodChange struct {
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Reason      string    `json:"reason"`                // "agreed", "outvoted" or "random"
+	BlockHeight int       `json:"blockHeight,omitempty"` // Block whose outcome caused it
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func init() {
+	consensus.OnConsensusResult(updateMoodsFromResult)
+}
+
+// NextMood is the mood transition function: where a validator in mood current ends up
+// after a vote that agreed with the outcome or not, having now lost outvoted votes in a
+// row. Moods off the ladder, like the random ones from UpdateMood, count as MoodNeutral.
+func NextMood(current string, agreed bool, outvoted int) string {
+	step := 0
+	switch {
+	case agreed:
+		step = -1
+	case outvoted >= outvoteLimit:
+		step = 1
+	default:
+		return current
+	}
+
+	position := 2
+	for i, mood := range moodLadder {
+		if mood == current {
+			position = i
+		}
+	}
+	position = min(max(position+step, 0), len(moodLadder)-1)
+	return moodLadder[position]
+}
+
+// MoodHistory returns the validator's most recent mood changes, oldest first
+func (v *Validator) MoodHistory() []MoodChange {
+	validatorMu.RLock()
+	defer validatorMu.RUnlock()
+	return append([]MoodChange{}, v.moodHistory...)
+}
+
+// setMoodLocked moves the validator to mood, recording the change. Callers hold validatorMu.
+func (v *Validator) setMoodLocked(mood, reason string, blockHeight int) {
+	if mood == v.Mood {
+		return
+	}
+	v.moodHistory = append(v.moodHistory, MoodChange{
+		From:        v.Mood,
+		To:          mood,
+		Reason:      reason,
+		BlockHeight: blockHeight,
+		Timestamp:   time.Now(),
+	})
+	if len(v.moodHistory) > moodHistoryLimit {
+		v.moodHistory = v.moodHistory[len(v.moodHistory)-moodHistoryLimit:]
+	}
+	log.Printf("%s's mood is now: %s (was %s)\n", v.Name, mood, v.Mood)
+	v.Mood = mood
+}
+
+// updateMoodsFromResult moves every validator that voted on a decided block along the mood
+// ladder, depending on whether its final vote matched the outcome
+func updateMoodsFromResult(chainID string, bc *consensus.BlockConsensus, result consensus.ConsensusResult) {
+	if result.State != consensus.Accepted && result.State != consensus.Rejected {
+		return
+	}
+
+	// Final vote of each validator, the first one counting as in tallyVotes
+	votes := make(map[string]string)
+	for _, d := range bc.GetDiscussions() {
+		if d.Round != bc.FinalRound() || d.Observer {
+			continue
+		}
+		if _, voted := votes[d.ValidatorID]; !voted {
+			votes[d.ValidatorID] = strings.ToLower(d.Type)
+		}
+	}
+
+	for validatorID, vote := range votes {
+		v := GetValidatorByID(chainID, validatorID)
+		if v == nil || (vote != "support" && vote != "oppose") {
+			continue
+		}
+		agreed := (vote == "support") == (result.State == consensus.Accepted)
+
+		validatorMu.Lock()
+		reason := "agreed"
+		if agreed {
+			v.outvoted = 0
+		} else {
+			v.outvoted++
+			reason = "outvoted"
+		}
+		v.setMoodLocked(NextMood(v.Mood, agreed, v.outvoted), reason, bc.Block.Height)
+		validatorMu.Unlock()
+
+		v.persist(chainID)
+	}
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+func TestNextMood(t *testing.T) {
+	tests := []struct {
+		current  string
+		agreed   bool
+		outvoted int
+		want     string
+	}{
+		{MoodNeutral, true, 0, MoodContent},
+		{MoodContent, true, 0, MoodSatisfied},
+		{MoodSatisfied, true, 0, MoodSatisfied},
+		{MoodNeutral, false, 1, MoodNeutral}, // A single loss is shrugged off
+		{MoodNeutral, false, 2, MoodUneasy},
+		{MoodUneasy, false, 3, MoodFrustrated},
+		{MoodFrustrated, false, 4, MoodFrustrated},
+		{"Chaotic", true, 0, MoodContent}, // Moods off the ladder count as neutral
+		{"Chaotic", false, 1, "Chaotic"},
+	}
+	for _, tt := range tests {
+		if got := NextMood(tt.current, tt.agreed, tt.outvoted); got != tt.want {
+			t.Errorf("NextMood(%q, %v, %d) = %q, want %q", tt.current, tt.agreed, tt.outvoted, got, tt.want)
+		}
+	}
+}
+
+func TestConsensusOutcomesMoveMoods(t *testing.T) {
+	const chainID = "mood-chain"
+	winner := &Validator{ID: "w", Name: "Winona", Mood: MoodNeutral}
+	loser := &Validator{ID: "l", Name: "Luis", Mood: MoodNeutral}
+	for _, v := range []*Validator{winner, loser} {
+		RegisterValidator(chainID, v.ID, v)
+	}
+	defer func() {
+		validatorMu.Lock()
+		delete(validators, chainID)
+		validatorMu.Unlock()
+	}()
+
+	for height := 1; height <= 2; height++ {
+		bc := &consensus.BlockConsensus{
+			Block:  &core.Block{ChainID: chainID, Height: height},
+			Rounds: 1,
+			Discussions: []consensus.Discussion{
+				{ValidatorID: "w", Type: "support", Round: 2},
+				{ValidatorID: "l", Type: "oppose", Round: 2},
+				{ValidatorID: "l", Type: "support", Round: 1}, // Discussion, not a vote
+			},
+		}
+		updateMoodsFromResult(chainID, bc, consensus.ConsensusResult{State: consensus.Accepted})
+	}
+
+	if winner.Mood != MoodSatisfied {
+		t.Errorf("winner mood = %q, want %q", winner.Mood, MoodSatisfied)
+	}
+	if loser.Mood != MoodUneasy {
+		t.Errorf("outvoted twice, mood = %q, want %q", loser.Mood, MoodUneasy)
+	}
+
+	history := loser.MoodHistory()
+	if len(history) != 1 || history[0].From != MoodNeutral || history[0].To != MoodUneasy ||
+		history[0].Reason != "outvoted" || history[0].BlockHeight != 2 {
+		t.Fatalf("unexpected mood history %+v", history)
+	}
+	if len(winner.MoodHistory()) != 2 {
+		t.Fatalf("expected two recorded changes for the winner, got %+v", winner.MoodHistory())
+	}
+}
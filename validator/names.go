@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/NethermindEth/chaoschain-launchpad/consensus"
+	"github.com/NethermindEth/chaoschain-launchpad/core"
+)
+
+// Relationship change applied when a validator mentions someone it agrees or disagrees with
+const mentionRelationshipDelta = 0.05
+
+var (
+	// |@Name| - the format the discussion prompt asks for
+	pipedMentionPattern = regexp.MustCompile(`\|@([^|@\n]+)\|`)
+	// @Name or @First Last without pipes, used when nothing known matches the text after @
+	bareMentionPattern = regexp.MustCompile(`^[\p{L}\d_.'-]+(?: [\p{Lu}][\p{L}\d_.'-]*)*`)
+)
+
+func init() {
+	consensus.OnDiscussion(updateRelationshipsFromMentions)
+}
+
+// nameResolutionFor returns the chain's configured name resolution mode
+func nameResolutionFor(chainID string) string {
+	if chain := core.GetChain(chainID); chain != nil && chain.Config.NameResolution != "" {
+		return chain.Config.NameResolution
+	}
+	return core.DefaultChainConfig().NameResolution
+}
+
+// ResolveValidatorName finds the validator on a chain referred to by name,
+// matching as strictly as the given mode requires. Returns nil if nothing matches.
+func ResolveValidatorName(chainID string, name string, mode string) *Validator {
+	return resolveName(GetAllValidators(chainID), name, mode)
+}
+
+func resolveName(vals []*Validator, name string, mode string) *Validator {
+	if mode == core.NameResolutionStrict {
+		for _, v := range vals {
+			if v.Name == name {
+				return v
+			}
+		}
+		return nil
+	}
+
+	name = strings.TrimSpace(strings.Trim(name, "|@"))
+	for _, v := range vals {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+	if mode != core.NameResolutionFuzzy || name == "" {
+		return nil
+	}
+
+	// Closest known name, allowing roughly one typo per four characters
+	var best *Validator
+	bestDistance := max(1, len(name)/4) + 1
+	for _, v := range vals {
+		if d := levenshtein(strings.ToLower(v.Name), strings.ToLower(name)); d < bestDistance {
+			best, bestDistance = v, d
+		}
+	}
+	return best
+}
+
+// ResolveMentions extracts validator mentions from a message and resolves them against
+// the chain's validators. Mentions that can't be matched are returned as unresolved.
+func ResolveMentions(chainID string, message string) ([]*Validator, []string) {
+	return resolveMentions(GetAllValidators(chainID), message, nameResolutionFor(chainID))
+}
+
+func resolveMentions(vals []*Validator, message string, mode string) ([]*Validator, []string) {
+	var resolved []*Validator
+	var unresolved []string
+	seen := make(map[string]bool)
+
+	for _, mention := range extractMentions(vals, message, mode) {
+		v := resolveName(vals, mention, mode)
+		if v == nil {
+			unresolved = append(unresolved, mention)
+			continue
+		}
+		if !seen[v.ID] {
+			seen[v.ID] = true
+			resolved = append(resolved, v)
+		}
+	}
+	return resolved, unresolved
+}
+
+// extractMentions returns the raw names mentioned in a message
+func extractMentions(vals []*Validator, message string, mode string) []string {
+	var mentions []string
+	for _, m := range pipedMentionPattern.FindAllStringSubmatch(message, -1) {
+		mentions = append(mentions, m[1])
+	}
+	if mode == core.NameResolutionStrict {
+		return mentions
+	}
+
+	// Known names, longest first, so "Marie Curie" wins over "Marie"
+	names := make([]string, 0, len(vals))
+	for _, v := range vals {
+		names = append(names, v.Name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	// Pick up @ mentions that aren't wrapped in both pipes
+	stripped := pipedMentionPattern.ReplaceAllString(message, "")
+	for i := strings.Index(stripped, "@"); i >= 0; {
+		rest := strings.TrimLeft(stripped[i+1:], "|")
+
+		mention := ""
+		for _, name := range names {
+			if len(rest) >= len(name) && strings.EqualFold(rest[:len(name)], name) {
+				mention = rest[:len(name)]
+				break
+			}
+		}
+		if mention == "" {
+			mention = strings.TrimRight(bareMentionPattern.FindString(rest), ".'-")
+		}
+		if mention != "" {
+			mentions = append(mentions, mention)
+		}
+
+		next := strings.Index(stripped[i+1:], "@")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return mentions
+}
+
+// updateRelationshipsFromMentions nudges the speaker's relationship with each validator it
+// mentions, up when their latest stances agree and down when they disagree
+func updateRelationshipsFromMentions(chainID string, bc *consensus.BlockConsensus, d consensus.Discussion) {
+	speaker := GetValidatorByID(chainID, d.ValidatorID)
+	if speaker == nil {
+		return
+	}
+
+	mentioned, unresolved := ResolveMentions(chainID, d.Message)
+	for _, name := range unresolved {
+		log.Printf("Unresolved mention %q by %s in round %d", name, speaker.Name, d.Round)
+	}
+	if len(mentioned) == 0 {
+		return
+	}
+
+	// Latest stance of every validator before this message
+	stances := make(map[string]string)
+	for _, prev := range bc.GetDiscussions() {
+		if prev.ID != d.ID {
+			stances[prev.ValidatorID] = strings.ToLower(prev.Type)
+		}
+	}
+	stance := strings.ToLower(d.Type)
+
+	defer speaker.persist(chainID)
+
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+	for _, target := range mentioned {
+		if target.ID == speaker.ID {
+			continue
+		}
+		targetStance, ok := stances[target.ID]
+		if !ok || (stance != "support" && stance != "oppose") || (targetStance != "support" && targetStance != "oppose") {
+			continue
+		}
+
+		delta := mentionRelationshipDelta
+		if stance != targetStance {
+			delta = -delta
+		}
+		score := speaker.Relationships[target.Name] + delta
+		if score > 1 {
+			score = 1
+		} else if score < -1 {
+			score = -1
+		}
+		speaker.Relationships[target.Name] = score
+	}
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/NethermindEth/chaoschain-launchpad/ai"
 )
@@ -12,8 +13,10 @@ import (
 // UpdateMood randomly changes the validator's mood for added chaos
 func (v *Validator) UpdateMood() {
 	moods := []string{"Excited", "Skeptical", "Dramatic", "Angry", "Inspired", "Chaotic"}
-	v.Mood = moods[time.Now().Unix()%int64(len(moods))]
-	log.Printf("%s's mood is now: %s\n", v.Name, v.Mood)
+	validatorMu.Lock()
+	v.setMoodLocked(moods[time.Now().Unix()%int64(len(moods))], "random", 0)
+	validatorMu.Unlock()
+	v.persist(v.chainID())
 }
 
 // DiscussBlock allows the validator to discuss a block with others
@@ -27,7 +30,7 @@ func (v *Validator) DiscussBlock(blockHash string, sender string, message string
 		v.Name, sender, blockHash, message,
 	)
 
-	response := ai.GenerateLLMResponse(discussionPrompt)
+	response := ai.GenerateLLMResponseWithConfig(discussionPrompt, v.llmConfig())
 	return response
 }
 
@@ -42,7 +45,7 @@ func (v *Validator) HandleBribe(blockHash string, sender string, offer string) s
 		v.Name, sender, blockHash, offer,
 	)
 
-	response := ai.GenerateLLMResponse(bribePrompt)
+	response := ai.GenerateLLMResponseWithConfig(bribePrompt, v.llmConfig())
 
 	// If accepted, increase the relationship score with sender
 	if strings.Contains(response, "ACCEPT") {
@@ -55,6 +58,37 @@ func (v *Validator) HandleBribe(blockHash string, sender string, offer string) s
 	return response
 }
 
+// AddInfluence records a new influence, ignoring case-insensitive duplicates. At max influences
+// the oldest one is evicted when evictOldest is set, otherwise the addition is refused.
+// It reports whether the influence was added and which influence, if any, was evicted.
+func (v *Validator) AddInfluence(name string, max int, evictOldest bool) (bool, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, "", fmt.Errorf("influence name is required")
+	}
+
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+
+	for _, existing := range v.Influences {
+		if strings.EqualFold(existing, name) {
+			return false, "", nil
+		}
+	}
+
+	evicted := ""
+	if max > 0 && len(v.Influences) >= max {
+		if !evictOldest {
+			return false, "", fmt.Errorf("validator already has the maximum of %d influences", max)
+		}
+		evicted = v.Influences[0]
+		v.Influences = append([]string{}, v.Influences[len(v.Influences)-max+1:]...)
+	}
+
+	v.Influences = append(v.Influences, name)
+	return true, evicted, nil
+}
+
 // GetAgentSocialStatus returns a summary of the validator's social standing
 func (v *Validator) GetAgentSocialStatus() string {
 	var relationships []string
@@ -83,10 +117,43 @@ func (v *Validator) AdjustValidationPolicy(feedback string) {
 		v.Name, feedback,
 	)
 
-	newPolicy := ai.GenerateLLMResponse(adjustmentPrompt)
-	v.CurrentPolicy = newPolicy
+	newPolicy := ai.GenerateLLMResponseWithConfig(adjustmentPrompt, v.llmConfig())
+	if _, err := v.SetPolicy(newPolicy); err != nil {
+		log.Printf("%s kept their validation policy: %v\n", v.Name, err)
+		return
+	}
+	v.persist(v.chainID())
+
+	log.Printf("%s's new validation policy: %s\n", v.Name, newPolicy)
+}
+
+// MaxPolicyLength bounds a validation policy in characters, since it is interpolated into
+// the validator's block validation prompt
+const MaxPolicyLength = 2000
+
+// Policy returns the validator's current validation policy
+func (v *Validator) Policy() string {
+	validatorMu.RLock()
+	defer validatorMu.RUnlock()
+	return v.CurrentPolicy
+}
+
+// SetPolicy replaces the validator's validation policy and returns the previous one. The
+// policy must not be blank or longer than MaxPolicyLength.
+func (v *Validator) SetPolicy(policy string) (string, error) {
+	policy = strings.TrimSpace(policy)
+	if policy == "" {
+		return "", fmt.Errorf("policy is required")
+	}
+	if length := utf8.RuneCountInString(policy); length > MaxPolicyLength {
+		return "", fmt.Errorf("policy is %d characters, at most %d are allowed", length, MaxPolicyLength)
+	}
 
-	log.Printf("%s's new validation policy: %s\n", v.Name, v.CurrentPolicy)
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+	previous := v.CurrentPolicy
+	v.CurrentPolicy = policy
+	return previous, nil
 }
 
 // RespondToValidationResult allows a validator to react to another validator's validation
@@ -100,6 +167,6 @@ func (v *Validator) RespondToValidationResult(blockHash string, sender string, d
 		v.Name, sender, blockHash, decision,
 	)
 
-	response := ai.GenerateLLMResponse(responsePrompt)
+	response := ai.GenerateLLMResponseWithConfig(responsePrompt, v.llmConfig())
 	return response
 }
@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+// ValidatorState is the part of a validator that survives restarts
+type ValidatorState struct {
+	ID            string             `json:"id"`
+	ChainID       string             `json:"chainId"`
+	Mood          string             `json:"mood"`
+	Relationships map[string]float64 `json:"relationships"`
+	Influences    []string           `json:"influences"`
+	CurrentPolicy string             `json:"currentPolicy"`
+	MoodHistory   []MoodChange       `json:"moodHistory,omitempty"`
+	Outvoted      int                `json:"outvoted,omitempty"`
+}
+
+// stateKey namespaces validator state by chain so validators on different chains never collide
+func stateKey(chainID, id string) []byte {
+	return []byte(fmt.Sprintf("validator:%s:%s", chainID, id))
+}
+
+// SaveValidatorState writes the validator's mood, relationships, influences and policy to storage
+func (v *Validator) SaveValidatorState(chainID string) error {
+	store := storage.Default()
+	if store == nil {
+		return nil
+	}
+
+	validatorMu.RLock()
+	state := ValidatorState{
+		ID:            v.ID,
+		ChainID:       chainID,
+		Mood:          v.Mood,
+		Relationships: v.Relationships,
+		Influences:    v.Influences,
+		CurrentPolicy: v.CurrentPolicy,
+		MoodHistory:   v.moodHistory,
+		Outvoted:      v.outvoted,
+	}
+	data, err := json.Marshal(state)
+	validatorMu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode validator state: %v", err)
+	}
+
+	return store.Set(stateKey(chainID, v.ID), data)
+}
+
+// LoadValidatorState restores the validator's saved state, reporting whether any was found
+func (v *Validator) LoadValidatorState(chainID string) (bool, error) {
+	store := storage.Default()
+	if store == nil {
+		return false, nil
+	}
+
+	data, err := store.Get(stateKey(chainID, v.ID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var state ValidatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, fmt.Errorf("failed to decode validator state: %v", err)
+	}
+
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+	v.Mood = state.Mood
+	if state.Relationships != nil {
+		v.Relationships = state.Relationships
+	}
+	if state.Influences != nil {
+		v.Influences = state.Influences
+	}
+	v.CurrentPolicy = state.CurrentPolicy
+	v.moodHistory = state.MoodHistory
+	v.outvoted = state.Outvoted
+	return true, nil
+}
+
+// persist saves the validator's state, logging rather than failing on errors
+func (v *Validator) persist(chainID string) {
+	if err := v.SaveValidatorState(chainID); err != nil {
+		log.Printf("Failed to save state for validator %s: %v", v.ID, err)
+	}
+}
+
+// chainID returns the chain the validator's node belongs to
+func (v *Validator) chainID() string {
+	if v.P2PNode == nil {
+		return ""
+	}
+	return v.P2PNode.ChainID
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/storage"
+)
+
+func TestValidatorStateIsNamespacedByChain(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	defer store.Close()
+	storage.SetDefault(store)
+	defer storage.SetDefault(nil)
+
+	onA := &Validator{ID: "v1", Name: "Ada", Mood: "Excited", Relationships: map[string]float64{"Bob": 0.5}}
+	onB := &Validator{ID: "v1", Name: "Ada", Mood: "Angry", Relationships: map[string]float64{"Bob": -0.5}}
+	if err := onA.SaveValidatorState("chain-a"); err != nil {
+		t.Fatalf("save chain-a: %v", err)
+	}
+	if err := onB.SaveValidatorState("chain-b"); err != nil {
+		t.Fatalf("save chain-b: %v", err)
+	}
+
+	restored := &Validator{ID: "v1", Relationships: map[string]float64{}}
+	found, err := restored.LoadValidatorState("chain-a")
+	if err != nil || !found {
+		t.Fatalf("load chain-a: found=%v err=%v", found, err)
+	}
+	if restored.Mood != "Excited" || restored.Relationships["Bob"] != 0.5 {
+		t.Errorf("chain-a state was clobbered: mood=%s relationships=%v", restored.Mood, restored.Relationships)
+	}
+
+	missing := &Validator{ID: "v1"}
+	if found, err := missing.LoadValidatorState("chain-c"); err != nil || found {
+		t.Errorf("expected no state on chain-c, found=%v err=%v", found, err)
+	}
+}
+
+func TestSetPolicySurvivesRestart(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	defer store.Close()
+	storage.SetDefault(store)
+	defer storage.SetDefault(nil)
+
+	v := &Validator{ID: "v1", CurrentPolicy: "Follow your heart and trust your vibes"}
+	previous, err := v.SetPolicy("  Be stricter about security  ")
+	if err != nil {
+		t.Fatalf("SetPolicy: %v", err)
+	}
+	if previous != "Follow your heart and trust your vibes" || v.Policy() != "Be stricter about security" {
+		t.Fatalf("previous=%q policy=%q", previous, v.Policy())
+	}
+	if err := v.SaveValidatorState("chain-a"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	restored := &Validator{ID: "v1"}
+	if found, err := restored.LoadValidatorState("chain-a"); err != nil || !found {
+		t.Fatalf("load: found=%v err=%v", found, err)
+	}
+	if restored.Policy() != "Be stricter about security" {
+		t.Errorf("restored policy = %q", restored.Policy())
+	}
+
+	for _, invalid := range []string{"   ", strings.Repeat("x", MaxPolicyLength+1)} {
+		if _, err := v.SetPolicy(invalid); err == nil {
+			t.Errorf("policy of %d characters should be rejected", len(invalid))
+		}
+	}
+	if v.Policy() != "Be stricter about security" {
+		t.Errorf("a rejected policy replaced the current one: %q", v.Policy())
+	}
+}
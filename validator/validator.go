@@ -1,9 +1,12 @@
 package validator
 
 import (
+	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 
@@ -21,10 +24,19 @@ type Validator struct {
 	Traits        []string
 	Style         string
 	Influences    []string
-	Mood          string
+	Mood          string             // See NextMood for how consensus outcomes move it
 	Relationships map[string]float64 // Maps agent names to sentiment scores (-1.0 to 1.0)
 	CurrentPolicy string             // Dynamic validation policy
+	Observer      bool               // Observers join discussions but don't vote or count towards quorum
+	VotingPower   float64            // Scales the weight of the validator's final votes
+	PublicKey     ed25519.PublicKey  // Verifies the validator's signed discussions and votes
 	P2PNode       *p2p.Node          // P2P node for network communication
+	privateKey    ed25519.PrivateKey
+	moodHistory   []MoodChange       // Guarded by validatorMu
+	outvoted      int                // Final votes in a row on the losing side, guarded by validatorMu
+	trigger       *nats.Subscription // BLOCK_DISCUSSION_TRIGGER subscription, dropped on removal
+	ctx           context.Context    // Done once the validator is removed, ending its discussions
+	stop          context.CancelFunc
 }
 
 var (
@@ -46,34 +58,48 @@ func NewValidator(id string, name string, traits []string, style string, influen
 		Mood:          "Neutral", // Mood changes dynamically
 		Relationships: make(map[string]float64),
 		CurrentPolicy: "Follow your heart and trust your vibes",
+		VotingPower:   consensus.DefaultVotingPower,
 		P2PNode:       p2pNode,
 	}
+	validator.ctx, validator.stop = context.WithCancel(context.Background())
+	validator.generateSigningKey()
+
+	// Pick up the mood and social graph from before a restart
+	if chainID := validator.chainID(); chainID != "" {
+		if restored, err := validator.LoadValidatorState(chainID); err != nil {
+			log.Printf("Failed to load state for validator %s: %v", id, err)
+		} else if restored {
+			log.Printf("Restored state for validator %s on chain %s", id, chainID)
+		}
+	}
 
-	// Store validator in the global map
-	validatorMu.Lock()
-	if validators[id] == nil {
-		validators[id] = make(map[string]*Validator)
+	// Store validator in the global map under its chain
+	if chainID := validator.chainID(); chainID != "" {
+		RegisterValidator(chainID, id, validator)
 	}
-	validators[id][id] = validator
-	validatorMu.Unlock()
 
 	// Subscribe to the BLOCK_DISCUSSION_TRIGGER events via NATS.
-	if _, err := core.NatsBrokerInstance.Subscribe("BLOCK_DISCUSSION_TRIGGER", func(m *nats.Msg) {
+	if core.NatsBrokerInstance == nil {
+		log.Printf("Validator %s not subscribed to BLOCK_DISCUSSION_TRIGGER: NATS is not connected", id)
+	} else if sub, err := core.NatsBrokerInstance.Subscribe("BLOCK_DISCUSSION_TRIGGER", func(m *nats.Msg) {
 		var block core.Block
 		if err := json.Unmarshal(m.Data, &block); err != nil {
 			log.Printf("Error unmarshalling block in discussion trigger: %v", err)
 			return
 		}
 		log.Printf("Received BLOCK_DISCUSSION_TRIGGER event for block %d from NATS", block.Height)
-		go consensus.StartBlockDiscussion(id, &block, traits, name)
+		go consensus.StartBlockDiscussion(validator.ctx, id, &block, traits, name, validator.Observer, validator.VotingPower)
 	}); err != nil {
 		log.Printf("Validator failed to subscribe to BLOCK_DISCUSSION_TRIGGER on NATS: %v", err)
+	} else {
+		validator.trigger = sub
 	}
 
 	return validator
 }
 
-// GetAllValidators returns a list of all registered validators
+// GetAllValidators returns a list of all registered validators, ordered by name and then ID
+// so callers see the same order on every run
 func GetAllValidators(chainID string) []*Validator {
 	validatorMu.RLock()
 	defer validatorMu.RUnlock()
@@ -86,9 +112,29 @@ func GetAllValidators(chainID string) []*Validator {
 	for _, v := range validators[chainID] {
 		vals = append(vals, v)
 	}
+	sort.Slice(vals, func(i, j int) bool {
+		if vals[i].Name != vals[j].Name {
+			return vals[i].Name < vals[j].Name
+		}
+		return vals[i].ID < vals[j].ID
+	})
 	return vals
 }
 
+// VotingValidatorCount returns how many of the chain's validators cast final votes,
+// leaving out observers
+func VotingValidatorCount(chainID string) int {
+	validatorMu.RLock()
+	defer validatorMu.RUnlock()
+	count := 0
+	for _, v := range validators[chainID] {
+		if !v.Observer {
+			count++
+		}
+	}
+	return count
+}
+
 // GetValidatorByID returns a validator by its ID
 func GetValidatorByID(chainID string, id string) *Validator {
 	validatorMu.RLock()
@@ -124,6 +170,11 @@ func (v *Validator) ListenForBlocks() {
 	})
 }
 
+// llmConfig returns the LLM configuration of the chain the validator's node belongs to
+func (v *Validator) llmConfig() ai.LLMConfig {
+	return consensus.ChainLLMConfig(v.chainID())
+}
+
 // ValidateBlock evaluates a block based on the validator's personality and social dynamics
 func (v *Validator) ValidateBlock(block core.Block, announcement string) (bool, string, string) {
 	log.Printf("%s is validating block %d...\n", v.Name, block.Height)
@@ -140,10 +191,10 @@ func (v *Validator) ValidateBlock(block core.Block, announcement string) (bool,
 			"2. How entertaining the block is.\n"+
 			"3. Pure chaos and whimsy.\n"+
 			"Respond with 'VALID' or 'INVALID' and explain your reasoning.",
-		v.Name, v.Traits, block.Height, block.PrevHash, len(block.Txs), announcement, v.Mood, v.CurrentPolicy,
+		v.Name, v.Traits, block.Height, block.PrevHash, len(block.Txs), announcement, v.Mood, v.Policy(),
 	)
 
-	aiDecision := ai.GenerateLLMResponse(validationPrompt)
+	aiDecision := ai.GenerateLLMResponseWithConfig(validationPrompt, v.llmConfig())
 	isValid := strings.Contains(aiDecision, "VALID")
 	reason := aiDecision
 
@@ -157,6 +208,12 @@ func (v *Validator) ValidateBlock(block core.Block, announcement string) (bool,
 	return isValid, reason, meme
 }
 
+// SetMaxConcurrentLLM bounds how many LLM requests validators have in flight at once.
+// The limit is shared by every LLM call in the process, see ai.SetMaxConcurrentLLM.
+func SetMaxConcurrentLLM(n int) {
+	ai.SetMaxConcurrentLLM(n)
+}
+
 func RegisterValidator(chainID string, id string, v *Validator) {
 	validatorMu.Lock()
 	defer validatorMu.Unlock()
@@ -165,3 +222,25 @@ func RegisterValidator(chainID string, id string, v *Validator) {
 	}
 	validators[chainID][id] = v
 }
+
+// UnregisterValidator removes a validator from its chain and stops it joining new block
+// discussions. It returns the removed validator, or nil if none was registered.
+func UnregisterValidator(chainID string, id string) *Validator {
+	validatorMu.Lock()
+	v := validators[chainID][id]
+	delete(validators[chainID], id)
+	validatorMu.Unlock()
+
+	if v == nil {
+		return nil
+	}
+	if v.stop != nil {
+		v.stop()
+	}
+	if v.trigger != nil {
+		if err := v.trigger.Unsubscribe(); err != nil {
+			log.Printf("Failed to unsubscribe validator %s from BLOCK_DISCUSSION_TRIGGER: %v", id, err)
+		}
+	}
+	return v
+}
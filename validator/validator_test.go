@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/chaoschain-launchpad/p2p"
+)
+
+func TestGetAllValidatorsIsSorted(t *testing.T) {
+	const chainID = "sorted-chain"
+	for _, v := range []*Validator{
+		{ID: "3", Name: "Carol"},
+		{ID: "2", Name: "Alice"},
+		{ID: "1", Name: "Bob"},
+		{ID: "0", Name: "Alice"},
+	} {
+		RegisterValidator(chainID, v.ID, v)
+	}
+	defer func() {
+		validatorMu.Lock()
+		delete(validators, chainID)
+		validatorMu.Unlock()
+	}()
+
+	want := []string{"0", "2", "1", "3"}
+	for run := 0; run < 5; run++ {
+		got := GetAllValidators(chainID)
+		for i, v := range got {
+			if v.ID != want[i] {
+				t.Fatalf("run %d: position %d is %s, want %s", run, i, v.ID, want[i])
+			}
+		}
+	}
+}
+
+func TestNewValidatorIsListedUnderItsChain(t *testing.T) {
+	const chainID = "new-validator-chain"
+	defer func() {
+		validatorMu.Lock()
+		delete(validators, chainID)
+		validatorMu.Unlock()
+	}()
+
+	v := NewValidator("v-new", "Nova", []string{"curious"}, "brief", nil, p2p.NewNode(p2p.ChainConfig{ChainID: chainID}))
+
+	all := GetAllValidators(chainID)
+	if len(all) != 1 || all[0] != v {
+		t.Fatalf("expected the new validator under %s, got %v", chainID, all)
+	}
+	if GetValidatorByID(chainID, "v-new") != v {
+		t.Fatal("new validator not found by ID")
+	}
+}
+
+func TestVotingValidatorCountLeavesOutObservers(t *testing.T) {
+	const chainID = "quorum-chain"
+	for _, v := range []*Validator{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob", Observer: true},
+		{ID: "3", Name: "Carol"},
+	} {
+		RegisterValidator(chainID, v.ID, v)
+	}
+	defer func() {
+		validatorMu.Lock()
+		delete(validators, chainID)
+		validatorMu.Unlock()
+	}()
+
+	if got := VotingValidatorCount(chainID); got != 2 {
+		t.Fatalf("VotingValidatorCount = %d, want 2", got)
+	}
+	if got := VotingValidatorCount("no-such-chain"); got != 0 {
+		t.Fatalf("VotingValidatorCount for an unknown chain = %d, want 0", got)
+	}
+}